@@ -0,0 +1,34 @@
+// Command fakegateway serves the Jumon gateway's internal LinkedIn API from canned fixtures
+// for local demos and CI. Point the MCP server at it with GATEWAY_BASE_URL and
+// GATEWAY_INTERNAL_SECRET. Faults can be injected while it runs:
+//
+//	curl -X POST localhost:8090/_fake/faults -d '{"path":"adAnalytics","status":429,"retryAfterSeconds":30,"times":1}'
+//	curl -X DELETE localhost:8090/_fake/faults
+//	curl -X DELETE localhost:8090/_fake/connections/user_123
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway/fakegateway"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8090", "listen address")
+	secret := flag.String("secret", "", "expected x-gateway-secret header (empty accepts any caller)")
+	disconnected := flag.String("disconnected", "", "comma-separated user IDs without a LinkedIn connection")
+	flag.Parse()
+
+	fake := fakegateway.New(*secret)
+	for _, userID := range strings.Split(*disconnected, ",") {
+		if userID = strings.TrimSpace(userID); userID != "" {
+			fake.Disconnect(userID)
+		}
+	}
+
+	log.Printf("fake Jumon gateway listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, fake))
+}
@@ -13,14 +13,34 @@ import (
 	"syscall"
 	"time"
 
+	"linkedin-mcp/internal/infrastructure/api/gateway"
 	"linkedin-mcp/internal/infrastructure/middleware"
 	"linkedin-mcp/internal/infrastructure/security"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-func Start() {
+const (
+	TransportHTTP  = "http"
+	TransportStdio = "stdio"
+)
+
+// Start runs the server over transport: streamable HTTP behind Clerk bearer auth, or stdio for
+// a local desktop client acting as one configured user.
+func Start(transport string) {
 	configs := readConfigs()
+	configs.ServerConfig.Transport = transport
+	switch transport {
+	case TransportHTTP:
+		startHTTP(configs)
+	case TransportStdio:
+		startStdio(configs)
+	default:
+		log.Fatalf("unknown transport %q: use %s or %s", transport, TransportHTTP, TransportStdio)
+	}
+}
+
+func startHTTP(configs Configs) {
 	components := initCommonComponents(configs)
 
 	server := initServer(configs, components)
@@ -34,32 +54,9 @@ func Start() {
 		log.Fatal(err)
 	}
 
-	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
-		return server
-	}, &mcp.StreamableHTTPOptions{JSONResponse: true})
-
-	mux := http.NewServeMux()
-	metadataPath := "/.well-known/oauth-protected-resource"
-	resourceMetadataURL := appendURLPath(configs.ServerConfig.PublicURL, metadataPath)
-	if resourceMetadataURL == "" {
-		resourceMetadataURL = metadataPath
-	}
-
-	protectedMCPHandler := middleware.RequireBearerAuth(
-		verifier,
-		resourceMetadataURL,
-		configs.AuthConfig.RequiredScope,
-		handler,
-	)
-	mux.Handle(configs.ServerConfig.Path, protectedMCPHandler)
-	mux.HandleFunc(metadataPath, oauthProtectedResourceHandler(configs))
-	mux.HandleFunc(metadataPath+configs.ServerConfig.Path, oauthProtectedResourceHandler(configs))
-
-	wrappedHandler := middleware.LoggingHandler(mux)
-
 	httpServer := &http.Server{
 		Addr:    configs.ServerConfig.BindAddress,
-		Handler: wrappedHandler,
+		Handler: initHTTPHandler(configs, server, components, verifier),
 	}
 
 	log.Printf("LinkedIn MCP server (streamable HTTP) listening on path %s (bind %s)", configs.ServerConfig.Path, configs.ServerConfig.BindAddress)
@@ -88,6 +85,60 @@ func Start() {
 	}
 }
 
+// oauthProtectedResourcePath serves the OAuth protected resource metadata (RFC 9728).
+const oauthProtectedResourcePath = "/.well-known/oauth-protected-resource"
+
+// initHTTPHandler serves MCP behind bearer auth plus the unauthenticated OAuth metadata,
+// metrics and health endpoints, and the LinkedIn OAuth callback in direct mode.
+func initHTTPHandler(configs Configs, server *mcp.Server, components Components, verifier middleware.TokenVerifier) http.Handler {
+	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
+		return server
+	}, &mcp.StreamableHTTPOptions{JSONResponse: true})
+
+	mux := http.NewServeMux()
+	resourceMetadataURL := appendURLPath(configs.ServerConfig.PublicURL, oauthProtectedResourcePath)
+	if resourceMetadataURL == "" {
+		resourceMetadataURL = oauthProtectedResourcePath
+	}
+
+	protectedMCPHandler := middleware.RequireBearerAuth(
+		verifier,
+		resourceMetadataURL,
+		configs.AuthConfig.RequiredScope,
+		apiVersionOverrideHandler(handler),
+	)
+	mux.Handle(configs.ServerConfig.Path, protectedMCPHandler)
+	mux.HandleFunc(oauthProtectedResourcePath, oauthProtectedResourceHandler(configs))
+	mux.HandleFunc(oauthProtectedResourcePath+configs.ServerConfig.Path, oauthProtectedResourceHandler(configs))
+	if configs.RateLimitConfig.MetricsPath != "" {
+		mux.Handle(configs.RateLimitConfig.MetricsPath, components.limiter.Metrics())
+	}
+	mux.HandleFunc(configs.ServerConfig.HealthPath, healthHandler(components.breakerStates))
+	if components.directProvider != nil {
+		mux.Handle(configs.LinkedInConfigs.Direct.CallbackPath(), components.directProvider.CallbackHandler())
+	}
+
+	return middleware.LoggingHandler(mux)
+}
+
+// apiVersionOverrideHandler lets an MCP request carry a LinkedIn-Version header that replaces
+// LINKEDIN_API_VERSION for the LinkedIn calls and resources it triggers, so an upcoming
+// version can be tried from one client before the server switches over.
+func apiVersionOverrideHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := strings.TrimSpace(r.Header.Get(gateway.HeaderLinkedInVersion))
+		if version == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := gateway.ValidateAPIVersion(version); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(gateway.WithAPIVersionOverride(r.Context(), version)))
+	})
+}
+
 func oauthProtectedResourceHandler(configs Configs) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		publicURL := strings.TrimRight(configs.ServerConfig.PublicURL, "/")
@@ -120,6 +171,29 @@ func oauthProtectedResourceHandler(configs Configs) http.HandlerFunc {
 	}
 }
 
+// healthHandler reports the gateway circuit breakers. It answers 200 even when a breaker is
+// open: the server itself is healthy and keeps failing gateway calls fast with a clear tool
+// error, so the status field says "degraded" instead.
+func healthHandler(breakerStates func() map[string]gateway.BreakerState) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		states := breakerStates()
+		status := "ok"
+		for _, state := range states {
+			if state.State != gateway.BreakerClosed {
+				status = "degraded"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]any{
+			"status":  status,
+			"gateway": states,
+		}); err != nil {
+			http.Error(w, "failed to write health response", http.StatusInternalServerError)
+		}
+	}
+}
+
 func appendURLPath(baseURL, path string) string {
 	base := strings.TrimRight(baseURL, "/")
 	normalizedPath := "/" + strings.TrimLeft(path, "/")
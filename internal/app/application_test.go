@@ -6,6 +6,8 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,3 +36,24 @@ func TestOAuthProtectedResourceHandler_ReturnsMetadata(t *testing.T) {
 	require.Equal(t, []interface{}{"https://clerk.example.com"}, payload["authorization_servers"])
 	require.Equal(t, []interface{}{"mcp:tools:read"}, payload["scopes_supported"])
 }
+
+func TestHealthHandler_ReportsBreakerStates(t *testing.T) {
+	states := map[string]gateway.BreakerState{
+		gateway.EndpointConnections: {State: gateway.BreakerClosed},
+		gateway.EndpointProxy:       {State: gateway.BreakerOpen, ConsecutiveFailures: 5},
+	}
+
+	res := httptest.NewRecorder()
+	healthHandler(func() map[string]gateway.BreakerState { return states }).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	require.Equal(t, http.StatusOK, res.Code)
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &payload))
+	require.Equal(t, "degraded", payload["status"])
+	require.Equal(t, "open", payload["gateway"].(map[string]any)["proxy"].(map[string]any)["state"])
+
+	res = httptest.NewRecorder()
+	healthHandler(func() map[string]gateway.BreakerState { return nil }).ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &payload))
+	require.Equal(t, "ok", payload["status"])
+}
@@ -1,8 +1,21 @@
 package app
 
 import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api/direct"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/ratelimit"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics"
 )
 
 type Configs struct {
@@ -10,10 +23,49 @@ type Configs struct {
 	AuthConfig      AuthConfig
 	GatewayConfig   GatewayConfig
 	ServerConfig    ServerConfig
+	GuardrailConfig GuardrailConfig
+	AnalyticsConfig AnalyticsConfig
+	CacheConfig     CacheConfig
+	RateLimitConfig RateLimitConfig
 }
 
+const (
+	LinkedInProviderGateway = "gateway"
+	LinkedInProviderDirect  = "direct"
+
+	defaultLinkedInCallbackPath = "/oauth/linkedin/callback"
+)
+
 type LinkedInConfigs struct {
 	BaseURL string
+	// APIVersion is the LinkedIn-Version (YYYYMM) pinned on every LinkedIn call. MCP requests
+	// may override it per request with the same header.
+	APIVersion string
+	// Provider selects how LinkedIn is reached: through the Jumon gateway, or directly with
+	// OAuth tokens this server keeps itself (see Direct).
+	Provider string
+	Direct   DirectConfig
+}
+
+// DirectConfig configures direct mode: the LinkedIn developer application used for the OAuth
+// authorization code flow and the encrypted per-user token store.
+type DirectConfig struct {
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string
+	Scopes         []string
+	TokenStorePath string
+	// TokenEncryptionKey encrypts the token store and signs OAuth states.
+	TokenEncryptionKey []byte
+}
+
+// CallbackPath is the path of RedirectURL, where the server receives the authorization code.
+func (c DirectConfig) CallbackPath() string {
+	parsed, err := url.Parse(c.RedirectURL)
+	if err != nil || parsed.Path == "" {
+		return defaultLinkedInCallbackPath
+	}
+	return parsed.Path
 }
 
 type AuthConfig struct {
@@ -22,18 +74,93 @@ type AuthConfig struct {
 	ClerkAudience    string
 	RequiredScope    string
 	AuthorizationURL string
+	// StdioUserID or StdioAccessToken identify the single user of the stdio transport, which
+	// has no bearer token per request. The access token is verified with Clerk at startup.
+	StdioUserID      string
+	StdioAccessToken string
 }
 
 type GatewayConfig struct {
 	BaseURL        string
 	InternalSecret string
 	ConnectURL     string
+	// ConnectionStateTTL is how long a confirmed LinkedIn connection is trusted per user
+	// before the gateway is asked again.
+	ConnectionStateTTL time.Duration
+	// BreakerFailureThreshold consecutive failures open a gateway endpoint's circuit breaker
+	// for BreakerOpenDuration; 0 disables the breaker.
+	BreakerFailureThreshold int
+	BreakerOpenDuration     time.Duration
+	// RecordFile, when set, appends every gateway request/response pair to this cassette with
+	// user IDs and secrets redacted. ReplayFile serves gateway calls from a cassette instead.
+	RecordFile string
+	ReplayFile string
 }
 
 type ServerConfig struct {
+	// Transport is TransportHTTP or TransportStdio, chosen with the --transport flag.
+	Transport   string
 	BindAddress string
 	Path        string
 	PublicURL   string
+	// HealthPath reports the gateway circuit breakers without authentication.
+	HealthPath string
+}
+
+// GuardrailConfig bounds the campaign budget changes mutating tools may apply.
+// Ceilings are keyed by ISO currency code.
+type GuardrailConfig struct {
+	MaxBudgetChangePercent float64
+	MaxDailyBudget         map[string]float64
+	MaxTotalBudget         map[string]float64
+	MaxUnitCost            map[string]float64
+}
+
+// AnalyticsConfig bounds get_analytics result sizes and sets the time zones relative date
+// ranges are resolved in.
+type AnalyticsConfig struct {
+	// MaxRows is the hard ceiling on elements merged by autoPaginate.
+	MaxRows int
+	// DefaultTimeZone and AccountTimeZones are IANA zone names; AccountTimeZones is keyed by
+	// numeric ad account ID and takes precedence over DefaultTimeZone.
+	DefaultTimeZone  string
+	AccountTimeZones map[string]string
+	// DerivedMetrics maps metric names to arithmetic expressions over raw fields, offered to
+	// every caller alongside the built-in derived metrics.
+	DerivedMetrics map[string]string
+	// ExportTTL is how long export_analytics resources stay readable.
+	ExportTTL time.Duration
+}
+
+// Response cache backends selectable with CACHE_BACKEND.
+const (
+	CacheBackendMemory = "memory"
+	CacheBackendDisk   = "disk"
+	CacheBackendNone   = "none"
+)
+
+// CacheConfig selects where LinkedIn read responses are cached and for how long. A zero TTL
+// disables caching for that kind of resource.
+type CacheConfig struct {
+	Backend string
+	// Dir is the cache directory of the disk backend.
+	Dir string
+	// MaxBytes bounds the total size of cached responses.
+	MaxBytes int64
+	// RecentAnalyticsTTL applies to analytics ranges that include the last few days,
+	// HistoricalAnalyticsTTL to closed ranges before them and MetadataTTL to entity reads.
+	RecentAnalyticsTTL     time.Duration
+	HistoricalAnalyticsTTL time.Duration
+	MetadataTTL            time.Duration
+}
+
+// RateLimitConfig bounds each user's tool calls. Default applies to every tool not listed in
+// Tools; zero fields disable that bound.
+type RateLimitConfig struct {
+	Default ratelimit.Limit
+	Tools   map[string]ratelimit.Limit
+	// MetricsPath serves the limiter counters in Prometheus text format; empty disables it.
+	MetricsPath string
 }
 
 func readConfigs() Configs {
@@ -55,30 +182,373 @@ func readConfigs() Configs {
 		authorizationURL = clerkIssuer
 	}
 
+	publicURL := strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL"))
+	linkedInConfigs := readLinkedInConfigs(publicURL)
+	// Paths the health and metrics endpoints must not take over; ServeMux panics on a
+	// duplicate pattern.
+	reservedPaths := map[string]string{
+		path:                              "MCP_SERVER_PATH",
+		oauthProtectedResourcePath:        "the OAuth protected resource metadata",
+		oauthProtectedResourcePath + path: "the OAuth protected resource metadata",
+	}
+	if linkedInConfigs.Provider == LinkedInProviderDirect {
+		reservedPaths[linkedInConfigs.Direct.CallbackPath()] = "the LinkedIn OAuth callback"
+	}
+	health := healthPath(reservedPaths)
+	reservedPaths[health] = "HEALTH_PATH"
+
 	return Configs{
-		LinkedInConfigs: LinkedInConfigs{
-			BaseURL: "https://api.linkedin.com/rest",
-		},
+		LinkedInConfigs: linkedInConfigs,
 		AuthConfig: AuthConfig{
 			ClerkIssuer:      clerkIssuer,
 			ClerkJWKSURL:     strings.TrimSpace(os.Getenv("CLERK_JWKS_URL")),
 			ClerkAudience:    strings.TrimSpace(os.Getenv("CLERK_AUDIENCE")),
 			RequiredScope:    strings.TrimSpace(os.Getenv("MCP_REQUIRED_SCOPE")),
 			AuthorizationURL: authorizationURL,
+			StdioUserID:      stdioUserID(),
+			StdioAccessToken: strings.TrimSpace(os.Getenv("MCP_STDIO_ACCESS_TOKEN")),
 		},
 		GatewayConfig: GatewayConfig{
-			BaseURL:        gatewayBaseURL(),
-			InternalSecret: gatewayInternalSecret(),
-			ConnectURL:     deriveConnectURL(gatewayBaseURL(), connectURLExplicit()),
+			BaseURL:                 gatewayBaseURL(),
+			InternalSecret:          gatewayInternalSecret(),
+			ConnectURL:              deriveConnectURL(gatewayBaseURL(), connectURLExplicit()),
+			ConnectionStateTTL:      gatewayConnectionStateTTL(),
+			BreakerFailureThreshold: gatewayBreakerFailureThreshold(),
+			BreakerOpenDuration:     gatewayBreakerOpenDuration(),
+			RecordFile:              gatewayRecordFile(),
+			ReplayFile:              gatewayReplayFile(),
 		},
 		ServerConfig: ServerConfig{
 			BindAddress: host + ":" + port,
 			Path:        path,
-			PublicURL:   strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL")),
+			PublicURL:   publicURL,
+			HealthPath:  health,
 		},
+		GuardrailConfig: readGuardrailConfig(),
+		AnalyticsConfig: readAnalyticsConfig(),
+		CacheConfig:     readCacheConfig(),
+		RateLimitConfig: readRateLimitConfig(reservedPaths),
 	}
 }
 
+func readLinkedInConfigs(publicURL string) LinkedInConfigs {
+	configs := LinkedInConfigs{
+		BaseURL:    "https://api.linkedin.com/rest",
+		APIVersion: strings.TrimSpace(envOrDefault("LINKEDIN_API_VERSION", gateway.DefaultAPIVersion)),
+		Provider:   strings.ToLower(strings.TrimSpace(envOrDefault("LINKEDIN_PROVIDER", LinkedInProviderGateway))),
+	}
+	if err := gateway.ValidateAPIVersion(configs.APIVersion); err != nil {
+		log.Fatalf("LINKEDIN_API_VERSION: %v", err)
+	}
+	switch configs.Provider {
+	case LinkedInProviderGateway:
+		return configs
+	case LinkedInProviderDirect:
+	default:
+		log.Fatalf("LINKEDIN_PROVIDER must be %s or %s", LinkedInProviderGateway, LinkedInProviderDirect)
+	}
+
+	redirectURL := strings.TrimSpace(os.Getenv("LINKEDIN_REDIRECT_URL"))
+	if redirectURL == "" {
+		redirectURL = appendURLPath(publicURL, defaultLinkedInCallbackPath)
+	}
+	key, err := parseEncryptionKey(os.Getenv("LINKEDIN_TOKEN_ENCRYPTION_KEY"))
+	if err != nil {
+		log.Fatalf("LINKEDIN_TOKEN_ENCRYPTION_KEY: %v", err)
+	}
+	configs.Direct = DirectConfig{
+		ClientID:           strings.TrimSpace(os.Getenv("LINKEDIN_CLIENT_ID")),
+		ClientSecret:       strings.TrimSpace(os.Getenv("LINKEDIN_CLIENT_SECRET")),
+		RedirectURL:        redirectURL,
+		Scopes:             parseScopes(os.Getenv("LINKEDIN_SCOPES")),
+		TokenStorePath:     strings.TrimSpace(envOrDefault("LINKEDIN_TOKEN_STORE_PATH", "linkedin-tokens.enc")),
+		TokenEncryptionKey: key,
+	}
+	if configs.Direct.ClientID == "" || configs.Direct.ClientSecret == "" {
+		log.Fatalf("LINKEDIN_CLIENT_ID and LINKEDIN_CLIENT_SECRET are required when LINKEDIN_PROVIDER=%s", LinkedInProviderDirect)
+	}
+	if configs.Direct.RedirectURL == "" {
+		log.Fatalf("LINKEDIN_REDIRECT_URL or PUBLIC_BASE_URL is required when LINKEDIN_PROVIDER=%s", LinkedInProviderDirect)
+	}
+	return configs
+}
+
+// parseEncryptionKey decodes a base64 key of direct.KeySize bytes, e.g. from
+// `openssl rand -base64 32`.
+func parseEncryptionKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("required when LINKEDIN_PROVIDER=%s; generate one with `openssl rand -base64 32`", LinkedInProviderDirect)
+	}
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("must be base64: %w", err)
+	}
+	if len(key) != direct.KeySize {
+		return nil, fmt.Errorf("must decode to %d bytes, got %d", direct.KeySize, len(key))
+	}
+	return key, nil
+}
+
+// parseScopes splits a comma or space separated scope list; empty means direct.DefaultScopes.
+func parseScopes(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+}
+
+func readCacheConfig() CacheConfig {
+	backend := strings.ToLower(strings.TrimSpace(envOrDefault("CACHE_BACKEND", CacheBackendMemory)))
+	if backend != CacheBackendMemory && backend != CacheBackendDisk && backend != CacheBackendNone {
+		log.Fatalf("CACHE_BACKEND must be one of: %s, %s, %s", CacheBackendMemory, CacheBackendDisk, CacheBackendNone)
+	}
+	maxBytes, err := strconv.ParseInt(strings.TrimSpace(envOrDefault("CACHE_MAX_BYTES", "67108864")), 10, 64)
+	if err != nil || maxBytes < 1 {
+		log.Fatalf("CACHE_MAX_BYTES must be a positive integer")
+	}
+
+	ttl := func(key, fallback string) time.Duration {
+		parsed, err := parseNonNegativeDuration(envOrDefault(key, fallback))
+		if err != nil {
+			log.Fatalf("%s: %v", key, err)
+		}
+		return parsed
+	}
+
+	return CacheConfig{
+		Backend:                backend,
+		Dir:                    strings.TrimSpace(envOrDefault("CACHE_DIR", filepath.Join(os.TempDir(), "linkedin-mcp-cache"))),
+		MaxBytes:               maxBytes,
+		RecentAnalyticsTTL:     ttl("CACHE_TTL_RECENT_ANALYTICS", "5m"),
+		HistoricalAnalyticsTTL: ttl("CACHE_TTL_HISTORICAL_ANALYTICS", "24h"),
+		MetadataTTL:            ttl("CACHE_TTL_METADATA", "15m"),
+	}
+}
+
+// parseNonNegativeDuration parses a Go duration such as "30s" or "2h"; "0" is allowed.
+func parseNonNegativeDuration(raw string) (time.Duration, error) {
+	duration, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, expected a value such as 30s or 2h", raw)
+	}
+	if duration < 0 {
+		return 0, fmt.Errorf("duration %q cannot be negative", raw)
+	}
+	return duration, nil
+}
+
+func readRateLimitConfig(reservedPaths map[string]string) RateLimitConfig {
+	perMinute, err := strconv.ParseFloat(strings.TrimSpace(envOrDefault("RATE_LIMIT_PER_MINUTE", "60")), 64)
+	if err != nil || perMinute < 0 {
+		log.Fatalf("RATE_LIMIT_PER_MINUTE must be a non-negative number")
+	}
+	count := func(key, fallback string) int {
+		value, err := strconv.Atoi(strings.TrimSpace(envOrDefault(key, fallback)))
+		if err != nil || value < 0 {
+			log.Fatalf("%s must be a non-negative integer", key)
+		}
+		return value
+	}
+	tools, err := parseToolLimits(os.Getenv("RATE_LIMIT_TOOLS"))
+	if err != nil {
+		log.Fatalf("RATE_LIMIT_TOOLS: %v", err)
+	}
+	rawMetricsPath, ok := os.LookupEnv("METRICS_PATH")
+	if !ok {
+		rawMetricsPath = "/metrics"
+	}
+	metricsPath, err := parseEndpointPath(rawMetricsPath, reservedPaths)
+	if err != nil {
+		log.Fatalf("METRICS_PATH: %v", err)
+	}
+
+	return RateLimitConfig{
+		Default: ratelimit.Limit{
+			PerMinute:   perMinute,
+			Burst:       count("RATE_LIMIT_BURST", "10"),
+			MaxInFlight: count("RATE_LIMIT_MAX_IN_FLIGHT", "4"),
+		},
+		Tools:       tools,
+		MetricsPath: metricsPath,
+	}
+}
+
+// parseEndpointPath normalizes the path of an optional endpoint such as METRICS_PATH. An
+// empty value disables the endpoint; a path in reservedPaths, which maps the paths other
+// endpoints are served on to their owners, is rejected.
+func parseEndpointPath(raw string, reservedPaths map[string]string) (string, error) {
+	path := strings.TrimSpace(raw)
+	if path == "" {
+		return "", nil
+	}
+	if strings.ContainsAny(path, " \t") {
+		return "", fmt.Errorf("%q must not contain spaces", path)
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if owner, taken := reservedPaths[path]; taken {
+		return "", fmt.Errorf("%q is already used by %s", path, owner)
+	}
+	return path, nil
+}
+
+// parseToolLimits parses "get_analytics=30/5/2,export_analytics_report=6/2/1" into per-tool
+// limits given as PER_MINUTE/BURST/MAX_IN_FLIGHT, where 0 disables that bound.
+func parseToolLimits(raw string) (map[string]ratelimit.Limit, error) {
+	limits := map[string]ratelimit.Limit{}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		tool, spec, ok := strings.Cut(entry, "=")
+		tool = strings.TrimSpace(tool)
+		parts := strings.Split(spec, "/")
+		if !ok || tool == "" || len(parts) != 3 {
+			return nil, fmt.Errorf("invalid entry %q, expected TOOL=PER_MINUTE/BURST/MAX_IN_FLIGHT", entry)
+		}
+		perMinute, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		if err != nil || perMinute < 0 {
+			return nil, fmt.Errorf("invalid rate in %q", entry)
+		}
+		burst, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || burst < 0 {
+			return nil, fmt.Errorf("invalid burst in %q", entry)
+		}
+		maxInFlight, err := strconv.Atoi(strings.TrimSpace(parts[2]))
+		if err != nil || maxInFlight < 0 {
+			return nil, fmt.Errorf("invalid max in-flight in %q", entry)
+		}
+		limits[tool] = ratelimit.Limit{PerMinute: perMinute, Burst: burst, MaxInFlight: maxInFlight}
+	}
+	return limits, nil
+}
+
+func readAnalyticsConfig() AnalyticsConfig {
+	maxRows, err := strconv.Atoi(strings.TrimSpace(envOrDefault("ANALYTICS_MAX_ROWS", "10000")))
+	if err != nil || maxRows < 1 {
+		log.Fatalf("ANALYTICS_MAX_ROWS must be a positive integer")
+	}
+
+	defaultTimeZone := strings.TrimSpace(envOrDefault("ANALYTICS_TIME_ZONE", "UTC"))
+	if _, err := time.LoadLocation(defaultTimeZone); err != nil {
+		log.Fatalf("ANALYTICS_TIME_ZONE: %v", err)
+	}
+	accountTimeZones, err := parseAccountTimeZones(os.Getenv("ANALYTICS_ACCOUNT_TIME_ZONES"))
+	if err != nil {
+		log.Fatalf("ANALYTICS_ACCOUNT_TIME_ZONES: %v", err)
+	}
+	derivedMetrics, err := parseDerivedMetrics(os.Getenv("ANALYTICS_DERIVED_METRICS"))
+	if err != nil {
+		log.Fatalf("ANALYTICS_DERIVED_METRICS: %v", err)
+	}
+	exportTTL, err := time.ParseDuration(strings.TrimSpace(envOrDefault("ANALYTICS_EXPORT_TTL", "1h")))
+	if err != nil || exportTTL <= 0 {
+		log.Fatalf("ANALYTICS_EXPORT_TTL must be a positive duration such as 30m or 2h")
+	}
+
+	return AnalyticsConfig{
+		MaxRows:          maxRows,
+		DefaultTimeZone:  defaultTimeZone,
+		AccountTimeZones: accountTimeZones,
+		DerivedMetrics:   derivedMetrics,
+		ExportTTL:        exportTTL,
+	}
+}
+
+// parseDerivedMetrics parses "name=expression;name2=expression2" into a name-to-expression
+// map. Entries are separated by semicolons because expressions may contain commas.
+func parseDerivedMetrics(raw string) (map[string]string, error) {
+	metrics := map[string]string{}
+	for _, entry := range strings.Split(raw, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, expression, ok := strings.Cut(entry, "=")
+		name, expression = strings.TrimSpace(name), strings.TrimSpace(expression)
+		if !ok || name == "" || expression == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected NAME=EXPRESSION", entry)
+		}
+		if _, exists := metrics[name]; exists {
+			return nil, fmt.Errorf("duplicate metric %q", name)
+		}
+		metrics[name] = expression
+	}
+	if err := getanalytics.ValidateDerivedMetrics(metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+// parseAccountTimeZones parses "512345678:America/New_York,598765432:Europe/Berlin" into an
+// account-to-zone map, rejecting zones the runtime cannot load.
+func parseAccountTimeZones(raw string) (map[string]string, error) {
+	zones := map[string]string{}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		accountID, zone, ok := strings.Cut(entry, ":")
+		accountID, zone = strings.TrimSpace(accountID), strings.TrimSpace(zone)
+		if !ok || accountID == "" || zone == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected ACCOUNT_ID:TIME_ZONE", entry)
+		}
+		if _, err := time.LoadLocation(zone); err != nil {
+			return nil, fmt.Errorf("invalid time zone in %q: %w", entry, err)
+		}
+		zones[accountID] = zone
+	}
+	return zones, nil
+}
+
+func readGuardrailConfig() GuardrailConfig {
+	maxChange, err := strconv.ParseFloat(strings.TrimSpace(envOrDefault("CAMPAIGN_MAX_BUDGET_CHANGE_PERCENT", "50")), 64)
+	if err != nil || maxChange < 0 {
+		log.Fatalf("CAMPAIGN_MAX_BUDGET_CHANGE_PERCENT must be a non-negative number")
+	}
+
+	ceilings := func(key string) map[string]float64 {
+		parsed, err := parseCurrencyCeilings(os.Getenv(key))
+		if err != nil {
+			log.Fatalf("%s: %v", key, err)
+		}
+		return parsed
+	}
+
+	return GuardrailConfig{
+		MaxBudgetChangePercent: maxChange,
+		MaxDailyBudget:         ceilings("CAMPAIGN_MAX_DAILY_BUDGET"),
+		MaxTotalBudget:         ceilings("CAMPAIGN_MAX_TOTAL_BUDGET"),
+		MaxUnitCost:            ceilings("CAMPAIGN_MAX_UNIT_COST"),
+	}
+}
+
+// parseCurrencyCeilings parses "USD:1000,EUR:900" into a currency-to-amount map.
+func parseCurrencyCeilings(raw string) (map[string]float64, error) {
+	ceilings := map[string]float64{}
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		currency, amountRaw, ok := strings.Cut(entry, ":")
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if !ok || currency == "" {
+			return nil, fmt.Errorf("invalid ceiling %q, expected CURRENCY:AMOUNT", entry)
+		}
+		amount, err := strconv.ParseFloat(strings.TrimSpace(amountRaw), 64)
+		// A NaN or infinite ceiling would silently turn the cap off for that currency.
+		if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) || amount <= 0 {
+			return nil, fmt.Errorf("invalid amount in ceiling %q", entry)
+		}
+		ceilings[currency] = amount
+	}
+	return ceilings, nil
+}
+
 func gatewayBaseURL() string {
 	if v := strings.TrimSpace(os.Getenv("GATEWAY_BASE_URL")); v != "" {
 		return v
@@ -93,6 +563,61 @@ func gatewayInternalSecret() string {
 	return strings.TrimSpace(os.Getenv("JUMON_GATEWAY_INTERNAL_SECRET"))
 }
 
+func gatewayConnectionStateTTL() time.Duration {
+	ttl, err := parseNonNegativeDuration(envOrDefault("GATEWAY_CONNECTION_STATE_TTL", "1m"))
+	if err != nil {
+		log.Fatalf("GATEWAY_CONNECTION_STATE_TTL: %v", err)
+	}
+	return ttl
+}
+
+func gatewayBreakerFailureThreshold() int {
+	threshold, err := strconv.Atoi(strings.TrimSpace(envOrDefault("GATEWAY_BREAKER_FAILURE_THRESHOLD", "5")))
+	if err != nil || threshold < 0 {
+		log.Fatalf("GATEWAY_BREAKER_FAILURE_THRESHOLD must be a non-negative integer")
+	}
+	return threshold
+}
+
+func gatewayBreakerOpenDuration() time.Duration {
+	duration, err := time.ParseDuration(strings.TrimSpace(envOrDefault("GATEWAY_BREAKER_OPEN_DURATION", "30s")))
+	if err != nil || duration <= 0 {
+		log.Fatalf("GATEWAY_BREAKER_OPEN_DURATION must be a positive duration such as 30s or 1m")
+	}
+	return duration
+}
+
+func stdioUserID() string {
+	userID := strings.TrimSpace(os.Getenv("MCP_STDIO_USER_ID"))
+	if userID != "" && strings.TrimSpace(os.Getenv("MCP_STDIO_ACCESS_TOKEN")) != "" {
+		log.Fatalf("MCP_STDIO_USER_ID and MCP_STDIO_ACCESS_TOKEN cannot both be set")
+	}
+	return userID
+}
+
+func gatewayRecordFile() string {
+	recordFile := strings.TrimSpace(os.Getenv("GATEWAY_RECORD_FILE"))
+	if recordFile != "" && gatewayReplayFile() != "" {
+		log.Fatalf("GATEWAY_RECORD_FILE and GATEWAY_REPLAY_FILE cannot both be set")
+	}
+	return recordFile
+}
+
+func gatewayReplayFile() string {
+	return strings.TrimSpace(os.Getenv("GATEWAY_REPLAY_FILE"))
+}
+
+func healthPath(reservedPaths map[string]string) string {
+	path, err := parseEndpointPath(envOrDefault("HEALTH_PATH", "/healthz"), reservedPaths)
+	if err != nil {
+		log.Fatalf("HEALTH_PATH: %v", err)
+	}
+	if path == "" {
+		return "/healthz"
+	}
+	return path
+}
+
 func connectURLExplicit() string {
 	if v := strings.TrimSpace(os.Getenv("GATEWAY_CONNECT_URL")); v != "" {
 		return v
@@ -1,7 +1,12 @@
 package app
 
 import (
+	"bytes"
+	"encoding/base64"
 	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/ratelimit"
 
 	"github.com/stretchr/testify/require"
 )
@@ -22,3 +27,137 @@ func TestDeriveConnectURL(t *testing.T) {
 		require.Equal(t, "/connections", url)
 	})
 }
+
+func TestParseCurrencyCeilings(t *testing.T) {
+	ceilings, err := parseCurrencyCeilings(" usd:1000, EUR:900.5 ,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"USD": 1000, "EUR": 900.5}, ceilings)
+
+	ceilings, err = parseCurrencyCeilings("")
+	require.NoError(t, err)
+	require.Empty(t, ceilings)
+
+	_, err = parseCurrencyCeilings("USD")
+	require.Error(t, err)
+
+	_, err = parseCurrencyCeilings("USD:-5")
+	require.Error(t, err)
+
+	for _, raw := range []string{"USD:NaN", "USD:Inf", "EUR:+Inf"} {
+		_, err = parseCurrencyCeilings(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestParseAccountTimeZones(t *testing.T) {
+	zones, err := parseAccountTimeZones(" 512345678:America/New_York , 598765432:Europe/Berlin")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"512345678": "America/New_York", "598765432": "Europe/Berlin"}, zones)
+
+	_, err = parseAccountTimeZones("512345678")
+	require.Error(t, err)
+
+	_, err = parseAccountTimeZones("512345678:Mars/Olympus_Mons")
+	require.Error(t, err)
+}
+
+func TestParseDerivedMetrics(t *testing.T) {
+	metrics, err := parseDerivedMetrics(" costPerQualifiedLead = costInLocalCurrency / (externalWebsiteConversions + oneClickLeads) ; leadShare=min(oneClickLeads, clicks) / clicks;")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"costPerQualifiedLead": "costInLocalCurrency / (externalWebsiteConversions + oneClickLeads)",
+		"leadShare":            "min(oneClickLeads, clicks) / clicks",
+	}, metrics)
+
+	for _, raw := range []string{"noExpression", "cpql=", "cpql=clicks / ", "CTR=clicks / impressions", "cpql=clicks;cpql=impressions", "cpql=pow(clicks, 2)"} {
+		_, err := parseDerivedMetrics(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestParseNonNegativeDuration(t *testing.T) {
+	duration, err := parseNonNegativeDuration(" 15m ")
+	require.NoError(t, err)
+	require.Equal(t, 15*time.Minute, duration)
+
+	duration, err = parseNonNegativeDuration("0")
+	require.NoError(t, err)
+	require.Zero(t, duration)
+
+	for _, raw := range []string{"", "15", "-1m", "soon"} {
+		_, err := parseNonNegativeDuration(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestParseToolLimits(t *testing.T) {
+	limits, err := parseToolLimits(" get_analytics=30/5/2, export_analytics_report=0.5/1/0 ")
+	require.NoError(t, err)
+	require.Equal(t, map[string]ratelimit.Limit{
+		"get_analytics":           {PerMinute: 30, Burst: 5, MaxInFlight: 2},
+		"export_analytics_report": {PerMinute: 0.5, Burst: 1, MaxInFlight: 0},
+	}, limits)
+
+	for _, raw := range []string{"get_analytics", "=1/1/1", "get_analytics=1/1", "get_analytics=-1/1/1", "get_analytics=1/x/1", "get_analytics=1/1/-2"} {
+		_, err := parseToolLimits(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key, err := parseEncryptionKey(" " + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{9}, 32)) + " ")
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+
+	for _, raw := range []string{"", "not base64!", base64.StdEncoding.EncodeToString([]byte("too short"))} {
+		_, err := parseEncryptionKey(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestParseScopes(t *testing.T) {
+	require.Equal(t, []string{"r_ads", "r_ads_reporting", "rw_ads"}, parseScopes("r_ads, r_ads_reporting rw_ads"))
+	require.Empty(t, parseScopes(" "))
+}
+
+func TestDirectConfigCallbackPath(t *testing.T) {
+	require.Equal(t, "/linkedin/oauth", DirectConfig{RedirectURL: "https://mcp.example.com/linkedin/oauth"}.CallbackPath())
+	require.Equal(t, defaultLinkedInCallbackPath, DirectConfig{RedirectURL: "https://mcp.example.com"}.CallbackPath())
+}
+
+func TestParseEndpointPath(t *testing.T) {
+	reserved := map[string]string{
+		"/mcp":                                  "MCP_SERVER_PATH",
+		"/healthz":                              "HEALTH_PATH",
+		"/.well-known/oauth-protected-resource": "the OAuth protected resource metadata",
+		"/.well-known/oauth-protected-resource/mcp": "the OAuth protected resource metadata",
+	}
+
+	path, err := parseEndpointPath(" metrics ", reserved)
+	require.NoError(t, err)
+	require.Equal(t, "/metrics", path)
+
+	path, err = parseEndpointPath("  ", reserved)
+	require.NoError(t, err)
+	require.Empty(t, path, "an empty value disables the endpoint")
+
+	for _, raw := range []string{"/mcp", "healthz", "/.well-known/oauth-protected-resource", "GET /metrics"} {
+		_, err := parseEndpointPath(raw, reserved)
+		require.Error(t, err, raw)
+	}
+	_, err = parseEndpointPath("/healthz", reserved)
+	require.ErrorContains(t, err, "already used by HEALTH_PATH")
+}
+
+func TestHealthPath(t *testing.T) {
+	reserved := map[string]string{"/mcp": "MCP_SERVER_PATH"}
+
+	t.Setenv("HEALTH_PATH", "")
+	require.Equal(t, "/healthz", healthPath(reserved))
+
+	t.Setenv("HEALTH_PATH", " status ")
+	require.Equal(t, "/status", healthPath(reserved))
+
+	t.Setenv("HEALTH_PATH", "  ")
+	require.Equal(t, "/healthz", healthPath(reserved))
+}
@@ -0,0 +1,249 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api/direct"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/api/gateway/fakegateway"
+	"linkedin-mcp/internal/infrastructure/ratelimit"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+const integrationUserID = "user_integration"
+
+type staticVerifier struct{}
+
+func (staticVerifier) Verify(ctx context.Context, token string) (string, error) {
+	return integrationUserID, nil
+}
+
+// bearerTransport authenticates every request and adds headers to it.
+type bearerTransport struct {
+	headers map[string]string
+}
+
+func (b bearerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer test-token")
+	for name, value := range b.headers {
+		r.Header.Set(name, value)
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// integrationConfigs points a server configuration at a fresh fake gateway.
+func integrationConfigs(t *testing.T) (*fakegateway.Server, Configs) {
+	t.Helper()
+	fake := fakegateway.New("integration-secret")
+	gatewayServer := httptest.NewServer(fake)
+	t.Cleanup(gatewayServer.Close)
+
+	configs := Configs{
+		LinkedInConfigs: LinkedInConfigs{BaseURL: "https://api.linkedin.com/rest", APIVersion: gateway.DefaultAPIVersion},
+		GatewayConfig: GatewayConfig{
+			BaseURL:            gatewayServer.URL,
+			InternalSecret:     "integration-secret",
+			ConnectURL:         gatewayServer.URL + "/connections",
+			ConnectionStateTTL: time.Minute,
+		},
+		ServerConfig:    ServerConfig{Path: "/mcp", HealthPath: "/healthz"},
+		AnalyticsConfig: AnalyticsConfig{MaxRows: 1000, DefaultTimeZone: "UTC", ExportTTL: time.Hour},
+		CacheConfig:     CacheConfig{Backend: CacheBackendNone},
+		RateLimitConfig: RateLimitConfig{Default: ratelimit.Limit{}, MetricsPath: "/metrics"},
+	}
+	return fake, configs
+}
+
+// startIntegrationServer runs the MCP server over streamable HTTP against a fake gateway and
+// returns a connected client session.
+func startIntegrationServer(t *testing.T) (*fakegateway.Server, *mcp.ClientSession) {
+	t.Helper()
+	fake, configs := integrationConfigs(t)
+	session, _ := serveIntegration(t, configs)
+	return fake, session
+}
+
+// serveIntegration serves configs over streamable HTTP and returns a connected client session
+// and the server URL.
+func serveIntegration(t *testing.T, configs Configs) (*mcp.ClientSession, string) {
+	t.Helper()
+	components := initCommonComponents(configs)
+	mcpServer := httptest.NewServer(initHTTPHandler(configs, initServer(configs, components), components, staticVerifier{}))
+	t.Cleanup(mcpServer.Close)
+	return connectIntegration(t, mcpServer.URL, nil), mcpServer.URL
+}
+
+// connectIntegration opens a client session to the server at serverURL sending headers on
+// every request.
+func connectIntegration(t *testing.T, serverURL string, headers map[string]string) *mcp.ClientSession {
+	t.Helper()
+	client := mcp.NewClient(&mcp.Implementation{Name: "integration-test", Version: "v0.0.0"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{
+		Endpoint:             serverURL + "/mcp",
+		HTTPClient:           &http.Client{Transport: bearerTransport{headers: headers}},
+		DisableStandaloneSSE: true,
+	}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name string, arguments map[string]any) (*mcp.CallToolResult, string) {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: arguments})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	return result, text.Text
+}
+
+func searchAdAccountsArguments() map[string]any {
+	return map[string]any{"accountIDs": []string{}, "status": []string{}, "references": []string{}, "names": []string{}}
+}
+
+func searchCampaignsArguments(accountID string) map[string]any {
+	return map[string]any{
+		"accountID":              accountID,
+		"campaignGroupURNs":      []string{},
+		"associatedEntityValues": []string{},
+		"campaignURNs":           []string{},
+		"status":                 []string{},
+		"type":                   []string{},
+		"name":                   []string{},
+		"sortOrder":              "ASCENDING",
+		"pageSize":               100,
+	}
+}
+
+func TestIntegration_ReadToolsServeFixtures(t *testing.T) {
+	_, session := startIntegrationServer(t)
+
+	result, text := callTool(t, session, "search_ad_accounts", searchAdAccountsArguments())
+	require.False(t, result.IsError, text)
+	require.Contains(t, text, "Acme Demo Account")
+
+	result, text = callTool(t, session, "search_campaigns", searchCampaignsArguments("512345678"))
+	require.False(t, result.IsError, text)
+	require.Contains(t, text, "Spring Launch - Lead Gen")
+
+	result, text = callTool(t, session, "get_analytics", map[string]any{
+		"accountID":       "512345678",
+		"pivot":           "CAMPAIGN",
+		"dateRangeStart":  map[string]any{"year": 2026, "month": 3, "day": 1},
+		"dateRangeEnd":    map[string]any{"year": 2026, "month": 3, "day": 2},
+		"timeGranularity": "DAILY",
+		"fields":          []string{"impressions", "clicks"},
+	})
+	require.False(t, result.IsError, text)
+	require.Contains(t, text, "urn:li:sponsoredCampaign:700000002")
+}
+
+func TestIntegration_InvalidParamScenario(t *testing.T) {
+	fake, session := startIntegrationServer(t)
+	fake.Inject(fakegateway.ParamInvalid("search", "wrong type").On("adAccounts").Once())
+
+	result, text := callTool(t, session, "search_ad_accounts", searchAdAccountsArguments())
+
+	require.True(t, result.IsError)
+	require.Contains(t, text, "LinkedIn rejected the request parameters")
+	require.Contains(t, text, "Field `search`: wrong type.")
+	require.Contains(t, text, "retry this tool call")
+}
+
+func TestIntegration_GatewayFailuresBecomeToolErrors(t *testing.T) {
+	fake, session := startIntegrationServer(t)
+
+	fake.Inject(fakegateway.RateLimited(120).On("adAccounts/512345678/adCampaigns").Once())
+	result, text := callTool(t, session, "search_campaigns", searchCampaignsArguments("512345678"))
+	require.True(t, result.IsError)
+	require.Contains(t, text, "retry after 120 seconds")
+
+	fake.Disconnect(integrationUserID)
+	result, text = callTool(t, session, "search_ad_accounts", searchAdAccountsArguments())
+	require.True(t, result.IsError)
+	require.Contains(t, text, "no LinkedIn account is connected")
+}
+
+func TestIntegration_DirectModeAsksUserToConnectLinkedIn(t *testing.T) {
+	_, configs := integrationConfigs(t)
+	configs.LinkedInConfigs.Provider = LinkedInProviderDirect
+	configs.LinkedInConfigs.Direct = DirectConfig{
+		ClientID:           "client-id",
+		ClientSecret:       "client-secret",
+		RedirectURL:        "https://mcp.example.com/oauth/linkedin/callback",
+		TokenStorePath:     filepath.Join(t.TempDir(), "tokens.enc"),
+		TokenEncryptionKey: bytes.Repeat([]byte{3}, direct.KeySize),
+	}
+	session, serverURL := serveIntegration(t, configs)
+
+	result, text := callTool(t, session, "search_ad_accounts", searchAdAccountsArguments())
+
+	require.True(t, result.IsError)
+	require.Contains(t, text, "no LinkedIn account is connected")
+	require.Contains(t, text, "https://mcp.example.com/oauth/linkedin/callback?connect=")
+
+	response, err := http.Get(serverURL + "/oauth/linkedin/callback?code=c&state=forged")
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response, err = http.Get(serverURL + "/healthz")
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+}
+
+func TestIntegration_LinkedInVersionPinnedAndOverridable(t *testing.T) {
+	fake, configs := integrationConfigs(t)
+	session, serverURL := serveIntegration(t, configs)
+
+	result, text := callTool(t, session, "search_ad_accounts", searchAdAccountsArguments())
+	require.False(t, result.IsError, text)
+	require.Equal(t, gateway.DefaultAPIVersion, lastProxyHeader(fake, gateway.HeaderLinkedInVersion))
+	require.Contains(t, readResourceText(t, session, "linkedin://analytics/metrics"), "view=li-lms-2026-03")
+
+	override := connectIntegration(t, serverURL, map[string]string{gateway.HeaderLinkedInVersion: "202606"})
+	result, text = callTool(t, override, "search_ad_accounts", searchAdAccountsArguments())
+	require.False(t, result.IsError, text)
+	require.Equal(t, "202606", lastProxyHeader(fake, gateway.HeaderLinkedInVersion))
+	resource := readResourceText(t, override, "linkedin://analytics/parameters")
+	require.Contains(t, resource, "view=li-lms-2026-06")
+	require.Contains(t, resource, `"apiVersion":"202606"`)
+
+	request, err := http.NewRequest(http.MethodPost, serverURL+"/mcp", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	request.Header.Set(gateway.HeaderLinkedInVersion, "2026-06")
+	response, err := (&http.Client{Transport: bearerTransport{}}).Do(request)
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func lastProxyHeader(fake *fakegateway.Server, name string) string {
+	requests := fake.Requests()
+	for i := len(requests) - 1; i >= 0; i-- {
+		if requests[i].Endpoint == fakegateway.EndpointProxy {
+			return requests[i].Headers[name]
+		}
+	}
+	return ""
+}
+
+func readResourceText(t *testing.T, session *mcp.ClientSession, uri string) string {
+	t.Helper()
+	result, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Contents)
+	return result.Contents[0].Text
+}
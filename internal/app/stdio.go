@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"linkedin-mcp/internal/infrastructure/middleware"
+	"linkedin-mcp/internal/infrastructure/security"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// startStdio serves the same tools over stdin/stdout for a local MCP client such as Claude
+// Desktop. There is no bearer token per request, so every call runs as the user configured
+// with MCP_STDIO_USER_ID or MCP_STDIO_ACCESS_TOKEN.
+func startStdio(configs Configs) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	userID, err := resolveStdioUserID(ctx, configs.AuthConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	components := initCommonComponents(configs)
+	server := initStdioServer(configs, components, userID)
+	if components.directProvider != nil {
+		go serveOAuthCallback(configs, components.directProvider.CallbackHandler())
+	}
+
+	log.Printf("LinkedIn MCP server (stdio) running as user %s", userID)
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && !errors.Is(err, context.Canceled) {
+		log.Fatal(err)
+	}
+}
+
+// serveOAuthCallback listens for the LinkedIn OAuth redirect in direct mode, since stdio has
+// no HTTP server of its own. A failure only affects connecting LinkedIn, so it is logged.
+func serveOAuthCallback(configs Configs, callback http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle(configs.LinkedInConfigs.Direct.CallbackPath(), callback)
+	log.Printf("LinkedIn OAuth callback listening on %s%s", configs.ServerConfig.BindAddress, configs.LinkedInConfigs.Direct.CallbackPath())
+	if err := http.ListenAndServe(configs.ServerConfig.BindAddress, mux); err != nil {
+		log.Printf("LinkedIn OAuth callback server stopped: %v", err)
+	}
+}
+
+func initStdioServer(configs Configs, components Components, userID string) *mcp.Server {
+	server := initServer(configs, components)
+	// Added last so it runs first: the rate limiter and tools need the user ID.
+	server.AddReceivingMiddleware(middleware.FixedUserID(userID))
+	return server
+}
+
+// resolveStdioUserID returns the configured user ID, or verifies the configured access token
+// with Clerk once at startup and returns its subject.
+func resolveStdioUserID(ctx context.Context, config AuthConfig) (string, error) {
+	if config.StdioUserID != "" {
+		return config.StdioUserID, nil
+	}
+	if config.StdioAccessToken == "" {
+		return "", errors.New("stdio transport needs MCP_STDIO_USER_ID or MCP_STDIO_ACCESS_TOKEN")
+	}
+	verifier, err := security.NewClerkTokenVerifier(config.ClerkJWKSURL, config.ClerkIssuer, config.ClerkAudience, config.RequiredScope)
+	if err != nil {
+		return "", err
+	}
+	userID, err := verifier.Verify(ctx, config.StdioAccessToken)
+	if err != nil {
+		return "", fmt.Errorf("MCP_STDIO_ACCESS_TOKEN rejected: %w", err)
+	}
+	return userID, nil
+}
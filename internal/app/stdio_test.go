@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway/fakegateway"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdioServer_RunsToolsAsConfiguredUser(t *testing.T) {
+	fake, configs := integrationConfigs(t)
+	configs.ServerConfig.Transport = TransportStdio
+	server := initStdioServer(configs, initCommonComponents(configs), "user_local")
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx := context.Background()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "stdio-test", Version: "v0.0.0"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	result, text := callTool(t, session, "search_ad_accounts", searchAdAccountsArguments())
+
+	require.False(t, result.IsError, text)
+	require.Contains(t, text, "Acme Demo Account")
+	requests := fake.Requests()
+	require.NotEmpty(t, requests)
+	for _, request := range requests {
+		require.Equal(t, "user_local", request.UserID)
+	}
+	require.Equal(t, 1, fake.Count(fakegateway.EndpointProxy))
+}
+
+func TestResolveStdioUserID(t *testing.T) {
+	userID, err := resolveStdioUserID(context.Background(), AuthConfig{StdioUserID: "user_local"})
+	require.NoError(t, err)
+	require.Equal(t, "user_local", userID)
+
+	_, err = resolveStdioUserID(context.Background(), AuthConfig{})
+	require.ErrorContains(t, err, "MCP_STDIO_USER_ID or MCP_STDIO_ACCESS_TOKEN")
+
+	_, err = resolveStdioUserID(context.Background(), AuthConfig{StdioAccessToken: "token"})
+	require.ErrorContains(t, err, "CLERK_JWKS_URL is required")
+}
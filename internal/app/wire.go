@@ -3,23 +3,39 @@ package app
 import (
 	_ "embed"
 	"log"
+	"os"
 	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api"
 	adaccountsapi "linkedin-mcp/internal/infrastructure/api/adaccounts"
+	"linkedin-mcp/internal/infrastructure/api/campaigngroups"
 	"linkedin-mcp/internal/infrastructure/api/campaigns"
 	creativesapi "linkedin-mcp/internal/infrastructure/api/creatives"
+	"linkedin-mcp/internal/infrastructure/api/direct"
 	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/api/gateway/recording"
+	"linkedin-mcp/internal/infrastructure/api/lookup"
 	reportingapi "linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/cache"
 	"linkedin-mcp/internal/infrastructure/http"
 	infrastructurelog "linkedin-mcp/internal/infrastructure/log"
 	locallogger "linkedin-mcp/internal/infrastructure/log/local"
+	"linkedin-mcp/internal/infrastructure/ratelimit"
+	"linkedin-mcp/internal/infrastructure/resources/analytics/derivedmetrics"
 	"linkedin-mcp/internal/infrastructure/resources/analytics/metrics"
 	"linkedin-mcp/internal/infrastructure/resources/analytics/queryparameters"
+	"linkedin-mcp/internal/infrastructure/resources/exports"
+	"linkedin-mcp/internal/infrastructure/tools/getadaccount"
 	"linkedin-mcp/internal/infrastructure/tools/getanalytics"
+	"linkedin-mcp/internal/infrastructure/tools/getcampaign"
+	"linkedin-mcp/internal/infrastructure/tools/getcreative"
+	"linkedin-mcp/internal/infrastructure/tools/pivotlabels"
 	"linkedin-mcp/internal/infrastructure/tools/searchadaccounts"
+	"linkedin-mcp/internal/infrastructure/tools/searchcampaigngroups"
 	"linkedin-mcp/internal/infrastructure/tools/searchcampaigns"
 	"linkedin-mcp/internal/infrastructure/tools/searchcreatives"
+	"linkedin-mcp/internal/infrastructure/tools/updatecampaignbudget"
+	"linkedin-mcp/internal/infrastructure/tools/updatecampaignstatus"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -27,13 +43,24 @@ import (
 //go:embed instructions/server_instructions.md
 var serverInstructions string
 
+// maxStoredExports bounds the rendered exports kept in memory across all users.
+const maxStoredExports = 500
+
 type Components struct {
-	httpClient    api.Client
-	gatewayClient *gateway.Client
-	logger        infrastructurelog.Logger
+	httpClient api.Client
+	// gatewayClient is set in gateway mode and directProvider in direct mode; provider is
+	// whichever of the two the executor uses.
+	gatewayClient  *gateway.Client
+	directProvider *direct.Provider
+	provider       gateway.Provider
+	executor       *gateway.Executor
+	logger         infrastructurelog.Logger
+	exportStore    *exports.Store
+	limiter        *ratelimit.Limiter
 }
 
 func initServer(configs Configs, components Components) *mcp.Server {
+	destructive := true
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "LinkedIn",
 		Version: "v1.0.0",
@@ -41,62 +68,251 @@ func initServer(configs Configs, components Components) *mcp.Server {
 	}, &mcp.ServerOptions{
 		Instructions: loadServerInstructions(),
 	})
+	var toolNames []string
 
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &toolNames, &mcp.Tool{
 		Name:        "search_ad_accounts",
 		Description: "Search for LinkedIn ad accounts without requiring an accountID argument.",
 	}, initSearchAdAccountsTool(configs, components).SearchAdAccounts)
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &toolNames, &mcp.Tool{
 		Name:        "search_campaigns",
 		Description: "Search for LinkedIn ad campaigns. Requires the accountID argument.",
 	}, initSearchCampaignsTool(configs, components).SearchCampaigns)
-	mcp.AddTool(server, &mcp.Tool{
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "search_campaign_groups",
+		Description: "Search for LinkedIn campaign groups by status, name or ID. Requires the accountID argument. Returns group URNs usable in search_campaigns and get_analytics filters.",
+	}, initSearchCampaignGroupsTool(configs, components).SearchCampaignGroups)
+	reportingTool := initReportingTool(configs, components)
+	addTool(server, &toolNames, &mcp.Tool{
 		Name:        "get_analytics",
 		Description: "Get LinkedIn ad analytics data. Requires accountID and should be used after reading analytics resources.",
-	}, initReportingTool(configs, components).GetAnalytics)
-	mcp.AddTool(server, &mcp.Tool{
+	}, reportingTool.GetAnalytics)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "compare_analytics",
+		Description: "Compare LinkedIn ad analytics between two periods (previous period, same period last year, or a custom range). Takes the get_analytics arguments and returns current, comparison, absolute and percentage change for every metric, joined by pivot value and time bucket. Requires accountID.",
+	}, reportingTool.CompareAnalytics)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "export_analytics",
+		Description: "Export LinkedIn ad analytics as a flat CSV, TSV or Markdown table (date bucket, pivot value and label, one column per requested metric). Takes the get_analytics arguments plus format. Small exports are returned inline; large ones as a linkedin://exports/{id} resource URI to read. Requires accountID.",
+	}, reportingTool.ExportAnalytics)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "export_analytics_report",
+		Description: "Build an Excel (.xlsx) client report from up to 10 analytics queries: one sheet per query with typed number, currency and percentage cells and a totals row, plus a Summary sheet. Each sheet takes the get_analytics arguments. Returns a linkedin://exports/{id} resource URI whose contents are the workbook as a binary blob. Requires accountID on every sheet.",
+	}, reportingTool.ExportReport)
+	addTool(server, &toolNames, &mcp.Tool{
 		Name:        "search_creatives",
 		Description: "List ad creatives for a campaign with normalized metadata (IDs, status, format; headline and landing URL when the API returns them, e.g. not for content-reference-only creatives). Requires accountID and campaignID or campaignURN.",
 	}, initSearchCreativesTool(configs, components).SearchCreatives)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "get_ad_account",
+		Description: "Get one or more LinkedIn ad accounts by numeric ID or URN. Returns the full entity plus normalized fields (name, status, currency).",
+	}, initGetAdAccountTool(configs, components).GetAdAccount)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "get_campaign",
+		Description: "Get the full configuration of one or more LinkedIn campaigns by numeric ID or URN (e.g. an ID copied from Campaign Manager). Requires accountID. Returns the full entity plus normalized fields (status, budgets, bid, schedule).",
+	}, initGetCampaignTool(configs, components).GetCampaign)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "get_creative",
+		Description: "Get one or more LinkedIn creatives by numeric ID or URN. Requires accountID. Returns the full entity plus normalized creative metadata.",
+	}, initGetCreativeTool(configs, components).GetCreative)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "update_campaign_status",
+		Description: "Pause, resume or archive one or more LinkedIn campaigns. Requires accountID. Returns before/after status per campaign and never changes anything without explicit user confirmation (elicitation, or confirm=true with the returned confirmationToken).",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &destructive,
+		},
+	}, initUpdateCampaignStatusTool(configs, components).UpdateCampaignStatus)
+	addTool(server, &toolNames, &mcp.Tool{
+		Name:        "update_campaign_budget",
+		Description: "Change dailyBudget, totalBudget, unitCost (bid) and run schedule end date on LinkedIn campaigns. Requires accountID. Returns a dry-run diff checked against server-side guardrails (max % change per call, per-currency ceilings, account currency) and only applies it after explicit user confirmation.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: &destructive,
+		},
+	}, initUpdateCampaignBudgetTool(configs, components).UpdateCampaignBudget)
 
-	analyticsResource := initAnalyticsResource()
+	analyticsResource := initAnalyticsResource(configs)
 	server.AddResource(&mcp.Resource{
 		URI:         "linkedin://analytics/parameters",
 		Name:        "LinkedIn Analytics Query Parameters",
 		Description: "Reference link to LinkedIn analytics query parameters documentation",
 	}, analyticsResource.ReadResource)
 
-	analyticsMetricsResource := initAnalyticsMetricsResource()
+	analyticsMetricsResource := initAnalyticsMetricsResource(configs)
 	server.AddResource(&mcp.Resource{
 		URI:         "linkedin://analytics/metrics",
 		Name:        "LinkedIn Analytics Metrics",
 		Description: "Reference link to LinkedIn analytics metrics documentation",
 	}, analyticsMetricsResource.ReadResource)
 
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: exports.URITemplate,
+		Name:        "LinkedIn Analytics Exports",
+		Description: "Rendered analytics exports returned by export_analytics as resourceURI; readable only by the user who created them until they expire",
+	}, exports.NewResource(components.exportStore).ReadResource)
+
+	derivedMetricsResource := initDerivedMetricsResource(configs)
+	server.AddResource(&mcp.Resource{
+		URI:         "linkedin://analytics/derived-metrics",
+		Name:        "LinkedIn Analytics Derived Metrics",
+		Description: "Catalogue of metrics computed server-side by get_analytics: name, aliases, formula and required raw fields",
+	}, derivedMetricsResource.ReadResource)
+
+	server.AddReceivingMiddleware(ratelimit.Middleware(components.limiter, toolNames))
+
 	return server
 }
 
+// addTool registers a tool and records its name, so the rate limiter only keeps state for
+// tools that exist.
+func addTool[In, Out any](server *mcp.Server, names *[]string, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) {
+	mcp.AddTool(server, tool, handler)
+	*names = append(*names, tool.Name)
+}
+
 func initCommonComponents(configs Configs) Components {
+	logger := locallogger.NewLogger()
+	if configs.ServerConfig.Transport == TransportStdio {
+		// stdout carries the MCP protocol.
+		logger = locallogger.NewLoggerTo(os.Stderr)
+	}
+	components := Components{
+		logger:      logger,
+		exportStore: exports.NewStore(configs.AnalyticsConfig.ExportTTL, maxStoredExports),
+		limiter:     ratelimit.NewLimiter(configs.RateLimitConfig.Default, configs.RateLimitConfig.Tools),
+	}
+
+	if configs.LinkedInConfigs.Provider == LinkedInProviderDirect {
+		components.httpClient = http.NewClient(nil)
+		components.directProvider = initDirectProvider(configs, components.httpClient)
+		components.provider = components.directProvider
+	} else {
+		components.httpClient = initGatewayHTTPClient(configs)
+		components.gatewayClient = gateway.NewClient(components.httpClient, configs.GatewayConfig.BaseURL, configs.GatewayConfig.InternalSecret, initGatewayOptions(configs)...)
+		components.provider = components.gatewayClient
+	}
+	components.executor = gateway.NewExecutor(components.provider, logger,
+		gateway.WithConnectionStateTTL(configs.GatewayConfig.ConnectionStateTTL),
+		gateway.WithAPIVersion(configs.LinkedInConfigs.APIVersion),
+	)
+	return components
+}
+
+// breakerStates reports the gateway circuit breakers; direct mode has none.
+func (c Components) breakerStates() map[string]gateway.BreakerState {
+	if c.gatewayClient == nil {
+		return nil
+	}
+	return c.gatewayClient.BreakerStates()
+}
+
+func initDirectProvider(configs Configs, httpClient api.Client) *direct.Provider {
+	directConfig := configs.LinkedInConfigs.Direct
+	tokens, err := direct.NewTokenStore(directConfig.TokenStorePath, directConfig.TokenEncryptionKey)
+	if err != nil {
+		log.Fatalf("LINKEDIN_TOKEN_STORE_PATH: %v", err)
+	}
+	oauth := direct.NewOAuth(direct.OAuthConfig{
+		ClientID:     directConfig.ClientID,
+		ClientSecret: directConfig.ClientSecret,
+		RedirectURL:  directConfig.RedirectURL,
+		Scopes:       directConfig.Scopes,
+	}, directConfig.TokenEncryptionKey)
+	return direct.NewProvider(httpClient, configs.LinkedInConfigs.BaseURL, configs.LinkedInConfigs.APIVersion, tokens, oauth)
+}
+
+// initGatewayHTTPClient wraps the HTTP client in a recorder, or replaces it with a replayer,
+// when a gateway cassette is configured.
+func initGatewayHTTPClient(configs Configs) api.Client {
+	if configs.GatewayConfig.ReplayFile != "" {
+		replayer, err := recording.NewReplayerFromFile(configs.GatewayConfig.ReplayFile)
+		if err != nil {
+			log.Fatalf("GATEWAY_REPLAY_FILE: %v", err)
+		}
+		return replayer
+	}
 	httpClient := http.NewClient(nil)
-	return Components{
-		httpClient:    httpClient,
-		gatewayClient: gateway.NewClient(httpClient, configs.GatewayConfig.BaseURL, configs.GatewayConfig.InternalSecret),
-		logger:        locallogger.NewLogger(),
+	if configs.GatewayConfig.RecordFile == "" {
+		return httpClient
+	}
+	recorder, err := recording.NewRecorder(httpClient, configs.GatewayConfig.RecordFile)
+	if err != nil {
+		log.Fatalf("GATEWAY_RECORD_FILE: %v", err)
+	}
+	return recorder
+}
+
+func initGatewayOptions(configs Configs) []gateway.Option {
+	var options []gateway.Option
+	if configs.GatewayConfig.BreakerFailureThreshold > 0 {
+		options = append(options, gateway.WithCircuitBreaker(gateway.BreakerSettings{
+			FailureThreshold: configs.GatewayConfig.BreakerFailureThreshold,
+			OpenDuration:     configs.GatewayConfig.BreakerOpenDuration,
+		}))
+	}
+
+	cacheConfig := configs.CacheConfig
+	var backend cache.Backend
+	switch cacheConfig.Backend {
+	case CacheBackendNone:
+		return options
+	case CacheBackendDisk:
+		disk, err := cache.NewDisk(cacheConfig.Dir, cacheConfig.MaxBytes)
+		if err != nil {
+			log.Fatalf("CACHE_DIR: %v", err)
+		}
+		backend = disk
+	default:
+		backend = cache.NewMemory(cacheConfig.MaxBytes)
 	}
+	return append(options, gateway.WithResponseCache(backend, gateway.CacheTTLs{
+		RecentAnalytics:     cacheConfig.RecentAnalyticsTTL,
+		HistoricalAnalytics: cacheConfig.HistoricalAnalyticsTTL,
+		Metadata:            cacheConfig.MetadataTTL,
+	}))
 }
 
 func initSearchCampaignsTool(configs Configs, components Components) *searchcampaigns.Tool {
 	queryBuilder := campaigns.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
 
-	campaignsRepository := campaigns.NewRepository(components.gatewayClient, queryBuilder, components.logger)
+	campaignsRepository := campaigns.NewRepository(components.executor, queryBuilder)
 
 	return searchcampaigns.NewTool(campaignsRepository, configs.GatewayConfig.ConnectURL)
 }
 
+func initSearchCampaignGroupsTool(configs Configs, components Components) *searchcampaigngroups.Tool {
+	queryBuilder := campaigngroups.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
+
+	campaignGroupsRepository := campaigngroups.NewRepository(components.executor, queryBuilder)
+
+	return searchcampaigngroups.NewTool(campaignGroupsRepository, configs.GatewayConfig.ConnectURL)
+}
+
+func initUpdateCampaignStatusTool(configs Configs, components Components) *updatecampaignstatus.Tool {
+	queryBuilder := campaigns.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
+
+	campaignsRepository := campaigns.NewRepository(components.executor, queryBuilder)
+
+	return updatecampaignstatus.NewTool(campaignsRepository, configs.GatewayConfig.ConnectURL)
+}
+
+func initUpdateCampaignBudgetTool(configs Configs, components Components) *updatecampaignbudget.Tool {
+	campaignsRepository := campaigns.NewRepository(components.executor, campaigns.NewQueryBuilder(configs.LinkedInConfigs.BaseURL))
+	adAccountsRepository := adaccountsapi.NewRepository(components.executor, adaccountsapi.NewQueryBuilder(configs.LinkedInConfigs.BaseURL))
+
+	guardrails := updatecampaignbudget.Guardrails{
+		MaxChangePercent:    configs.GuardrailConfig.MaxBudgetChangePercent,
+		DailyBudgetCeilings: configs.GuardrailConfig.MaxDailyBudget,
+		TotalBudgetCeilings: configs.GuardrailConfig.MaxTotalBudget,
+		UnitCostCeilings:    configs.GuardrailConfig.MaxUnitCost,
+	}
+
+	return updatecampaignbudget.NewTool(campaignsRepository, adAccountsRepository, guardrails, configs.GatewayConfig.ConnectURL)
+}
+
 func initSearchAdAccountsTool(configs Configs, components Components) *searchadaccounts.Tool {
 	queryBuilder := adaccountsapi.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
 
-	repository := adaccountsapi.NewRepository(components.gatewayClient, queryBuilder, components.logger)
+	repository := adaccountsapi.NewRepository(components.executor, queryBuilder)
 
 	return searchadaccounts.NewTool(repository, configs.GatewayConfig.ConnectURL)
 }
@@ -104,23 +320,87 @@ func initSearchAdAccountsTool(configs Configs, components Components) *searchada
 func initReportingTool(configs Configs, components Components) *getanalytics.Tool {
 	queryBuilder := reportingapi.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
 
-	reportingRepository := reportingapi.NewRepository(components.gatewayClient, queryBuilder, components.logger)
+	reportingRepository := reportingapi.NewRepository(components.executor, queryBuilder, components.logger)
 
-	return getanalytics.NewTool(reportingRepository, configs.GatewayConfig.ConnectURL)
+	return getanalytics.NewTool(reportingRepository, initPivotLabelsResolver(configs, components), components.exportStore, getanalytics.Settings{
+		MaxRows:          configs.AnalyticsConfig.MaxRows,
+		DefaultTimeZone:  configs.AnalyticsConfig.DefaultTimeZone,
+		AccountTimeZones: configs.AnalyticsConfig.AccountTimeZones,
+		DerivedMetrics:   configs.AnalyticsConfig.DerivedMetrics,
+	}, configs.GatewayConfig.ConnectURL)
+}
+
+func initPivotLabelsResolver(configs Configs, components Components) *pivotlabels.Resolver {
+	baseURL := configs.LinkedInConfigs.BaseURL
+	return pivotlabels.NewResolver(
+		campaigns.NewRepository(components.executor, campaigns.NewQueryBuilder(baseURL)),
+		campaigngroups.NewRepository(components.executor, campaigngroups.NewQueryBuilder(baseURL)),
+		creativesapi.NewRepository(components.executor, creativesapi.NewQueryBuilder(baseURL)),
+		adaccountsapi.NewRepository(components.executor, adaccountsapi.NewQueryBuilder(baseURL)),
+		lookup.NewRepository(components.executor, lookup.NewQueryBuilder(baseURL)),
+	)
 }
 
 func initSearchCreativesTool(configs Configs, components Components) *searchcreatives.Tool {
 	queryBuilder := creativesapi.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
-	repository := creativesapi.NewRepository(components.gatewayClient, queryBuilder, components.logger)
+	repository := creativesapi.NewRepository(components.executor, queryBuilder)
 	return searchcreatives.NewTool(repository, configs.GatewayConfig.ConnectURL)
 }
 
-func initAnalyticsResource() *queryparameters.Resource {
-	return queryparameters.NewResource()
+func initGetAdAccountTool(configs Configs, components Components) *getadaccount.Tool {
+	queryBuilder := adaccountsapi.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
+	repository := adaccountsapi.NewRepository(components.executor, queryBuilder)
+	return getadaccount.NewTool(repository, configs.GatewayConfig.ConnectURL)
 }
 
-func initAnalyticsMetricsResource() *metrics.Resource {
-	return metrics.NewResource()
+func initGetCampaignTool(configs Configs, components Components) *getcampaign.Tool {
+	queryBuilder := campaigns.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
+	repository := campaigns.NewRepository(components.executor, queryBuilder)
+	return getcampaign.NewTool(repository, configs.GatewayConfig.ConnectURL)
+}
+
+func initGetCreativeTool(configs Configs, components Components) *getcreative.Tool {
+	queryBuilder := creativesapi.NewQueryBuilder(configs.LinkedInConfigs.BaseURL)
+	repository := creativesapi.NewRepository(components.executor, queryBuilder)
+	return getcreative.NewTool(repository, configs.GatewayConfig.ConnectURL)
+}
+
+func initAnalyticsResource(configs Configs) *queryparameters.Resource {
+	return queryparameters.NewResource(configs.LinkedInConfigs.APIVersion)
+}
+
+func initAnalyticsMetricsResource(configs Configs) *metrics.Resource {
+	return metrics.NewResource(configs.LinkedInConfigs.APIVersion)
+}
+
+func initDerivedMetricsResource(configs Configs) *derivedmetrics.Resource {
+	var catalogue []derivedmetrics.Metric
+	for _, metric := range getanalytics.BuiltinDerivedMetrics() {
+		catalogue = append(catalogue, derivedmetrics.Metric{
+			Name:           metric.Name,
+			Aliases:        metric.Aliases,
+			Formula:        metric.Formula,
+			Unit:           metric.Unit,
+			RequiredFields: metric.RequiredFields,
+			Source:         "built-in",
+		})
+	}
+
+	configured, err := getanalytics.ConfiguredDerivedMetrics(configs.AnalyticsConfig.DerivedMetrics)
+	if err != nil {
+		log.Fatalf("ANALYTICS_DERIVED_METRICS: %v", err)
+	}
+	for _, metric := range configured {
+		catalogue = append(catalogue, derivedmetrics.Metric{
+			Name:           metric.Name,
+			Formula:        metric.Formula,
+			Unit:           metric.Unit,
+			RequiredFields: metric.RequiredFields,
+			Source:         "server",
+		})
+	}
+
+	return derivedmetrics.NewResource(catalogue)
 }
 
 func loadServerInstructions() string {
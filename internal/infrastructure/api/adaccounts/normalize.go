@@ -0,0 +1,42 @@
+package adaccounts
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NormalizeAdAccount maps one LinkedIn ad account entity to the flat MCP view.
+func NormalizeAdAccount(raw map[string]any) NormalizedAdAccount {
+	out := NormalizedAdAccount{}
+	if raw == nil {
+		return out
+	}
+
+	switch v := raw["id"].(type) {
+	case float64:
+		out.AccountID = strconv.FormatInt(int64(v), 10)
+	case string:
+		out.AccountID = strings.TrimPrefix(strings.TrimSpace(v), AccountURNPrefix)
+	}
+	if out.AccountID != "" {
+		out.AccountURN = AccountURN(out.AccountID)
+	}
+
+	out.Name, _ = raw["name"].(string)
+	out.Status, _ = raw["status"].(string)
+	out.Type, _ = raw["type"].(string)
+	out.CurrencyCode, _ = raw["currency"].(string)
+	out.Reference, _ = raw["reference"].(string)
+	if statuses, ok := raw["servingStatuses"].([]any); ok {
+		for _, status := range statuses {
+			if s, ok := status.(string); ok {
+				out.ServingStatuses = append(out.ServingStatuses, s)
+			}
+		}
+	}
+	if test, ok := raw["test"].(bool); ok {
+		out.Test = &test
+	}
+
+	return out
+}
@@ -0,0 +1,26 @@
+package adaccounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAdAccount(t *testing.T) {
+	normalized := NormalizeAdAccount(map[string]any{
+		"id":              float64(512345678),
+		"name":            "Acme EMEA",
+		"status":          "ACTIVE",
+		"type":            "BUSINESS",
+		"currency":        "EUR",
+		"reference":       "urn:li:organization:2414183",
+		"servingStatuses": []any{"RUNNABLE"},
+		"test":            true,
+	})
+
+	require.Equal(t, "512345678", normalized.AccountID)
+	require.Equal(t, "urn:li:sponsoredAccount:512345678", normalized.AccountURN)
+	require.Equal(t, "EUR", normalized.CurrencyCode)
+	require.Equal(t, []string{"RUNNABLE"}, normalized.ServingStatuses)
+	require.True(t, *normalized.Test)
+}
@@ -25,3 +25,8 @@ type SearchInput struct {
 	// Count controls number of results per page (max 1000).
 	Count int
 }
+
+// GetInput fetches ad accounts by numeric ID.
+type GetInput struct {
+	AccountIDs []string
+}
@@ -22,6 +22,16 @@ func (qb *QueryBuilder) BuildSearchAdAccountsQuery(input SearchInput) string {
 	return fullURL
 }
 
+// BuildAdAccountsQuery builds the ad accounts collection URL, used for BATCH_GET.
+func (qb *QueryBuilder) BuildAdAccountsQuery() string {
+	return fmt.Sprintf("%s/adAccounts", strings.TrimRight(qb.baseURL, "/"))
+}
+
+// BuildAdAccountQuery builds the entity URL of one ad account, used for GET.
+func (qb *QueryBuilder) BuildAdAccountQuery(accountID string) string {
+	return fmt.Sprintf("%s/adAccounts/%s", strings.TrimRight(qb.baseURL, "/"), url.PathEscape(strings.TrimSpace(accountID)))
+}
+
 func (qb *QueryBuilder) buildQueryParams(input SearchInput) string {
 	params := []string{"q=search"}
 	var searchParts []string
@@ -2,140 +2,88 @@ package adaccounts
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api/gateway"
-	"linkedin-mcp/internal/infrastructure/middleware"
 )
 
-const (
-	logMessageFailedRequest        = "failed to make request"
-	logMessageLinkedInAPIError     = "linkedin api responded with error"
-	logMessageFailedDecodeResponse = "failed to decode response"
-
-	logTagURL    = "url"
-	logTagError  = "error"
-	logTagStatus = "status"
-	logTagBody   = "body"
-
-	errFmtFailedRequest         = "failed to make request: %w"
-	errFmtLinkedInAPIErrorJSON  = "linkedin api error: status %d, body: %v"
-	errFmtLinkedInAPIErrorPlain = "linkedin api error: status %d, body: %s"
-	errFmtLinkedInAPIError      = "linkedin api error: status %d"
-	errFmtDecodeResponse        = "failed to decode response: %w"
-)
-
-type Logger interface {
-	Error(ctx context.Context, message string, tags map[string]string)
-}
-
 type Repository struct {
-	gatewayClient *gateway.Client
-	queryBuilder  *QueryBuilder
-	logger        Logger
+	executor     *gateway.Executor
+	queryBuilder *QueryBuilder
 }
 
-func NewRepository(gatewayClient *gateway.Client, queryBuilder *QueryBuilder, logger Logger) *Repository {
+func NewRepository(executor *gateway.Executor, queryBuilder *QueryBuilder) *Repository {
 	return &Repository{
-		gatewayClient: gatewayClient,
-		queryBuilder:  queryBuilder,
-		logger:        logger,
+		executor:     executor,
+		queryBuilder: queryBuilder,
 	}
 }
 
 func (r *Repository) SearchAdAccounts(ctx context.Context, input SearchInput) (*SearchResult, error) {
 	requestURL := r.queryBuilder.BuildSearchAdAccountsQuery(input)
-	resourcePath, query, err := gateway.ParseLinkedInRESTProxyTarget(requestURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build gateway proxy target: %w", err)
-	}
-	userID, ok := middleware.UserIDFromContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf("missing authenticated user in request context")
-	}
-
-	connectionResponse, err := r.gatewayClient.GetLinkedInConnection(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch LinkedIn connection state from gateway: %w", err)
-	}
-	if gateway.IsLinkedInNotConnectedResponse(connectionResponse) {
-		return nil, gateway.ErrLinkedInNotConnected
-	}
-	if connectionResponse.StatusCode < 200 || connectionResponse.StatusCode >= 300 {
-		return nil, fmt.Errorf("failed to fetch LinkedIn connection state from gateway: status %d", connectionResponse.StatusCode)
+	var liResp LinkedInResponse
+	if err := r.executor.GetJSON(ctx, requestURL, nil, &liResp); err != nil {
+		return nil, withRequestContext(err, requestURL)
 	}
 
-	response, err := r.gatewayClient.ProxyLinkedInOrRefresh(ctx, userID, resourcePath, query, nil)
-	if err != nil {
-		r.logError(ctx, logMessageFailedRequest, map[string]string{
-			logTagURL:   requestURL,
-			logTagError: err.Error(),
-		})
-		return nil, fmt.Errorf(errFmtFailedRequest, err)
-	}
-	if gateway.IsLinkedInNotConnectedResponse(response) {
-		return nil, gateway.ErrLinkedInNotConnected
-	}
-	if validationErr, ok := gateway.ParseLinkedInParamValidationResponse(response); ok {
-		validationErr.Message = strings.TrimSpace(validationErr.Message + " request_url=" + requestURL)
-		if len(validationErr.InputErrors) == 0 {
-			bodyString := strings.TrimSpace(string(response.Body))
-			if bodyString != "" {
-				validationErr.Message = strings.TrimSpace(validationErr.Message + " provider_payload=" + bodyString)
-			}
-		}
-		return nil, validationErr
+	result := &SearchResult{
+		Elements: liResp.Elements,
+		Paging:   liResp.Paging,
 	}
 
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		bodyString := strings.TrimSpace(string(response.Body))
-		tags := map[string]string{
-			logTagURL:    requestURL,
-			logTagStatus: strconv.Itoa(response.StatusCode),
-		}
-		if bodyString != "" {
-			tags[logTagBody] = bodyString
-		}
+	return result, nil
+}
 
-		r.logError(ctx, logMessageLinkedInAPIError, tags)
+// GetAdAccounts fetches ad accounts by ID with a Rest.li GET for a single ID and BATCH_GET
+// for several. IDs LinkedIn does not return are reported in NotFound instead of failing the call.
+func (r *Repository) GetAdAccounts(ctx context.Context, input GetInput) (*GetResult, error) {
+	if len(input.AccountIDs) == 0 {
+		return nil, fmt.Errorf("at least one ad account ID is required")
+	}
 
-		var errBody any
-		if err := json.Unmarshal(response.Body, &errBody); err == nil {
-			return nil, fmt.Errorf(errFmtLinkedInAPIErrorJSON, response.StatusCode, errBody)
+	result := &GetResult{}
+	if len(input.AccountIDs) == 1 {
+		id := input.AccountIDs[0]
+		requestURL := r.queryBuilder.BuildAdAccountQuery(id)
+		entity, err := r.executor.GetEntity(ctx, requestURL)
+		if err != nil {
+			return nil, withRequestContext(err, requestURL)
 		}
-
-		trimmedBody := strings.TrimSpace(string(response.Body))
-		if trimmedBody != "" {
-			return nil, fmt.Errorf(errFmtLinkedInAPIErrorPlain, response.StatusCode, trimmedBody)
+		if entity == nil {
+			result.NotFound = append(result.NotFound, id)
+		} else {
+			result.Elements = append(result.Elements, entity)
 		}
-
-		return nil, fmt.Errorf(errFmtLinkedInAPIError, response.StatusCode)
+		return result, nil
 	}
 
-	var liResp LinkedInResponse
-	if err := json.Unmarshal(response.Body, &liResp); err != nil {
-		r.logError(ctx, logMessageFailedDecodeResponse, map[string]string{
-			logTagURL:   requestURL,
-			logTagError: err.Error(),
-		})
-		return nil, fmt.Errorf(errFmtDecodeResponse, err)
+	requestURL := r.queryBuilder.BuildAdAccountsQuery()
+	batch, err := r.executor.BatchGet(ctx, requestURL, input.AccountIDs)
+	if err != nil {
+		return nil, withRequestContext(err, requestURL)
 	}
-
-	result := &SearchResult{
-		Elements: liResp.Elements,
-		Paging:   liResp.Paging,
+	for _, id := range input.AccountIDs {
+		if entity, ok := batch.Results[id]; ok {
+			result.Elements = append(result.Elements, entity)
+			continue
+		}
+		result.NotFound = append(result.NotFound, id)
 	}
-
 	return result, nil
 }
 
-func (r *Repository) logError(ctx context.Context, message string, tags map[string]string) {
-	if r.logger == nil {
-		return
+// withRequestContext adds the request URL (and the provider payload when LinkedIn did not list
+// input errors) to parameter validation errors, because ad account finder syntax errors are
+// otherwise hard to diagnose.
+func withRequestContext(err error, requestURL string) error {
+	validationErr, ok := gateway.AsLinkedInParamValidation(err)
+	if !ok {
+		return err
 	}
-
-	r.logger.Error(ctx, message, tags)
+	validationErr.Message = strings.TrimSpace(validationErr.Message + " request_url=" + requestURL)
+	if len(validationErr.InputErrors) == 0 && validationErr.ProviderPayload != "" {
+		validationErr.Message = strings.TrimSpace(validationErr.Message + " provider_payload=" + validationErr.ProviderPayload)
+	}
+	return validationErr
 }
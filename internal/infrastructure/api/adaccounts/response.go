@@ -9,3 +9,23 @@ type SearchResult struct {
 	Elements []map[string]any `json:"elements"`
 	Paging   map[string]any   `json:"paging,omitempty"`
 }
+
+// GetResult holds the ad accounts returned by GET/BATCH_GET in request order. IDs LinkedIn did
+// not return are listed in NotFound.
+type GetResult struct {
+	Elements []map[string]any
+	NotFound []string
+}
+
+// NormalizedAdAccount is a flat, agent-friendly view of an ad account entity.
+type NormalizedAdAccount struct {
+	AccountID       string   `json:"accountId"`
+	AccountURN      string   `json:"accountUrn"`
+	Name            string   `json:"name,omitempty"`
+	Status          string   `json:"status,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	CurrencyCode    string   `json:"currencyCode,omitempty"`
+	Reference       string   `json:"reference,omitempty"`
+	ServingStatuses []string `json:"servingStatuses,omitempty"`
+	Test            *bool    `json:"test,omitempty"`
+}
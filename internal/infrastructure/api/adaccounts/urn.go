@@ -0,0 +1,30 @@
+package adaccounts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccountURNPrefix is the LinkedIn URN prefix for ad accounts.
+// Format: urn:li:sponsoredAccount:{id}
+const AccountURNPrefix = "urn:li:sponsoredAccount:"
+
+// AccountURN returns the URN for a numeric ad account ID.
+func AccountURN(accountID string) string {
+	return AccountURNPrefix + strings.TrimSpace(accountID)
+}
+
+// ParseAccountID accepts either a numeric ad account ID or an ad account URN and returns the
+// numeric ID.
+func ParseAccountID(reference string) (string, error) {
+	id := strings.TrimPrefix(strings.TrimSpace(reference), AccountURNPrefix)
+	if id == "" {
+		return "", fmt.Errorf("ad account reference cannot be empty")
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("ad account reference %q must be a numeric ID or %s{id}", reference, AccountURNPrefix)
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,20 @@
+package adaccounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccountID(t *testing.T) {
+	id, err := ParseAccountID("urn:li:sponsoredAccount:512345678")
+	require.NoError(t, err)
+	require.Equal(t, "512345678", id)
+
+	id, err = ParseAccountID(" 512345678 ")
+	require.NoError(t, err)
+	require.Equal(t, "512345678", id)
+
+	_, err = ParseAccountID("urn:li:organization:1")
+	require.Error(t, err)
+}
@@ -0,0 +1,14 @@
+package campaigngroups
+
+// SearchInput represents the supported filters for the adCampaignGroups search finder.
+// Reference: https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads/account-structure/create-and-manage-campaign-groups#search-for-campaign-groups
+type SearchInput struct {
+	AccountID         string
+	CampaignGroupURNs []string
+	Status            []string
+	Name              []string
+	Test              *bool
+	SortOrder         string
+	PageSize          int
+	PageToken         string
+}
@@ -0,0 +1,66 @@
+package campaigngroups
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type QueryBuilder struct {
+	baseURL string
+}
+
+func NewQueryBuilder(baseURL string) *QueryBuilder {
+	return &QueryBuilder{baseURL: baseURL}
+}
+
+func (qb *QueryBuilder) BuildSearchCampaignGroupsQuery(input SearchInput) string {
+	endpoint := fmt.Sprintf("%s/adAccounts/%s/adCampaignGroups", strings.TrimRight(qb.baseURL, "/"), url.PathEscape(input.AccountID))
+
+	return endpoint + "?" + qb.buildQueryParams(input)
+}
+
+func (qb *QueryBuilder) buildQueryParams(input SearchInput) string {
+	params := []string{"q=search"}
+
+	// Rest.li composite search parameter, e.g. search=(status:(values:List(ACTIVE)),test:false)
+	var searchParts []string
+
+	addList := func(field string, values []string) {
+		cleaned := make([]string, 0, len(values))
+		for _, v := range values {
+			if v == "" {
+				continue
+			}
+			cleaned = append(cleaned, v)
+		}
+		if len(cleaned) == 0 {
+			return
+		}
+		searchParts = append(searchParts, fmt.Sprintf("%s:(values:List(%s))", field, strings.Join(cleaned, ",")))
+	}
+
+	addList("id", input.CampaignGroupURNs)
+	addList("status", input.Status)
+	addList("name", input.Name)
+
+	if input.Test != nil {
+		searchParts = append(searchParts, fmt.Sprintf("test:%t", *input.Test))
+	}
+
+	if len(searchParts) > 0 {
+		params = append(params, fmt.Sprintf("search=(%s)", strings.Join(searchParts, ",")))
+	}
+
+	if input.SortOrder != "" {
+		params = append(params, fmt.Sprintf("sortOrder=%s", url.QueryEscape(input.SortOrder)))
+	}
+	if input.PageSize > 0 {
+		params = append(params, fmt.Sprintf("pageSize=%d", input.PageSize))
+	}
+	if input.PageToken != "" {
+		params = append(params, fmt.Sprintf("pageToken=%s", url.QueryEscape(input.PageToken)))
+	}
+
+	return strings.Join(params, "&")
+}
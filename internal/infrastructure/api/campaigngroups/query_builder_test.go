@@ -0,0 +1,38 @@
+package campaigngroups
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSearchCampaignGroupsQuery_BuildsCompositeSearch(t *testing.T) {
+	testFlag := false
+	qb := NewQueryBuilder("https://api.linkedin.com/rest/")
+
+	query := qb.BuildSearchCampaignGroupsQuery(SearchInput{
+		AccountID:         "512345678",
+		CampaignGroupURNs: []string{"urn:li:sponsoredCampaignGroup:1", ""},
+		Status:            []string{"ACTIVE", "PAUSED"},
+		Name:              []string{"Brand"},
+		Test:              &testFlag,
+		SortOrder:         "DESCENDING",
+		PageSize:          50,
+		PageToken:         "abc=",
+	})
+
+	require.Equal(t,
+		"https://api.linkedin.com/rest/adAccounts/512345678/adCampaignGroups?q=search"+
+			"&search=(id:(values:List(urn:li:sponsoredCampaignGroup:1)),status:(values:List(ACTIVE,PAUSED)),name:(values:List(Brand)),test:false)"+
+			"&sortOrder=DESCENDING&pageSize=50&pageToken=abc%3D",
+		query,
+	)
+}
+
+func TestBuildSearchCampaignGroupsQuery_OmitsEmptySearch(t *testing.T) {
+	qb := NewQueryBuilder("https://api.linkedin.com/rest")
+
+	query := qb.BuildSearchCampaignGroupsQuery(SearchInput{AccountID: "1"})
+
+	require.Equal(t, "https://api.linkedin.com/rest/adAccounts/1/adCampaignGroups?q=search", query)
+}
@@ -0,0 +1,46 @@
+package campaigngroups
+
+import (
+	"context"
+	"net/url"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+)
+
+const (
+	pagingNextKey       = "next"
+	queryParamPageToken = "pageToken"
+)
+
+type Repository struct {
+	executor     *gateway.Executor
+	queryBuilder *QueryBuilder
+}
+
+func NewRepository(executor *gateway.Executor, queryBuilder *QueryBuilder) *Repository {
+	return &Repository{
+		executor:     executor,
+		queryBuilder: queryBuilder,
+	}
+}
+
+func (r *Repository) SearchCampaignGroups(ctx context.Context, input SearchInput) (*SearchResult, error) {
+	var liResp LinkedInResponse
+	if err := r.executor.GetJSON(ctx, r.queryBuilder.BuildSearchCampaignGroupsQuery(input), nil, &liResp); err != nil {
+		return nil, err
+	}
+
+	result := &SearchResult{
+		Elements: liResp.Elements,
+	}
+
+	if nextRaw, ok := liResp.Paging[pagingNextKey].(string); ok && nextRaw != "" {
+		if u, err := url.Parse(nextRaw); err == nil {
+			if token := u.Query().Get(queryParamPageToken); token != "" {
+				result.Metadata.NextPageToken = token
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,28 @@
+package campaigngroups
+
+// Campaign group statuses accepted by the adCampaignGroups search finder.
+// Reference: https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads/account-structure/create-and-manage-campaign-groups
+const (
+	StatusActive          = "ACTIVE"
+	StatusPaused          = "PAUSED"
+	StatusArchived        = "ARCHIVED"
+	StatusCanceled        = "CANCELED"
+	StatusDraft           = "DRAFT"
+	StatusPendingDeletion = "PENDING_DELETION"
+	StatusRemoved         = "REMOVED"
+)
+
+var validStatuses = map[string]bool{
+	StatusActive:          true,
+	StatusPaused:          true,
+	StatusArchived:        true,
+	StatusCanceled:        true,
+	StatusDraft:           true,
+	StatusPendingDeletion: true,
+	StatusRemoved:         true,
+}
+
+// IsValidStatus reports whether status is a known LinkedIn campaign group status.
+func IsValidStatus(status string) bool {
+	return validStatuses[status]
+}
@@ -0,0 +1,44 @@
+package campaigngroups
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CampaignGroupURNPrefix is the LinkedIn URN prefix for sponsored campaign groups.
+// Format: urn:li:sponsoredCampaignGroup:{id}
+const CampaignGroupURNPrefix = "urn:li:sponsoredCampaignGroup:"
+
+// CampaignGroupURN returns the URN for a numeric campaign group ID.
+func CampaignGroupURN(campaignGroupID string) string {
+	return CampaignGroupURNPrefix + strings.TrimSpace(campaignGroupID)
+}
+
+// ParseCampaignGroupID accepts either a numeric campaign group ID or a campaign group URN
+// and returns the numeric ID.
+func ParseCampaignGroupID(reference string) (string, error) {
+	id := strings.TrimPrefix(strings.TrimSpace(reference), CampaignGroupURNPrefix)
+	if id == "" {
+		return "", fmt.Errorf("campaign group reference cannot be empty")
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("campaign group reference %q must be a numeric ID or %s{id}", reference, CampaignGroupURNPrefix)
+		}
+	}
+	return id, nil
+}
+
+// ElementID extracts the numeric campaign group ID from a decoded campaign group entity.
+// LinkedIn returns the id as a JSON number, which encoding/json decodes as float64.
+func ElementID(element map[string]any) string {
+	switch v := element["id"].(type) {
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case string:
+		return strings.TrimPrefix(strings.TrimSpace(v), CampaignGroupURNPrefix)
+	default:
+		return ""
+	}
+}
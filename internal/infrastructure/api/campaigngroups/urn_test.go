@@ -0,0 +1,33 @@
+package campaigngroups
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCampaignGroupID(t *testing.T) {
+	id, err := ParseCampaignGroupID("612345678")
+	require.NoError(t, err)
+	require.Equal(t, "612345678", id)
+
+	id, err = ParseCampaignGroupID(" urn:li:sponsoredCampaignGroup:612345678 ")
+	require.NoError(t, err)
+	require.Equal(t, "612345678", id)
+
+	_, err = ParseCampaignGroupID("urn:li:sponsoredCampaign:1")
+	require.Error(t, err)
+
+	_, err = ParseCampaignGroupID("  ")
+	require.Error(t, err)
+}
+
+func TestCampaignGroupURN(t *testing.T) {
+	require.Equal(t, "urn:li:sponsoredCampaignGroup:42", CampaignGroupURN(" 42 "))
+}
+
+func TestElementID(t *testing.T) {
+	require.Equal(t, "612345678", ElementID(map[string]any{"id": float64(612345678)}))
+	require.Equal(t, "42", ElementID(map[string]any{"id": "urn:li:sponsoredCampaignGroup:42"}))
+	require.Equal(t, "", ElementID(map[string]any{}))
+}
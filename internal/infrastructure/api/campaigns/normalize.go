@@ -0,0 +1,78 @@
+package campaigns
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NormalizeCampaign maps one LinkedIn campaign entity to the flat MCP view. Fields missing
+// from the entity are left empty.
+func NormalizeCampaign(raw map[string]any) NormalizedCampaign {
+	out := NormalizedCampaign{}
+	if raw == nil {
+		return out
+	}
+
+	out.CampaignID = ElementID(raw)
+	if out.CampaignID != "" {
+		out.CampaignURN = CampaignURN(out.CampaignID)
+	}
+	out.Name = stringField(raw, "name")
+	out.Status = stringField(raw, "status")
+	out.Type = stringField(raw, "type")
+	out.Format = stringField(raw, "format")
+	out.ObjectiveType = stringField(raw, "objectiveType")
+	out.CostType = stringField(raw, "costType")
+	out.AccountURN = stringField(raw, "account")
+	out.CampaignGroupURN = stringField(raw, "campaignGroup")
+
+	out.DailyBudget, out.CurrencyCode = moneyField(raw, "dailyBudget", out.CurrencyCode)
+	out.TotalBudget, out.CurrencyCode = moneyField(raw, "totalBudget", out.CurrencyCode)
+	out.UnitCost, out.CurrencyCode = moneyField(raw, "unitCost", out.CurrencyCode)
+
+	if schedule, ok := raw["runSchedule"].(map[string]any); ok {
+		out.RunScheduleStart = epochMillisField(schedule, "start")
+		out.RunScheduleEnd = epochMillisField(schedule, "end")
+	}
+
+	if test, ok := raw["test"].(bool); ok {
+		out.Test = &test
+	}
+
+	return out
+}
+
+func stringField(raw map[string]any, key string) string {
+	value, _ := raw[key].(string)
+	return strings.TrimSpace(value)
+}
+
+// moneyField reads a LinkedIn MoneyAmount ({"amount": "50.00", "currencyCode": "USD"}) and
+// returns the amount plus the currency, falling back to the currency already seen.
+func moneyField(raw map[string]any, key, currency string) (string, string) {
+	money, ok := raw[key].(map[string]any)
+	if !ok {
+		return "", currency
+	}
+	if code := stringField(money, "currencyCode"); code != "" {
+		currency = code
+	}
+	return stringField(money, "amount"), currency
+}
+
+// epochMillisField formats a LinkedIn epoch-milliseconds timestamp as RFC 3339 in UTC.
+func epochMillisField(raw map[string]any, key string) string {
+	switch v := raw[key].(type) {
+	case float64:
+		return time.UnixMilli(int64(v)).UTC().Format(time.RFC3339)
+	case string:
+		millis, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return ""
+		}
+		return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+	default:
+		return ""
+	}
+}
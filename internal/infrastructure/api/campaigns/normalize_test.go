@@ -0,0 +1,41 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCampaign(t *testing.T) {
+	normalized := NormalizeCampaign(map[string]any{
+		"id":            float64(394073893),
+		"name":          "Q3 Demand Gen",
+		"status":        "ACTIVE",
+		"type":          "SPONSORED_UPDATES",
+		"costType":      "CPM",
+		"objectiveType": "LEAD_GENERATION",
+		"account":       "urn:li:sponsoredAccount:512345678",
+		"campaignGroup": "urn:li:sponsoredCampaignGroup:612345678",
+		"dailyBudget":   map[string]any{"amount": "100.00", "currencyCode": "EUR"},
+		"unitCost":      map[string]any{"amount": "8.50"},
+		"runSchedule":   map[string]any{"start": float64(1719792000000)},
+		"test":          false,
+	})
+
+	require.Equal(t, "394073893", normalized.CampaignID)
+	require.Equal(t, "urn:li:sponsoredCampaign:394073893", normalized.CampaignURN)
+	require.Equal(t, "Q3 Demand Gen", normalized.Name)
+	require.Equal(t, "urn:li:sponsoredCampaignGroup:612345678", normalized.CampaignGroupURN)
+	require.Equal(t, "EUR", normalized.CurrencyCode)
+	require.Equal(t, "100.00", normalized.DailyBudget)
+	require.Empty(t, normalized.TotalBudget)
+	require.Equal(t, "8.50", normalized.UnitCost)
+	require.Equal(t, "2024-07-01T00:00:00Z", normalized.RunScheduleStart)
+	require.Empty(t, normalized.RunScheduleEnd)
+	require.NotNil(t, normalized.Test)
+	require.False(t, *normalized.Test)
+}
+
+func TestNormalizeCampaign_Nil(t *testing.T) {
+	require.Equal(t, NormalizedCampaign{}, NormalizeCampaign(nil))
+}
@@ -13,3 +13,19 @@ type SearchInput struct {
 	PageSize               int
 	PageToken              string
 }
+
+// UpdateInput describes a Rest.li partial update of a single campaign.
+// Set holds the fields to overwrite and Delete the optional fields to clear;
+// fields absent from both are left untouched by LinkedIn.
+type UpdateInput struct {
+	AccountID  string
+	CampaignID string
+	Set        map[string]any
+	Delete     []string
+}
+
+// GetInput fetches campaigns of one ad account by numeric ID.
+type GetInput struct {
+	AccountID   string
+	CampaignIDs []string
+}
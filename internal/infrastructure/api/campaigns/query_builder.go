@@ -22,6 +22,23 @@ func (qb *QueryBuilder) BuildSearchCampaignsQuery(input SearchInput) string {
 	return fullURL
 }
 
+// BuildCampaignsQuery builds the campaigns collection URL of an ad account, used for BATCH_GET.
+func (qb *QueryBuilder) BuildCampaignsQuery(accountID string) string {
+	return fmt.Sprintf("%s/adAccounts/%s/adCampaigns",
+		strings.TrimRight(qb.baseURL, "/"),
+		url.PathEscape(strings.TrimSpace(accountID)),
+	)
+}
+
+// BuildCampaignQuery builds the entity URL of one campaign, used for GET and partial updates.
+func (qb *QueryBuilder) BuildCampaignQuery(accountID, campaignID string) string {
+	return fmt.Sprintf("%s/adAccounts/%s/adCampaigns/%s",
+		strings.TrimRight(qb.baseURL, "/"),
+		url.PathEscape(strings.TrimSpace(accountID)),
+		url.PathEscape(strings.TrimSpace(campaignID)),
+	)
+}
+
 func (qb *QueryBuilder) buildQueryParams(input SearchInput) string {
 	var params []string
 
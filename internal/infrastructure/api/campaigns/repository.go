@@ -2,123 +2,33 @@ package campaigns
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/url"
-	"strconv"
-	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api/gateway"
-	"linkedin-mcp/internal/infrastructure/middleware"
 )
 
 const (
-	logMessageFailedRequest        = "failed to make request"
-	logMessageLinkedInAPIError     = "linkedin api responded with error"
-	logMessageFailedDecodeResponse = "failed to decode response"
-
-	logTagURL    = "url"
-	logTagError  = "error"
-	logTagStatus = "status"
-	logTagBody   = "body"
-
-	errFmtFailedRequest         = "failed to make request: %w"
-	errFmtLinkedInAPIErrorJSON  = "linkedin api error: status %d, body: %v"
-	errFmtLinkedInAPIErrorPlain = "linkedin api error: status %d, body: %s"
-	errFmtLinkedInAPIError      = "linkedin api error: status %d"
-	errFmtDecodeResponse        = "failed to decode response: %w"
-
 	pagingNextKey       = "next"
 	queryParamPageToken = "pageToken"
 )
 
-type Logger interface {
-	Error(ctx context.Context, message string, tags map[string]string)
-}
-
 type Repository struct {
-	gatewayClient *gateway.Client
-	queryBuilder  *QueryBuilder
-	logger        Logger
+	executor     *gateway.Executor
+	queryBuilder *QueryBuilder
 }
 
-func NewRepository(gatewayClient *gateway.Client, queryBuilder *QueryBuilder, logger Logger) *Repository {
+func NewRepository(executor *gateway.Executor, queryBuilder *QueryBuilder) *Repository {
 	return &Repository{
-		gatewayClient: gatewayClient,
-		queryBuilder:  queryBuilder,
-		logger:        logger,
+		executor:     executor,
+		queryBuilder: queryBuilder,
 	}
 }
 
 func (r *Repository) SearchCampaigns(ctx context.Context, input SearchInput) (*SearchResult, error) {
-	requestURL := r.queryBuilder.BuildSearchCampaignsQuery(input)
-	resourcePath, query, err := gateway.ParseLinkedInRESTProxyTarget(requestURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build gateway proxy target: %w", err)
-	}
-	userID, ok := middleware.UserIDFromContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf("missing authenticated user in request context")
-	}
-
-	connectionResponse, err := r.gatewayClient.GetLinkedInConnection(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch LinkedIn connection state from gateway: %w", err)
-	}
-	if gateway.IsLinkedInNotConnectedResponse(connectionResponse) {
-		return nil, gateway.ErrLinkedInNotConnected
-	}
-	if connectionResponse.StatusCode < 200 || connectionResponse.StatusCode >= 300 {
-		return nil, fmt.Errorf("failed to fetch LinkedIn connection state from gateway: status %d", connectionResponse.StatusCode)
-	}
-
-	response, err := r.gatewayClient.ProxyLinkedInOrRefresh(ctx, userID, resourcePath, query, nil)
-	if err != nil {
-		r.logError(ctx, logMessageFailedRequest, map[string]string{
-			logTagURL:   requestURL,
-			logTagError: err.Error(),
-		})
-		return nil, fmt.Errorf(errFmtFailedRequest, err)
-	}
-	if gateway.IsLinkedInNotConnectedResponse(response) {
-		return nil, gateway.ErrLinkedInNotConnected
-	}
-	if validationErr, ok := gateway.ParseLinkedInParamValidationResponse(response); ok {
-		return nil, validationErr
-	}
-
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		bodyString := strings.TrimSpace(string(response.Body))
-		tags := map[string]string{
-			logTagURL:    requestURL,
-			logTagStatus: strconv.Itoa(response.StatusCode),
-		}
-		if bodyString != "" {
-			tags[logTagBody] = bodyString
-		}
-
-		r.logError(ctx, logMessageLinkedInAPIError, tags)
-
-		var errBody any
-		if err := json.Unmarshal(response.Body, &errBody); err == nil {
-			return nil, fmt.Errorf(errFmtLinkedInAPIErrorJSON, response.StatusCode, errBody)
-		}
-
-		trimmedBody := strings.TrimSpace(string(response.Body))
-		if trimmedBody != "" {
-			return nil, fmt.Errorf(errFmtLinkedInAPIErrorPlain, response.StatusCode, trimmedBody)
-		}
-
-		return nil, fmt.Errorf(errFmtLinkedInAPIError, response.StatusCode)
-	}
-
 	var liResp LinkedInResponse
-	if err := json.Unmarshal(response.Body, &liResp); err != nil {
-		r.logError(ctx, logMessageFailedDecodeResponse, map[string]string{
-			logTagURL:   requestURL,
-			logTagError: err.Error(),
-		})
-		return nil, fmt.Errorf(errFmtDecodeResponse, err)
+	if err := r.executor.GetJSON(ctx, r.queryBuilder.BuildSearchCampaignsQuery(input), nil, &liResp); err != nil {
+		return nil, err
 	}
 
 	result := &SearchResult{
@@ -136,10 +46,55 @@ func (r *Repository) SearchCampaigns(ctx context.Context, input SearchInput) (*S
 	return result, nil
 }
 
-func (r *Repository) logError(ctx context.Context, message string, tags map[string]string) {
-	if r.logger == nil {
-		return
+// UpdateCampaign applies a Rest.li partial update to one campaign. LinkedIn answers a
+// successful partial update with 204 No Content, so there is no entity to decode.
+func (r *Repository) UpdateCampaign(ctx context.Context, input UpdateInput) error {
+	if len(input.Set) == 0 && len(input.Delete) == 0 {
+		return fmt.Errorf("campaign update has no fields to change")
+	}
+
+	requestURL := r.queryBuilder.BuildCampaignQuery(input.AccountID, input.CampaignID)
+	resourcePath, _, err := gateway.ParseLinkedInRESTProxyTarget(requestURL)
+	if err != nil {
+		return fmt.Errorf("failed to build gateway proxy target: %w", err)
+	}
+
+	_, err = r.executor.Do(ctx, requestURL, gateway.NewPartialUpdateRequest(resourcePath, input.Set, input.Delete))
+	return err
+}
+
+// GetCampaigns fetches campaigns by ID with a Rest.li GET for a single ID and BATCH_GET for
+// several. IDs LinkedIn does not return are reported in NotFound instead of failing the call.
+func (r *Repository) GetCampaigns(ctx context.Context, input GetInput) (*GetResult, error) {
+	if len(input.CampaignIDs) == 0 {
+		return nil, fmt.Errorf("at least one campaign ID is required")
+	}
+
+	result := &GetResult{}
+	if len(input.CampaignIDs) == 1 {
+		id := input.CampaignIDs[0]
+		entity, err := r.executor.GetEntity(ctx, r.queryBuilder.BuildCampaignQuery(input.AccountID, id))
+		if err != nil {
+			return nil, err
+		}
+		if entity == nil {
+			result.NotFound = append(result.NotFound, id)
+		} else {
+			result.Elements = append(result.Elements, entity)
+		}
+		return result, nil
 	}
 
-	r.logger.Error(ctx, message, tags)
+	batch, err := r.executor.BatchGet(ctx, r.queryBuilder.BuildCampaignsQuery(input.AccountID), input.CampaignIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range input.CampaignIDs {
+		if entity, ok := batch.Results[id]; ok {
+			result.Elements = append(result.Elements, entity)
+			continue
+		}
+		result.NotFound = append(result.NotFound, id)
+	}
+	return result, nil
 }
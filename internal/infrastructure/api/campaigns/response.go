@@ -11,3 +11,32 @@ type SearchResult struct {
 		NextPageToken string `json:"nextPageToken,omitempty"`
 	} `json:"metadata,omitempty"`
 }
+
+// GetResult holds the campaigns returned by GET/BATCH_GET in request order. IDs LinkedIn did
+// not return are listed in NotFound.
+type GetResult struct {
+	Elements []map[string]any
+	NotFound []string
+}
+
+// NormalizedCampaign is a flat, agent-friendly view of the campaign fields analysts ask
+// about most. Monetary amounts keep LinkedIn's decimal string representation.
+type NormalizedCampaign struct {
+	CampaignID       string `json:"campaignId"`
+	CampaignURN      string `json:"campaignUrn"`
+	Name             string `json:"name,omitempty"`
+	Status           string `json:"status,omitempty"`
+	Type             string `json:"type,omitempty"`
+	Format           string `json:"format,omitempty"`
+	ObjectiveType    string `json:"objectiveType,omitempty"`
+	CostType         string `json:"costType,omitempty"`
+	AccountURN       string `json:"accountUrn,omitempty"`
+	CampaignGroupURN string `json:"campaignGroupUrn,omitempty"`
+	CurrencyCode     string `json:"currencyCode,omitempty"`
+	DailyBudget      string `json:"dailyBudget,omitempty"`
+	TotalBudget      string `json:"totalBudget,omitempty"`
+	UnitCost         string `json:"unitCost,omitempty"`
+	RunScheduleStart string `json:"runScheduleStart,omitempty"`
+	RunScheduleEnd   string `json:"runScheduleEnd,omitempty"`
+	Test             *bool  `json:"test,omitempty"`
+}
@@ -0,0 +1,45 @@
+package campaigns
+
+// Campaign statuses accepted by the adCampaigns search finder and returned on campaign entities.
+// Reference: https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads/account-structure/create-and-manage-campaigns
+const (
+	StatusActive          = "ACTIVE"
+	StatusPaused          = "PAUSED"
+	StatusArchived        = "ARCHIVED"
+	StatusCompleted       = "COMPLETED"
+	StatusCanceled        = "CANCELED"
+	StatusDraft           = "DRAFT"
+	StatusPendingDeletion = "PENDING_DELETION"
+	StatusRemoved         = "REMOVED"
+)
+
+var validStatuses = map[string]bool{
+	StatusActive:          true,
+	StatusPaused:          true,
+	StatusArchived:        true,
+	StatusCompleted:       true,
+	StatusCanceled:        true,
+	StatusDraft:           true,
+	StatusPendingDeletion: true,
+	StatusRemoved:         true,
+}
+
+// statusTransitions lists the status changes the MCP server is willing to issue through a
+// partial update. Terminal states (COMPLETED, CANCELED, REMOVED, PENDING_DELETION) and
+// launching a DRAFT are deliberately excluded: they are irreversible or need more than a
+// status flip, so they stay in Campaign Manager.
+var statusTransitions = map[string]map[string]bool{
+	StatusActive:   {StatusPaused: true, StatusArchived: true},
+	StatusPaused:   {StatusActive: true, StatusArchived: true},
+	StatusArchived: {StatusActive: true, StatusPaused: true},
+}
+
+// IsValidStatus reports whether status is a known LinkedIn campaign status.
+func IsValidStatus(status string) bool {
+	return validStatuses[status]
+}
+
+// CanTransitionStatus reports whether a campaign in status from may be moved to status to.
+func CanTransitionStatus(from, to string) bool {
+	return statusTransitions[from][to]
+}
@@ -0,0 +1,17 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransitionStatus(t *testing.T) {
+	require.True(t, CanTransitionStatus(StatusActive, StatusPaused))
+	require.True(t, CanTransitionStatus(StatusPaused, StatusActive))
+	require.True(t, CanTransitionStatus(StatusArchived, StatusPaused))
+	require.False(t, CanTransitionStatus(StatusActive, StatusActive))
+	require.False(t, CanTransitionStatus(StatusCompleted, StatusActive))
+	require.False(t, CanTransitionStatus(StatusDraft, StatusActive))
+	require.False(t, CanTransitionStatus(StatusPaused, StatusRemoved))
+}
@@ -0,0 +1,44 @@
+package campaigns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CampaignURNPrefix is the LinkedIn URN prefix for sponsored campaigns.
+// Format: urn:li:sponsoredCampaign:{id}
+const CampaignURNPrefix = "urn:li:sponsoredCampaign:"
+
+// CampaignURN returns the URN for a numeric campaign ID.
+func CampaignURN(campaignID string) string {
+	return CampaignURNPrefix + strings.TrimSpace(campaignID)
+}
+
+// ParseCampaignID accepts either a numeric campaign ID or a campaign URN and returns the
+// numeric ID.
+func ParseCampaignID(reference string) (string, error) {
+	id := strings.TrimPrefix(strings.TrimSpace(reference), CampaignURNPrefix)
+	if id == "" {
+		return "", fmt.Errorf("campaign reference cannot be empty")
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("campaign reference %q must be a numeric ID or %s{id}", reference, CampaignURNPrefix)
+		}
+	}
+	return id, nil
+}
+
+// ElementID extracts the numeric campaign ID from a decoded campaign entity. LinkedIn returns
+// the id as a JSON number, which encoding/json decodes as float64.
+func ElementID(element map[string]any) string {
+	switch v := element["id"].(type) {
+	case float64:
+		return strconv.FormatInt(int64(v), 10)
+	case string:
+		return strings.TrimPrefix(strings.TrimSpace(v), CampaignURNPrefix)
+	default:
+		return ""
+	}
+}
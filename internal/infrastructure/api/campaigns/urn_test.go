@@ -0,0 +1,29 @@
+package campaigns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCampaignID(t *testing.T) {
+	id, err := ParseCampaignID(" urn:li:sponsoredCampaign:394073893 ")
+	require.NoError(t, err)
+	require.Equal(t, "394073893", id)
+
+	id, err = ParseCampaignID("394073893")
+	require.NoError(t, err)
+	require.Equal(t, "394073893", id)
+
+	_, err = ParseCampaignID("urn:li:sponsoredCampaignGroup:1")
+	require.Error(t, err)
+
+	_, err = ParseCampaignID("")
+	require.Error(t, err)
+}
+
+func TestElementID(t *testing.T) {
+	require.Equal(t, "394073893", ElementID(map[string]any{"id": float64(394073893)}))
+	require.Equal(t, "12", ElementID(map[string]any{"id": "urn:li:sponsoredCampaign:12"}))
+	require.Equal(t, "", ElementID(map[string]any{}))
+}
@@ -11,3 +11,9 @@ type SearchInput struct {
 	PageToken    string
 	SortOrder    string
 }
+
+// GetInput fetches creatives of one ad account by URN (urn:li:sponsoredCreative:{id}).
+type GetInput struct {
+	AccountID    string
+	CreativeURNs []string
+}
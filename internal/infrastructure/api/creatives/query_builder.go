@@ -43,6 +43,20 @@ func (qb *QueryBuilder) BuildSearchCreativesByCampaignsQuery(input SearchInput)
 	return endpoint + "?" + strings.Join(params, "&")
 }
 
+// BuildCreativesQuery builds the creatives collection URL of an ad account, used for BATCH_GET.
+func (qb *QueryBuilder) BuildCreativesQuery(accountID string) string {
+	return fmt.Sprintf("%s/adAccounts/%s/creatives",
+		strings.TrimRight(qb.baseURL, "/"),
+		url.PathEscape(strings.TrimSpace(accountID)),
+	)
+}
+
+// BuildCreativeQuery builds the entity URL of one creative. LinkedIn keys creatives by their
+// URN, which must be URL-encoded in the path (…/creatives/urn%3Ali%3AsponsoredCreative%3A123).
+func (qb *QueryBuilder) BuildCreativeQuery(accountID, creativeURN string) string {
+	return qb.BuildCreativesQuery(accountID) + "/" + url.QueryEscape(strings.TrimSpace(creativeURN))
+}
+
 func buildRestLiEncodedList(items []string) string {
 	encoded := make([]string, 0, len(items))
 	for _, item := range items {
@@ -46,3 +46,11 @@ func TestQueryBuilder_BuildSearchCreativesByCampaignsQuery_EncodesURN(t *testing
 	rawList := parsed.Query().Get("campaigns")
 	require.Contains(t, rawList, "394073893")
 }
+
+func TestQueryBuilder_BuildCreativeQuery_EncodesURN(t *testing.T) {
+	qb := NewQueryBuilder("https://api.linkedin.com/rest/")
+
+	u := qb.BuildCreativeQuery("512247261", "urn:li:sponsoredCreative:123")
+
+	require.Equal(t, "https://api.linkedin.com/rest/adAccounts/512247261/creatives/urn%3Ali%3AsponsoredCreative%3A123", u)
+}
@@ -2,121 +2,31 @@ package creatives
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api/gateway"
-	"linkedin-mcp/internal/infrastructure/middleware"
 )
 
-const (
-	logMessageFailedRequest        = "failed to make request"
-	logMessageLinkedInAPIError     = "linkedin api responded with error"
-	logMessageFailedDecodeResponse = "failed to decode response"
-
-	logTagURL    = "url"
-	logTagError  = "error"
-	logTagStatus = "status"
-	logTagBody   = "body"
-
-	errFmtFailedRequest         = "failed to make request: %w"
-	errFmtLinkedInAPIErrorJSON  = "linkedin api error: status %d, body: %v"
-	errFmtLinkedInAPIErrorPlain = "linkedin api error: status %d, body: %s"
-	errFmtLinkedInAPIError      = "linkedin api error: status %d"
-	errFmtDecodeResponse        = "failed to decode response: %w"
-)
-
-type Logger interface {
-	Error(ctx context.Context, message string, tags map[string]string)
-}
-
 type Repository struct {
-	gatewayClient *gateway.Client
-	queryBuilder  *QueryBuilder
-	logger        Logger
+	executor     *gateway.Executor
+	queryBuilder *QueryBuilder
 }
 
-func NewRepository(gatewayClient *gateway.Client, queryBuilder *QueryBuilder, logger Logger) *Repository {
+func NewRepository(executor *gateway.Executor, queryBuilder *QueryBuilder) *Repository {
 	return &Repository{
-		gatewayClient: gatewayClient,
-		queryBuilder:  queryBuilder,
-		logger:        logger,
+		executor:     executor,
+		queryBuilder: queryBuilder,
 	}
 }
 
 func (r *Repository) SearchCreatives(ctx context.Context, input SearchInput) (*SearchResult, error) {
-	requestURL := r.queryBuilder.BuildSearchCreativesByCampaignsQuery(input)
-	resourcePath, query, err := gateway.ParseLinkedInRESTProxyTarget(requestURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build gateway proxy target: %w", err)
-	}
-	userID, ok := middleware.UserIDFromContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf("missing authenticated user in request context")
-	}
-
-	connectionResponse, err := r.gatewayClient.GetLinkedInConnection(ctx, userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch LinkedIn connection state from gateway: %w", err)
-	}
-	if gateway.IsLinkedInNotConnectedResponse(connectionResponse) {
-		return nil, gateway.ErrLinkedInNotConnected
-	}
-	if connectionResponse.StatusCode < 200 || connectionResponse.StatusCode >= 300 {
-		return nil, fmt.Errorf("failed to fetch LinkedIn connection state from gateway: status %d", connectionResponse.StatusCode)
-	}
-
 	// LinkedIn Rest.li creatives criteria finder expects X-RestLi-Method: FINDER (see Microsoft Learn).
-	response, err := r.gatewayClient.ProxyLinkedInOrRefresh(ctx, userID, resourcePath, query, map[string]string{
-		"X-RestLi-Method": "FINDER",
-	})
-	if err != nil {
-		r.logError(ctx, logMessageFailedRequest, map[string]string{
-			logTagURL:   requestURL,
-			logTagError: err.Error(),
-		})
-		return nil, fmt.Errorf(errFmtFailedRequest, err)
-	}
-	if gateway.IsLinkedInNotConnectedResponse(response) {
-		return nil, gateway.ErrLinkedInNotConnected
-	}
-	if validationErr, ok := gateway.ParseLinkedInParamValidationResponse(response); ok {
-		return nil, validationErr
-	}
-
-	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		bodyString := strings.TrimSpace(string(response.Body))
-		tags := map[string]string{
-			logTagURL:    requestURL,
-			logTagStatus: strconv.Itoa(response.StatusCode),
-		}
-		if bodyString != "" {
-			tags[logTagBody] = bodyString
-		}
-
-		r.logError(ctx, logMessageLinkedInAPIError, tags)
-
-		var errBody any
-		if err := json.Unmarshal(response.Body, &errBody); err == nil {
-			return nil, fmt.Errorf(errFmtLinkedInAPIErrorJSON, response.StatusCode, errBody)
-		}
-
-		if bodyString != "" {
-			return nil, fmt.Errorf(errFmtLinkedInAPIErrorPlain, response.StatusCode, bodyString)
-		}
-
-		return nil, fmt.Errorf(errFmtLinkedInAPIError, response.StatusCode)
-	}
-
 	var liResp LinkedInListResponse
-	if err := json.Unmarshal(response.Body, &liResp); err != nil {
-		r.logError(ctx, logMessageFailedDecodeResponse, map[string]string{
-			logTagURL:   requestURL,
-			logTagError: err.Error(),
-		})
-		return nil, fmt.Errorf(errFmtDecodeResponse, err)
+	err := r.executor.GetJSON(ctx, r.queryBuilder.BuildSearchCreativesByCampaignsQuery(input), map[string]string{
+		gateway.HeaderRestLiMethod: gateway.RestLiMethodFinder,
+	}, &liResp)
+	if err != nil {
+		return nil, err
 	}
 
 	normalized := make([]NormalizedCreative, 0, len(liResp.Elements))
@@ -143,9 +53,39 @@ func summarizeCreativesPagination(metadata map[string]any, requestedPageSize int
 	return out
 }
 
-func (r *Repository) logError(ctx context.Context, message string, tags map[string]string) {
-	if r.logger == nil {
-		return
+// GetCreatives fetches creatives of one ad account with a Rest.li GET for a single creative and
+// BATCH_GET for several. Creatives are keyed by URN; URNs LinkedIn does not return are
+// reported in NotFound instead of failing the call.
+func (r *Repository) GetCreatives(ctx context.Context, input GetInput) (*GetResult, error) {
+	if len(input.CreativeURNs) == 0 {
+		return nil, fmt.Errorf("at least one creative URN is required")
+	}
+
+	result := &GetResult{}
+	if len(input.CreativeURNs) == 1 {
+		urn := input.CreativeURNs[0]
+		entity, err := r.executor.GetEntity(ctx, r.queryBuilder.BuildCreativeQuery(input.AccountID, urn))
+		if err != nil {
+			return nil, err
+		}
+		if entity == nil {
+			result.NotFound = append(result.NotFound, urn)
+		} else {
+			result.Elements = append(result.Elements, entity)
+		}
+		return result, nil
+	}
+
+	batch, err := r.executor.BatchGet(ctx, r.queryBuilder.BuildCreativesQuery(input.AccountID), input.CreativeURNs)
+	if err != nil {
+		return nil, err
+	}
+	for _, urn := range input.CreativeURNs {
+		if entity, ok := batch.Results[urn]; ok {
+			result.Elements = append(result.Elements, entity)
+			continue
+		}
+		result.NotFound = append(result.NotFound, urn)
 	}
-	r.logger.Error(ctx, message, tags)
+	return result, nil
 }
@@ -0,0 +1,58 @@
+package creatives
+
+import (
+	"context"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/api/gateway/recording"
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSearchCreatives_RecordedContentVariants replays a recorded creatives finder response
+// covering the content shapes LinkedIn returns for share references, spotlight ads, media,
+// inline posts and formats the normalizer does not know.
+func TestSearchCreatives_RecordedContentVariants(t *testing.T) {
+	replayer, err := recording.NewReplayerFromFile("testdata/creative_variants.json")
+	require.NoError(t, err)
+	executor := gateway.NewExecutor(gateway.NewClient(replayer, "http://gateway.invalid", "secret"), nil)
+	repository := NewRepository(executor, NewQueryBuilder("https://api.linkedin.com/rest"))
+
+	result, err := repository.SearchCreatives(middleware.ContextWithUserID(context.Background(), "user_1"), SearchInput{
+		AccountID:    "512345678",
+		CampaignURNs: []string{"urn:li:sponsoredCampaign:700000001"},
+		PageSize:     100,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "DgEAAAGM", result.Paging.NextPageToken)
+	require.Len(t, result.Elements, 5)
+
+	reference := result.Elements[0]
+	require.Equal(t, "810000001", reference.CreativeID)
+	require.Equal(t, "content_reference", reference.ContentKind)
+
+	spotlight := result.Elements[1]
+	require.Equal(t, "spotlight", spotlight.ContentKind)
+	require.Equal(t, "PENDING", spotlight.ReviewStatus)
+	require.NotNil(t, spotlight.IsServing)
+	require.False(t, *spotlight.IsServing)
+	require.Equal(t, "See Acme in action", spotlight.Headline)
+	require.Equal(t, "REQUEST_DEMO", spotlight.CTA)
+	require.Equal(t, "https://acme.example/demo", spotlight.LandingPageURL)
+
+	media := result.Elements[2]
+	require.Equal(t, "urn:li:sponsoredCreative:810000003", media.CreativeURN)
+	require.Equal(t, "media", media.ContentKind)
+	require.Equal(t, "Product tour", media.Headline)
+	require.Equal(t, "https://acme.example/tour", media.LandingPageURL)
+
+	inline := result.Elements[3]
+	require.Equal(t, "inline_post", inline.ContentKind)
+	require.Equal(t, "https://acme.example/pipeline", inline.LandingPageURL)
+	require.Equal(t, "LEARN_MORE", inline.CTA)
+
+	require.Equal(t, "structured_content", result.Elements[4].ContentKind)
+}
@@ -33,3 +33,10 @@ type PagingSummary struct {
 	PageSize      int    `json:"pageSize,omitempty"`
 	NextPageToken string `json:"nextPageToken,omitempty"`
 }
+
+// GetResult holds the raw creatives returned by GET/BATCH_GET in request order. URNs LinkedIn
+// did not return are listed in NotFound.
+type GetResult struct {
+	Elements []map[string]any
+	NotFound []string
+}
@@ -0,0 +1,26 @@
+package creatives
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CreativeURN returns the URN for a numeric creative ID.
+func CreativeURN(creativeID string) string {
+	return sponsoredCreativeURNPrefix + strings.TrimSpace(creativeID)
+}
+
+// ParseCreativeID accepts either a numeric creative ID or a creative URN and returns the
+// numeric ID.
+func ParseCreativeID(reference string) (string, error) {
+	id := strings.TrimPrefix(strings.TrimSpace(reference), sponsoredCreativeURNPrefix)
+	if id == "" {
+		return "", fmt.Errorf("creative reference cannot be empty")
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("creative reference %q must be a numeric ID or %s{id}", reference, sponsoredCreativeURNPrefix)
+		}
+	}
+	return id, nil
+}
@@ -0,0 +1,20 @@
+package creatives
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreativeID(t *testing.T) {
+	id, err := ParseCreativeID("urn:li:sponsoredCreative:123")
+	require.NoError(t, err)
+	require.Equal(t, "123", id)
+
+	id, err = ParseCreativeID("456")
+	require.NoError(t, err)
+	require.Equal(t, "456", id)
+
+	_, err = ParseCreativeID("urn:li:sponsoredCampaign:1")
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package direct
+
+import (
+	"log"
+	"net/http"
+)
+
+// CallbackHandler serves the OAuth redirect URL. Followed from a connect link, it sets the
+// nonce cookie and redirects to LinkedIn. Called back by LinkedIn, it checks the signed state
+// against the cookie, exchanges the authorization code and stores the user's tokens. The user
+// then retries the tool call.
+func (p *Provider) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if ticket := query.Get(connectParam); ticket != "" {
+			p.beginConsent(w, r, ticket)
+			return
+		}
+		if denied := query.Get("error"); denied != "" {
+			http.Error(w, "LinkedIn was not connected: "+firstNonEmpty(query.Get("error_description"), denied), http.StatusBadRequest)
+			return
+		}
+		var nonce string
+		if cookie, err := r.Cookie(nonceCookieName); err == nil {
+			nonce = cookie.Value
+		}
+		userID, err := p.oauth.userFromState(query.Get("state"), nonce)
+		if err != nil {
+			http.Error(w, "This LinkedIn connection is invalid, has expired or was completed in another browser than the one that opened the connect link. Retry the tool call to get a new link.", http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(w, p.oauth.nonceCookie("", -1))
+		code := query.Get("code")
+		if code == "" {
+			http.Error(w, "missing authorization code", http.StatusBadRequest)
+			return
+		}
+
+		token, err := p.oauth.Exchange(r.Context(), code)
+		if err != nil {
+			log.Printf("linkedin oauth code exchange failed: %v", err)
+			http.Error(w, "LinkedIn did not accept the authorization. Retry the tool call to get a new connect link.", http.StatusBadGateway)
+			return
+		}
+		if err := p.tokens.Put(userID, token); err != nil {
+			log.Printf("failed to store linkedin token: %v", err)
+			http.Error(w, "failed to store the LinkedIn connection", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("LinkedIn is connected. You can close this window and retry the tool call in your MCP client.\n"))
+	})
+}
+
+func (p *Provider) beginConsent(w http.ResponseWriter, r *http.Request, ticket string) {
+	authURL, nonce, err := p.oauth.begin(ticket)
+	if err != nil {
+		http.Error(w, "This LinkedIn connect link is invalid, has expired or was already used. Retry the tool call to get a new one.", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, p.oauth.nonceCookie(nonce, int(stateTTL.Seconds())))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
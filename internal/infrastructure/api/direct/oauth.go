@@ -0,0 +1,317 @@
+package direct
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+)
+
+// LinkedIn OAuth 2.0 endpoints for the authorization code flow.
+// Reference: https://learn.microsoft.com/en-us/linkedin/shared/authentication/authorization-code-flow
+const (
+	DefaultAuthorizationURL = "https://www.linkedin.com/oauth/v2/authorization"
+	DefaultTokenURL         = "https://www.linkedin.com/oauth/v2/accessToken"
+
+	// stateTTL bounds how long a connect link, and the consent it starts, stay valid.
+	stateTTL = time.Hour
+
+	// connectParam carries the signed ticket of a connect link on the redirect URL.
+	connectParam = "connect"
+	// nonceCookieName binds a consent to the browser that followed the connect link.
+	nonceCookieName = "linkedin_oauth_nonce"
+)
+
+// DefaultScopes cover reading accounts, campaigns and analytics and the campaign update tools.
+var DefaultScopes = []string{"r_ads", "r_ads_reporting", "rw_ads"}
+
+var errInvalidState = errors.New("invalid or expired OAuth state")
+
+// OAuthConfig identifies the LinkedIn developer application. RedirectURL must be registered
+// as an authorized redirect URL of the application.
+type OAuthConfig struct {
+	ClientID         string
+	ClientSecret     string
+	RedirectURL      string
+	Scopes           []string
+	AuthorizationURL string
+	TokenURL         string
+}
+
+// OAuthError is an error response of the LinkedIn token endpoint, e.g. invalid_grant for an
+// expired or revoked refresh token.
+type OAuthError struct {
+	StatusCode  int
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// revoked reports whether LinkedIn refused the grant itself, so retrying cannot succeed and
+// the user has to connect again. Other errors, such as invalid_client after a rotated client
+// secret, are configuration or transient problems that must not discard the user's grant.
+func (e *OAuthError) revoked() bool {
+	return e.Code == "invalid_grant"
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("linkedin oauth error %s: %s", e.Code, e.Description)
+	}
+	return fmt.Sprintf("linkedin oauth error %s (status %d)", e.Code, e.StatusCode)
+}
+
+// OAuth runs the LinkedIn authorization code flow. Users get a connect link to this server
+// carrying a signed, single-use ticket for their user ID. Following it sets a nonce cookie in
+// that browser and redirects to LinkedIn with a state bound to the nonce, so a consent only
+// counts when it completes in the browser that first followed the link.
+type OAuth struct {
+	config     OAuthConfig
+	stateKey   []byte
+	ticketKey  []byte
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu sync.Mutex
+	// usedTickets maps the nonce of every followed ticket to its expiry.
+	usedTickets map[string]int64
+}
+
+// NewOAuth signs states with a key derived from secret, the token store secret.
+func NewOAuth(config OAuthConfig, secret []byte) *OAuth {
+	if config.AuthorizationURL == "" {
+		config.AuthorizationURL = DefaultAuthorizationURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = DefaultTokenURL
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultScopes
+	}
+	return &OAuth{
+		config:      config,
+		stateKey:    deriveKey(secret, "oauth-state"),
+		ticketKey:   deriveKey(secret, "oauth-ticket"),
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		now:         time.Now,
+		usedTickets: map[string]int64{},
+	}
+}
+
+// ConnectURL returns the link that connects the LinkedIn account of userID. It points at
+// RedirectURL, which starts the consent with [OAuth.begin] when it sees the ticket.
+func (o *OAuth) ConnectURL(userID string) string {
+	ticket := o.seal(o.ticketKey, statePayload{UserID: userID, ExpiresAt: o.now().Add(stateTTL).Unix(), Nonce: rand.Text()})
+	separator := "?"
+	if strings.Contains(o.config.RedirectURL, "?") {
+		separator = "&"
+	}
+	return o.config.RedirectURL + separator + url.Values{connectParam: {ticket}}.Encode()
+}
+
+// begin consumes ticket and returns the LinkedIn consent page to redirect to, with a state
+// bound to nonce, the value of the nonce cookie to set in the browser.
+func (o *OAuth) begin(ticket string) (authURL, nonce string, err error) {
+	payload, err := o.open(o.ticketKey, ticket)
+	if err != nil {
+		return "", "", err
+	}
+	if !o.consumeTicket(payload) {
+		return "", "", errInvalidState
+	}
+
+	nonce = rand.Text()
+	state := o.seal(o.stateKey, statePayload{UserID: payload.UserID, ExpiresAt: o.now().Add(stateTTL).Unix(), Nonce: nonceHash(nonce)})
+	values := url.Values{
+		"response_type": {"code"},
+		"client_id":     {o.config.ClientID},
+		"redirect_uri":  {o.config.RedirectURL},
+		"state":         {state},
+		"scope":         {strings.Join(o.config.Scopes, " ")},
+	}
+	return o.config.AuthorizationURL + "?" + values.Encode(), nonce, nil
+}
+
+// consumeTicket reports whether the ticket is followed for the first time, so a connect link
+// forwarded after its owner opened it cannot start another consent.
+func (o *OAuth) consumeTicket(payload statePayload) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	now := o.now().Unix()
+	for nonce, expiresAt := range o.usedTickets {
+		if now > expiresAt {
+			delete(o.usedTickets, nonce)
+		}
+	}
+	if _, used := o.usedTickets[payload.Nonce]; used {
+		return false
+	}
+	o.usedTickets[payload.Nonce] = payload.ExpiresAt
+	return true
+}
+
+// nonceCookie returns the cookie carrying nonce to the callback; a negative maxAge clears it.
+func (o *OAuth) nonceCookie(nonce string, maxAge int) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     nonceCookieName,
+		Value:    nonce,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		// Lax still sends the cookie on LinkedIn's top-level redirect back to the callback.
+		SameSite: http.SameSiteLaxMode,
+	}
+	if redirectURL, err := url.Parse(o.config.RedirectURL); err == nil {
+		cookie.Secure = redirectURL.Scheme == "https"
+		if redirectURL.Path != "" {
+			cookie.Path = redirectURL.Path
+		}
+	}
+	return cookie
+}
+
+// Exchange trades an authorization code for tokens.
+func (o *OAuth) Exchange(ctx context.Context, code string) (Token, error) {
+	return o.token(ctx, url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {o.config.RedirectURL},
+	})
+}
+
+// Refresh renews an access token. LinkedIn keeps the refresh token's original expiry, so the
+// returned token reuses refreshToken when the response omits it.
+func (o *OAuth) Refresh(ctx context.Context, refreshToken string) (Token, error) {
+	token, err := o.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err == nil && token.RefreshToken == "" {
+		token.RefreshToken = refreshToken
+	}
+	return token, err
+}
+
+func (o *OAuth) token(ctx context.Context, form url.Values) (Token, error) {
+	form.Set("client_id", o.config.ClientID)
+	form.Set("client_secret", o.config.ClientSecret)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, o.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := o.httpClient.Do(request)
+	if err != nil {
+		return Token{}, fmt.Errorf("linkedin token request failed: %w", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return Token{}, fmt.Errorf("failed to read linkedin token response: %w", err)
+	}
+	if response.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := (&api.Response{StatusCode: response.StatusCode, Headers: response.Header}).RetryAfter(o.now())
+		return Token{}, &gateway.RateLimitError{Source: gateway.RateLimitSourceLinkedIn, StatusCode: response.StatusCode, RetryAfter: retryAfter}
+	}
+	if response.StatusCode != http.StatusOK {
+		oauthErr := &OAuthError{StatusCode: response.StatusCode}
+		_ = json.Unmarshal(body, oauthErr)
+		return Token{}, oauthErr
+	}
+
+	var payload struct {
+		AccessToken           string `json:"access_token"`
+		ExpiresIn             int64  `json:"expires_in"`
+		RefreshToken          string `json:"refresh_token"`
+		RefreshTokenExpiresIn int64  `json:"refresh_token_expires_in"`
+		Scope                 string `json:"scope"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.AccessToken == "" {
+		return Token{}, fmt.Errorf("invalid linkedin token response")
+	}
+	now := o.now()
+	token := Token{
+		AccessToken:  payload.AccessToken,
+		ExpiresAt:    now.Add(time.Duration(payload.ExpiresIn) * time.Second),
+		RefreshToken: payload.RefreshToken,
+		Scope:        payload.Scope,
+	}
+	if payload.RefreshTokenExpiresIn > 0 {
+		token.RefreshTokenExpiresAt = now.Add(time.Duration(payload.RefreshTokenExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+type statePayload struct {
+	UserID    string `json:"u"`
+	ExpiresAt int64  `json:"e"`
+	// Nonce identifies a ticket, or is the hash of the browser nonce a state is bound to.
+	Nonce string `json:"n"`
+}
+
+// userFromState verifies the signature and expiry of state, and that nonce (from the
+// browser's cookie) is the one it was issued for, and returns its user ID.
+func (o *OAuth) userFromState(state, nonce string) (string, error) {
+	payload, err := o.open(o.stateKey, state)
+	if err != nil {
+		return "", err
+	}
+	if nonce == "" || !hmac.Equal([]byte(payload.Nonce), []byte(nonceHash(nonce))) {
+		return "", errInvalidState
+	}
+	return payload.UserID, nil
+}
+
+func (o *OAuth) seal(key []byte, payload statePayload) string {
+	raw, _ := json.Marshal(payload)
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(sign(key, encoded))
+}
+
+// open verifies the signature and expiry of a value sealed with key.
+func (o *OAuth) open(key []byte, value string) (statePayload, error) {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return statePayload{}, errInvalidState
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, sign(key, encoded)) {
+		return statePayload{}, errInvalidState
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return statePayload{}, errInvalidState
+	}
+	var payload statePayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.UserID == "" || payload.Nonce == "" {
+		return statePayload{}, errInvalidState
+	}
+	if o.now().Unix() > payload.ExpiresAt {
+		return statePayload{}, errInvalidState
+	}
+	return payload, nil
+}
+
+func nonceHash(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func sign(key []byte, value string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(value))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,186 @@
+// Package direct calls the LinkedIn Marketing API without the Jumon gateway. It runs the
+// LinkedIn OAuth authorization code flow itself and keeps each user's tokens in an encrypted
+// file, for teams that do not run Jumon.
+package direct
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+)
+
+const (
+	HeaderRestLiProtocolVersion = "X-Restli-Protocol-Version"
+	restLiProtocolVersion       = "2.0.0"
+
+	// expirySkew refreshes access tokens slightly before LinkedIn stops accepting them.
+	expirySkew = time.Minute
+)
+
+var (
+	connectedBody    = []byte(`{"connected":true}`)
+	notConnectedBody = []byte(`{"connected":false,"code":"LINKEDIN_NOT_CONNECTED"}`)
+)
+
+// Provider is a [gateway.Provider] that calls LinkedIn's REST API with the user's own OAuth
+// token. Users without a stored token, or whose token can no longer be refreshed, are
+// reported as not connected and get a link to the LinkedIn consent page.
+type Provider struct {
+	httpClient api.Client
+	baseURL    string
+	apiVersion string
+	tokens     *TokenStore
+	oauth      *OAuth
+	now        func() time.Time
+}
+
+var (
+	_ gateway.Provider                     = (*Provider)(nil)
+	_ gateway.ConnectURLProvider           = (*Provider)(nil)
+	_ gateway.ExplicitNotConnectedProvider = (*Provider)(nil)
+)
+
+// NewProvider calls LinkedIn at baseURL (https://api.linkedin.com/rest) with apiVersion as
+// LinkedIn-Version, defaulting to [gateway.DefaultAPIVersion]. A LinkedIn-Version in the
+// request headers, as the [gateway.Executor] sends, takes precedence.
+func NewProvider(httpClient api.Client, baseURL, apiVersion string, tokens *TokenStore, oauth *OAuth) *Provider {
+	if apiVersion == "" {
+		apiVersion = gateway.DefaultAPIVersion
+	}
+	return &Provider{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiVersion: apiVersion,
+		tokens:     tokens,
+		oauth:      oauth,
+		now:        time.Now,
+	}
+}
+
+// ConnectURL returns the link that starts the LinkedIn consent for userID.
+func (p *Provider) ConnectURL(userID string) string {
+	return p.oauth.ConnectURL(userID)
+}
+
+// MarksNotConnectedInBody reports that every not-connected response carries
+// {"connected":false}; LinkedIn's own 404s mean a missing or inaccessible entity.
+func (p *Provider) MarksNotConnectedInBody() bool {
+	return true
+}
+
+func (p *Provider) GetLinkedInConnection(ctx context.Context, userID string) (*api.Response, error) {
+	token, ok := p.tokens.Get(userID)
+	if !ok || (p.expired(token) && !token.refreshable(p.now())) {
+		return notConnectedResponse(), nil
+	}
+	return &api.Response{StatusCode: http.StatusOK, Body: connectedBody}, nil
+}
+
+func (p *Provider) ProxyLinkedInRequestOrRefresh(ctx context.Context, userID string, request gateway.ProxyRequest) (*api.Response, error) {
+	token, ok := p.tokens.Get(userID)
+	if !ok {
+		return notConnectedResponse(), nil
+	}
+	if p.expired(token) {
+		refreshed, ok, err := p.refresh(ctx, userID, token)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return notConnectedResponse(), nil
+		}
+		token = refreshed
+	}
+
+	response, err := p.do(ctx, token, request)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+	// LinkedIn rejected the token before executing anything, so replaying after a refresh
+	// cannot apply a write twice.
+	refreshed, ok, err := p.refresh(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return notConnectedResponse(), nil
+	}
+	return p.do(ctx, refreshed, request)
+}
+
+func (p *Provider) expired(token Token) bool {
+	return !p.now().Add(expirySkew).Before(token.ExpiresAt)
+}
+
+// refresh renews the user's token. It reports false, and forgets the token, when LinkedIn
+// will not renew it any more so the user is asked to connect again; throttling, server and
+// transport failures are returned as errors and keep the token.
+func (p *Provider) refresh(ctx context.Context, userID string, token Token) (Token, bool, error) {
+	if !token.refreshable(p.now()) {
+		return Token{}, false, p.tokens.Delete(userID)
+	}
+	refreshed, err := p.oauth.Refresh(ctx, token.RefreshToken)
+	var oauthErr *OAuthError
+	if errors.As(err, &oauthErr) && oauthErr.revoked() {
+		return Token{}, false, p.tokens.Delete(userID)
+	}
+	if err != nil {
+		return Token{}, false, err
+	}
+	if refreshed.RefreshTokenExpiresAt.IsZero() {
+		refreshed.RefreshTokenExpiresAt = token.RefreshTokenExpiresAt
+	}
+	if err := p.tokens.Put(userID, refreshed); err != nil {
+		return Token{}, false, fmt.Errorf("failed to store refreshed linkedin token: %w", err)
+	}
+	return refreshed, true, nil
+}
+
+func (p *Provider) do(ctx context.Context, token Token, request gateway.ProxyRequest) (*api.Response, error) {
+	requestURL := p.baseURL + "/" + encodePath(request.ResourcePath)
+	if len(request.Query) > 0 {
+		requestURL += "?" + encodeQuery(request.Query)
+	}
+	headers := map[string]string{
+		"Authorization":               "Bearer " + token.AccessToken,
+		gateway.HeaderLinkedInVersion: p.apiVersion,
+		HeaderRestLiProtocolVersion:   restLiProtocolVersion,
+		"Accept":                      "application/json",
+	}
+	for name, value := range request.Headers {
+		headers[name] = value
+	}
+
+	var (
+		response *api.Response
+		err      error
+	)
+	switch method := strings.ToUpper(strings.TrimSpace(request.Method)); method {
+	case "", http.MethodGet:
+		response, err = p.httpClient.Get(ctx, requestURL, headers)
+	case http.MethodPost:
+		response, err = p.httpClient.Post(ctx, requestURL, request.Body, headers)
+	case http.MethodPut:
+		response, err = p.httpClient.Put(ctx, requestURL, request.Body, headers)
+	case http.MethodPatch:
+		response, err = p.httpClient.Patch(ctx, requestURL, request.Body, headers)
+	case http.MethodDelete:
+		response, err = p.httpClient.Delete(ctx, requestURL, headers)
+	default:
+		return nil, fmt.Errorf("unsupported linkedin request method %s", method)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return normalizeErrorResponse(response), nil
+}
+
+func notConnectedResponse() *api.Response {
+	return &api.Response{StatusCode: http.StatusNotFound, Body: notConnectedBody}
+}
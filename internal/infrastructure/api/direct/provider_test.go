@@ -0,0 +1,367 @@
+package direct
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	customhttp "linkedin-mcp/internal/infrastructure/http"
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLinkedIn serves the REST API for accessToken and the OAuth token endpoint, which
+// answers refreshes with refreshStatus and refreshBody (invalid_grant by default for 400) and
+// hands out "fresh-token".
+type fakeLinkedIn struct {
+	accessToken   string
+	refreshStatus int
+	refreshBody   string
+	restStatus    int
+	restBody      string
+
+	mu       sync.Mutex
+	requests []*http.Request
+	forms    []url.Values
+}
+
+func (f *fakeLinkedIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/oauth/v2/accessToken" {
+		_ = r.ParseForm()
+		f.forms = append(f.forms, r.PostForm)
+		if f.refreshStatus != 0 && r.PostForm.Get("grant_type") == "refresh_token" {
+			w.WriteHeader(f.refreshStatus)
+			if f.refreshBody != "" {
+				_, _ = w.Write([]byte(f.refreshBody))
+			} else if f.refreshStatus == http.StatusBadRequest {
+				_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"The provided authorization grant is revoked"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"error":"temporarily_unavailable"}`))
+			}
+			return
+		}
+		f.accessToken = "fresh-token"
+		_, _ = w.Write([]byte(`{"access_token":"fresh-token","expires_in":5184000,"refresh_token":"refresh-2","refresh_token_expires_in":31536000,"scope":"r_ads,r_ads_reporting"}`))
+		return
+	}
+
+	f.requests = append(f.requests, r.Clone(context.Background()))
+	if r.Header.Get("Authorization") != "Bearer "+f.accessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"status":401,"serviceErrorCode":65600,"code":"REVOKED_ACCESS_TOKEN","message":"The token used in the request has been revoked by the user"}`))
+		return
+	}
+	status := f.restStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	body := f.restBody
+	if body == "" {
+		body = `{"elements":[{"id":512345678,"name":"Acme"}]}`
+	}
+	_, _ = w.Write([]byte(body))
+}
+
+func (f *fakeLinkedIn) lastRequest() *http.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[len(f.requests)-1]
+}
+
+func newTestProvider(t *testing.T, linkedIn *fakeLinkedIn) (*Provider, *TokenStore) {
+	t.Helper()
+	server := httptest.NewServer(linkedIn)
+	t.Cleanup(server.Close)
+
+	store, err := NewTokenStore(filepath.Join(t.TempDir(), "tokens.enc"), testKey(7))
+	require.NoError(t, err)
+	oauth := NewOAuth(OAuthConfig{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		RedirectURL:  "https://mcp.example.com/oauth/linkedin/callback",
+		TokenURL:     server.URL + "/oauth/v2/accessToken",
+	}, testKey(7))
+	return NewProvider(customhttp.NewClient(nil), server.URL+"/rest", "", store, oauth), store
+}
+
+func validToken(accessToken string) Token {
+	return Token{
+		AccessToken:  accessToken,
+		ExpiresAt:    time.Now().Add(time.Hour),
+		RefreshToken: "refresh-1",
+	}
+}
+
+func userContext() context.Context {
+	return middleware.ContextWithUserID(context.Background(), "user_1")
+}
+
+func TestProvider_CallsLinkedInWithVersionHeadersAndRestLiEncoding(t *testing.T) {
+	linkedIn := &fakeLinkedIn{accessToken: "token-1"}
+	provider, store := newTestProvider(t, linkedIn)
+	require.NoError(t, store.Put("user_1", validToken("token-1")))
+
+	response, err := provider.ProxyLinkedInRequestOrRefresh(context.Background(), "user_1", gateway.ProxyRequest{
+		ResourcePath: "adAccounts/512345678/creatives",
+		Query:        map[string]string{"q": "criteria", "campaigns": "List(urn:li:sponsoredCampaign:1)"},
+		Headers:      map[string]string{gateway.HeaderRestLiMethod: gateway.RestLiMethodFinder},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	request := linkedIn.lastRequest()
+	require.Equal(t, http.MethodGet, request.Method)
+	require.Equal(t, "/rest/adAccounts/512345678/creatives", request.URL.Path)
+	require.Equal(t, "campaigns=List(urn%3Ali%3AsponsoredCampaign%3A1)&q=criteria", request.URL.RawQuery)
+	require.Equal(t, "Bearer token-1", request.Header.Get("Authorization"))
+	require.Equal(t, gateway.DefaultAPIVersion, request.Header.Get(gateway.HeaderLinkedInVersion))
+	require.Equal(t, "2.0.0", request.Header.Get(HeaderRestLiProtocolVersion))
+	require.Equal(t, gateway.RestLiMethodFinder, request.Header.Get(gateway.HeaderRestLiMethod))
+}
+
+func TestProvider_UserWithoutTokenGetsConnectLink(t *testing.T) {
+	provider, _ := newTestProvider(t, &fakeLinkedIn{})
+	executor := gateway.NewExecutor(provider, nil)
+
+	var target map[string]any
+	err := executor.GetJSON(userContext(), "https://api.linkedin.com/rest/adAccounts?q=search", nil, &target)
+
+	require.True(t, gateway.IsLinkedInNotConnected(err))
+	connectURL := gateway.ConnectURLFromError(err)
+	require.True(t, strings.HasPrefix(connectURL, "https://mcp.example.com/oauth/linkedin/callback?connect="), connectURL)
+
+	authURL, cookie := followConnectLink(t, provider, connectURL)
+	require.Equal(t, "www.linkedin.com", authURL.Host)
+	require.Equal(t, "client-id", authURL.Query().Get("client_id"))
+	require.Equal(t, "r_ads r_ads_reporting rw_ads", authURL.Query().Get("scope"))
+	require.True(t, cookie.HttpOnly)
+	require.True(t, cookie.Secure)
+	require.Equal(t, "/oauth/linkedin/callback", cookie.Path)
+	userID, stateErr := provider.oauth.userFromState(authURL.Query().Get("state"), cookie.Value)
+	require.NoError(t, stateErr)
+	require.Equal(t, "user_1", userID)
+}
+
+// followConnectLink opens connectURL like a browser and returns the LinkedIn consent page it
+// redirects to and the nonce cookie it sets.
+func followConnectLink(t *testing.T, provider *Provider, connectURL string) (*url.URL, *http.Cookie) {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	provider.CallbackHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, connectURL, nil))
+	require.Equal(t, http.StatusFound, recorder.Code, recorder.Body.String())
+	authURL, err := url.Parse(recorder.Header().Get("Location"))
+	require.NoError(t, err)
+	cookies := recorder.Result().Cookies()
+	require.Len(t, cookies, 1)
+	return authURL, cookies[0]
+}
+
+func callback(provider *Provider, state string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/oauth/linkedin/callback?code=auth-code&state="+url.QueryEscape(state), nil)
+	if cookie != nil {
+		request.AddCookie(cookie)
+	}
+	recorder := httptest.NewRecorder()
+	provider.CallbackHandler().ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestProvider_RefreshesAfter401AndRetries(t *testing.T) {
+	linkedIn := &fakeLinkedIn{accessToken: "rotated-elsewhere"}
+	provider, store := newTestProvider(t, linkedIn)
+	require.NoError(t, store.Put("user_1", validToken("token-1")))
+
+	response, err := provider.ProxyLinkedInRequestOrRefresh(context.Background(), "user_1", gateway.ProxyRequest{ResourcePath: "adAccounts"})
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "refresh_token", linkedIn.forms[0].Get("grant_type"))
+	require.Equal(t, "refresh-1", linkedIn.forms[0].Get("refresh_token"))
+	stored, _ := store.Get("user_1")
+	require.Equal(t, "fresh-token", stored.AccessToken)
+	require.Equal(t, "refresh-2", stored.RefreshToken)
+}
+
+func TestProvider_RefreshesExpiredTokenBeforeCalling(t *testing.T) {
+	linkedIn := &fakeLinkedIn{accessToken: "fresh-token"}
+	provider, store := newTestProvider(t, linkedIn)
+	expired := validToken("token-1")
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	require.NoError(t, store.Put("user_1", expired))
+
+	response, err := provider.ProxyLinkedInRequestOrRefresh(context.Background(), "user_1", gateway.ProxyRequest{ResourcePath: "adAccounts"})
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Len(t, linkedIn.requests, 1)
+	require.Equal(t, "Bearer fresh-token", linkedIn.lastRequest().Header.Get("Authorization"))
+}
+
+func TestProvider_RevokedRefreshTokenDisconnectsUser(t *testing.T) {
+	linkedIn := &fakeLinkedIn{accessToken: "rotated-elsewhere", refreshStatus: http.StatusBadRequest}
+	provider, store := newTestProvider(t, linkedIn)
+	require.NoError(t, store.Put("user_1", validToken("token-1")))
+
+	response, err := provider.ProxyLinkedInRequestOrRefresh(context.Background(), "user_1", gateway.ProxyRequest{ResourcePath: "adAccounts"})
+
+	require.NoError(t, err)
+	require.True(t, gateway.IsLinkedInNotConnectedResponse(response))
+	_, ok := store.Get("user_1")
+	require.False(t, ok)
+}
+
+func TestProvider_ThrottledRefreshKeepsToken(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusRequestTimeout} {
+		linkedIn := &fakeLinkedIn{accessToken: "rotated-elsewhere", refreshStatus: status}
+		provider, store := newTestProvider(t, linkedIn)
+		require.NoError(t, store.Put("user_1", validToken("token-1")))
+
+		_, err := provider.ProxyLinkedInRequestOrRefresh(context.Background(), "user_1", gateway.ProxyRequest{ResourcePath: "adAccounts"})
+
+		require.Error(t, err, status)
+		_, ok := store.Get("user_1")
+		require.True(t, ok, status)
+		if status == http.StatusTooManyRequests {
+			_, isRateLimit := gateway.AsRateLimit(err)
+			require.True(t, isRateLimit)
+		}
+	}
+}
+
+func TestProvider_RejectedClientKeepsToken(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized} {
+		linkedIn := &fakeLinkedIn{
+			accessToken:   "rotated-elsewhere",
+			refreshStatus: status,
+			refreshBody:   `{"error":"invalid_client","error_description":"Client authentication failed"}`,
+		}
+		provider, store := newTestProvider(t, linkedIn)
+		require.NoError(t, store.Put("user_1", validToken("token-1")))
+
+		_, err := provider.ProxyLinkedInRequestOrRefresh(context.Background(), "user_1", gateway.ProxyRequest{ResourcePath: "adAccounts"})
+
+		var oauthErr *OAuthError
+		require.ErrorAs(t, err, &oauthErr, status)
+		require.Equal(t, "invalid_client", oauthErr.Code)
+		_, ok := store.Get("user_1")
+		require.True(t, ok, "a misconfigured client secret must not discard the grant")
+	}
+}
+
+func TestProvider_ParamErrorsFollowGatewayContract(t *testing.T) {
+	linkedIn := &fakeLinkedIn{
+		accessToken: "token-1",
+		restStatus:  http.StatusBadRequest,
+		restBody:    `{"status":400,"message":"Invalid param","errorDetails":{"inputErrors":[{"code":"PARAM_INVALID","description":"wrong type","input":{"inputPath":{"fieldPath":"search"}}}]}}`,
+	}
+	provider, store := newTestProvider(t, linkedIn)
+	require.NoError(t, store.Put("user_1", validToken("token-1")))
+
+	var target map[string]any
+	err := gateway.NewExecutor(provider, nil).GetJSON(userContext(), "https://api.linkedin.com/rest/adAccounts?q=search", nil, &target)
+
+	validationErr, ok := gateway.AsLinkedInParamValidation(err)
+	require.True(t, ok)
+	require.Equal(t, "search", validationErr.InputErrors[0].FieldPath)
+}
+
+func TestProvider_LinkedInNotFoundIsNotADisconnect(t *testing.T) {
+	linkedIn := &fakeLinkedIn{
+		accessToken: "token-1",
+		restStatus:  http.StatusNotFound,
+		restBody:    `{"status":404,"code":"NOT_FOUND","message":"Not Found: adAccounts/999"}`,
+	}
+	provider, store := newTestProvider(t, linkedIn)
+	require.NoError(t, store.Put("user_1", validToken("token-1")))
+	executor := gateway.NewExecutor(provider, nil)
+
+	var target map[string]any
+	err := executor.GetJSON(userContext(), "https://api.linkedin.com/rest/adAccounts/999/adCampaigns?q=search", nil, &target)
+	require.False(t, gateway.IsLinkedInNotConnected(err))
+	var apiErr *gateway.LinkedInAPIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	entity, err := executor.GetEntity(userContext(), "https://api.linkedin.com/rest/adAccounts/999")
+	require.NoError(t, err)
+	require.Nil(t, entity)
+	_, ok := store.Get("user_1")
+	require.True(t, ok)
+}
+
+func TestCallbackHandler_StoresTokenForStateUser(t *testing.T) {
+	linkedIn := &fakeLinkedIn{}
+	provider, store := newTestProvider(t, linkedIn)
+	authURL, cookie := followConnectLink(t, provider, provider.ConnectURL("user_1"))
+
+	recorder := callback(provider, authURL.Query().Get("state"), cookie)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "LinkedIn is connected")
+	require.Equal(t, "authorization_code", linkedIn.forms[0].Get("grant_type"))
+	require.Equal(t, "auth-code", linkedIn.forms[0].Get("code"))
+	require.Equal(t, "https://mcp.example.com/oauth/linkedin/callback", linkedIn.forms[0].Get("redirect_uri"))
+	stored, ok := store.Get("user_1")
+	require.True(t, ok)
+	require.Equal(t, "fresh-token", stored.AccessToken)
+
+	connection, err := provider.GetLinkedInConnection(context.Background(), "user_1")
+	require.NoError(t, err)
+	require.False(t, gateway.IsLinkedInNotConnectedResponse(connection))
+}
+
+func TestCallbackHandler_RejectsTamperedOrExpiredState(t *testing.T) {
+	provider, store := newTestProvider(t, &fakeLinkedIn{})
+	authURL, cookie := followConnectLink(t, provider, provider.ConnectURL("user_1"))
+	state := authURL.Query().Get("state")
+	_, signature, _ := strings.Cut(state, ".")
+	payload, _ := json.Marshal(statePayload{UserID: "user_2", ExpiresAt: time.Now().Add(time.Hour).Unix(), Nonce: nonceHash(cookie.Value)})
+
+	for _, forged := range []string{"", "garbage", base64.RawURLEncoding.EncodeToString(payload) + "." + signature} {
+		require.Equal(t, http.StatusBadRequest, callback(provider, forged, cookie).Code)
+	}
+
+	provider.oauth.now = func() time.Time { return time.Now().Add(2 * stateTTL) }
+	require.Equal(t, http.StatusBadRequest, callback(provider, state, cookie).Code)
+
+	_, ok := store.Get("user_2")
+	require.False(t, ok)
+}
+
+func TestCallbackHandler_BindsConsentToTheBrowserThatFollowedTheLink(t *testing.T) {
+	provider, store := newTestProvider(t, &fakeLinkedIn{})
+	connectURL := provider.ConnectURL("user_1")
+	authURL, cookie := followConnectLink(t, provider, connectURL)
+	state := authURL.Query().Get("state")
+
+	// Another browser has no cookie, or its own from another consent.
+	require.Equal(t, http.StatusBadRequest, callback(provider, state, nil).Code)
+	_, otherCookie := followConnectLink(t, provider, provider.ConnectURL("user_1"))
+	require.Equal(t, http.StatusBadRequest, callback(provider, state, otherCookie).Code)
+
+	// A connect link only starts one consent.
+	recorder := httptest.NewRecorder()
+	provider.CallbackHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, connectURL, nil))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	_, ok := store.Get("user_1")
+	require.False(t, ok)
+
+	require.Equal(t, http.StatusOK, callback(provider, state, cookie).Code)
+	_, ok = store.Get("user_1")
+	require.True(t, ok)
+}
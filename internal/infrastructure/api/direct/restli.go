@@ -0,0 +1,130 @@
+package direct
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// urnPattern matches URN leaves inside Rest.li values, e.g. the items of List(...).
+var urnPattern = regexp.MustCompile(`urn:li:[A-Za-z0-9]+:[^,()\s]+`)
+
+// encodePath escapes each resource path segment. Entity keys that are URNs must have their
+// colons encoded (creatives/urn%3Ali%3AsponsoredCreative%3A123).
+func encodePath(resourcePath string) string {
+	segments := strings.Split(strings.Trim(resourcePath, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "urn:") {
+			segments[i] = url.QueryEscape(segment)
+		} else {
+			segments[i] = url.PathEscape(segment)
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// encodeQuery serializes proxy query values for a Rest.li 2.0 call. Values arrive decoded, as
+// the gateway takes them; LinkedIn wants the (,): structure literal and reserved characters
+// inside leaf values escaped. URNs are the leaves that contain reserved characters, so they
+// are escaped whole.
+// Reference: https://learn.microsoft.com/en-us/linkedin/shared/api-guide/concepts/protocol-version
+func encodeQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, url.QueryEscape(key)+"="+encodeRestLiValue(query[key]))
+	}
+	return strings.Join(pairs, "&")
+}
+
+func encodeRestLiValue(value string) string {
+	var builder strings.Builder
+	last := 0
+	for _, match := range urnPattern.FindAllStringIndex(value, -1) {
+		builder.WriteString(escapeStructured(value[last:match[0]]))
+		builder.WriteString(url.QueryEscape(value[match[0]:match[1]]))
+		last = match[1]
+	}
+	builder.WriteString(escapeStructured(value[last:]))
+	return builder.String()
+}
+
+// escapeStructured percent-encodes everything except unreserved characters and the Rest.li
+// delimiters.
+func escapeStructured(value string) string {
+	var builder strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'a' <= b && b <= 'z', 'A' <= b && b <= 'Z', '0' <= b && b <= '9',
+			b == '-', b == '.', b == '_', b == '~', b == '(', b == ')', b == ',', b == ':':
+			builder.WriteByte(b)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+// normalizeErrorResponse rewrites LinkedIn's own 400/422 validation payload into the gateway
+// error contract, so the executor reports field-level problems the same way in both modes.
+//
+//	{"status":400,"message":"...","errorDetails":{"inputErrors":[{"code":"...","description":"...",
+//	  "input":{"inputPath":{"fieldPath":"..."}}}]}}
+func normalizeErrorResponse(response *api.Response) *api.Response {
+	if response == nil || (response.StatusCode != http.StatusBadRequest && response.StatusCode != http.StatusUnprocessableEntity) {
+		return response
+	}
+	var payload struct {
+		Message      string `json:"message"`
+		ErrorDetails struct {
+			InputErrors []struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+				Input       struct {
+					InputPath struct {
+						FieldPath string `json:"fieldPath"`
+					} `json:"inputPath"`
+				} `json:"input"`
+			} `json:"inputErrors"`
+		} `json:"errorDetails"`
+	}
+	if err := json.Unmarshal(response.Body, &payload); err != nil {
+		return response
+	}
+
+	inputErrors := make([]map[string]string, 0, len(payload.ErrorDetails.InputErrors))
+	for _, inputError := range payload.ErrorDetails.InputErrors {
+		inputErrors = append(inputErrors, map[string]string{
+			"code":        inputError.Code,
+			"description": inputError.Description,
+			"fieldPath":   inputError.Input.InputPath.FieldPath,
+		})
+	}
+	code := "LINKEDIN_PARAM_INVALID"
+	if len(inputErrors) == 0 {
+		// The executor decides from the message whether a generic 400 is correctable.
+		code = "LINKEDIN_API_ERROR"
+	}
+	body, err := json.Marshal(map[string]any{
+		"code":            code,
+		"message":         payload.Message,
+		"providerStatus":  response.StatusCode,
+		"inputErrors":     inputErrors,
+		"providerPayload": json.RawMessage(response.Body),
+	})
+	if err != nil {
+		return response
+	}
+	return &api.Response{StatusCode: response.StatusCode, Headers: response.Headers, Body: body}
+}
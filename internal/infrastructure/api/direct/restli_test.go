@@ -0,0 +1,53 @@
+package direct
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeQuery_EscapesLeavesAndKeepsRestLiStructure(t *testing.T) {
+	encoded := encodeQuery(map[string]string{
+		"q":         "search",
+		"campaigns": "List(urn:li:sponsoredCampaign:1,urn:li:sponsoredCampaign:2)",
+		"search":    "(name:(values:List(Acme Corp & Co)))",
+		"dateRange": "(start:(year:2026,month:3,day:1))",
+	})
+
+	require.Equal(t,
+		"campaigns=List(urn%3Ali%3AsponsoredCampaign%3A1,urn%3Ali%3AsponsoredCampaign%3A2)"+
+			"&dateRange=(start:(year:2026,month:3,day:1))"+
+			"&q=search"+
+			"&search=(name:(values:List(Acme%20Corp%20%26%20Co)))",
+		encoded)
+}
+
+func TestEncodePath_EscapesURNKeys(t *testing.T) {
+	require.Equal(t, "adAccounts/512345678/creatives/urn%3Ali%3AsponsoredCreative%3A123",
+		encodePath("/adAccounts/512345678/creatives/urn:li:sponsoredCreative:123"))
+	require.Equal(t, "adCampaigns/700000001", encodePath("adCampaigns/700000001"))
+}
+
+func TestNormalizeErrorResponse_MapsInputErrorsToGatewayContract(t *testing.T) {
+	response := normalizeErrorResponse(&api.Response{
+		StatusCode: http.StatusBadRequest,
+		Body: []byte(`{"status":400,"message":"Invalid params","errorDetails":{"inputErrors":[` +
+			`{"code":"PARAM_INVALID","description":"wrong type","input":{"inputPath":{"fieldPath":"search/name"}}}]}}`),
+	})
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(response.Body, &payload))
+	require.Equal(t, "LINKEDIN_PARAM_INVALID", payload["code"])
+	require.Equal(t, "Invalid params", payload["message"])
+	require.Equal(t, []any{map[string]any{"code": "PARAM_INVALID", "description": "wrong type", "fieldPath": "search/name"}}, payload["inputErrors"])
+}
+
+func TestNormalizeErrorResponse_LeavesOtherStatusesAlone(t *testing.T) {
+	original := &api.Response{StatusCode: http.StatusForbidden, Body: []byte(`{"message":"Not enough permissions"}`)}
+
+	require.Same(t, original, normalizeErrorResponse(original))
+}
@@ -0,0 +1,149 @@
+package direct
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// KeySize is the length of the secret that encrypts the token store, in bytes (AES-256).
+const KeySize = 32
+
+// tokenStoreAAD binds the ciphertext to this file format.
+var tokenStoreAAD = []byte("linkedin-mcp token store v1")
+
+// Token is one user's LinkedIn OAuth grant.
+type Token struct {
+	AccessToken           string    `json:"accessToken"`
+	ExpiresAt             time.Time `json:"expiresAt"`
+	RefreshToken          string    `json:"refreshToken,omitempty"`
+	RefreshTokenExpiresAt time.Time `json:"refreshTokenExpiresAt,omitempty"`
+	Scope                 string    `json:"scope,omitempty"`
+}
+
+// refreshable reports whether the grant can still be renewed without the user.
+func (t Token) refreshable(now time.Time) bool {
+	if t.RefreshToken == "" {
+		return false
+	}
+	return t.RefreshTokenExpiresAt.IsZero() || now.Before(t.RefreshTokenExpiresAt)
+}
+
+// TokenStore keeps LinkedIn tokens per user in a single file encrypted with AES-256-GCM. The
+// whole map is rewritten on every change, which is fine for the handful of users a direct
+// deployment serves.
+type TokenStore struct {
+	path string
+	aead cipher.AEAD
+
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+// NewTokenStore opens the store at path, creating it on the first write. key must be
+// [KeySize] bytes; a file encrypted with another key cannot be opened.
+func NewTokenStore(path string, key []byte) (*TokenStore, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("token store key must be %d bytes, got %d", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(deriveKey(key, "token-store"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &TokenStore{path: path, aead: aead, tokens: map[string]Token{}}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *TokenStore) Get(userID string) (Token, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[userID]
+	return token, ok
+}
+
+func (s *TokenStore) Put(userID string, token Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[userID] = token
+	return s.saveLocked()
+}
+
+func (s *TokenStore) Delete(userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tokens[userID]; !ok {
+		return nil
+	}
+	delete(s.tokens, userID)
+	return s.saveLocked()
+}
+
+func (s *TokenStore) load() error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return fmt.Errorf("token store %s is corrupt", s.path)
+	}
+	plaintext, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], tokenStoreAAD)
+	if err != nil {
+		return fmt.Errorf("cannot decrypt token store %s; was it written with another key?", s.path)
+	}
+	return json.Unmarshal(plaintext, &s.tokens)
+}
+
+// saveLocked encrypts the tokens with a fresh nonce and replaces the file atomically.
+func (s *TokenStore) saveLocked() error {
+	plaintext, err := json.Marshal(s.tokens)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	data := s.aead.Seal(nonce, nonce, plaintext, tokenStoreAAD)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// deriveKey gives each use of the configured secret its own key.
+func deriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,56 @@
+package direct
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, KeySize)
+}
+
+func TestTokenStore_PersistsEncryptedTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	store, err := NewTokenStore(path, testKey(1))
+	require.NoError(t, err)
+	token := Token{
+		AccessToken:  "AQV-access-secret",
+		ExpiresAt:    time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC),
+		RefreshToken: "AQX-refresh-secret",
+	}
+	require.NoError(t, store.Put("user_1", token))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "AQV-access-secret")
+	require.NotContains(t, string(data), "user_1")
+
+	reopened, err := NewTokenStore(path, testKey(1))
+	require.NoError(t, err)
+	stored, ok := reopened.Get("user_1")
+	require.True(t, ok)
+	require.Equal(t, token.AccessToken, stored.AccessToken)
+	require.True(t, token.ExpiresAt.Equal(stored.ExpiresAt))
+
+	require.NoError(t, reopened.Delete("user_1"))
+	_, ok = reopened.Get("user_1")
+	require.False(t, ok)
+}
+
+func TestTokenStore_RejectsWrongKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.enc")
+	store, err := NewTokenStore(path, testKey(1))
+	require.NoError(t, err)
+	require.NoError(t, store.Put("user_1", Token{AccessToken: "token"}))
+
+	_, err = NewTokenStore(path, testKey(2))
+	require.ErrorContains(t, err, "cannot decrypt")
+
+	_, err = NewTokenStore(path, []byte("short"))
+	require.ErrorContains(t, err, "must be 32 bytes")
+}
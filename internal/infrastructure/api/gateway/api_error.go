@@ -0,0 +1,55 @@
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrMissingUser means the request context carries no authenticated user, so no LinkedIn
+// call can be made on anyone's behalf.
+var ErrMissingUser = errors.New("missing authenticated user in request context")
+
+// LinkedInAPIError is a non-2xx LinkedIn response that is neither a missing connection nor a
+// parameter validation problem. Body is the trimmed provider response body.
+type LinkedInAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *LinkedInAPIError) Error() string {
+	var decoded any
+	if err := json.Unmarshal([]byte(e.Body), &decoded); err == nil {
+		return fmt.Sprintf("linkedin api error: status %d, body: %v", e.StatusCode, decoded)
+	}
+	if e.Body != "" {
+		return fmt.Sprintf("linkedin api error: status %d, body: %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("linkedin api error: status %d", e.StatusCode)
+}
+
+func AsLinkedInAPIError(err error) (*LinkedInAPIError, bool) {
+	var target *LinkedInAPIError
+	if !errors.As(err, &target) {
+		return nil, false
+	}
+	return target, true
+}
+
+// ConnectionStateError means the gateway could not report whether the user has a LinkedIn
+// connection, either because the call failed (Err) or it answered with StatusCode.
+type ConnectionStateError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *ConnectionStateError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("failed to fetch LinkedIn connection state from gateway: %v", e.Err)
+	}
+	return fmt.Sprintf("failed to fetch LinkedIn connection state from gateway: status %d", e.StatusCode)
+}
+
+func (e *ConnectionStateError) Unwrap() error {
+	return e.Err
+}
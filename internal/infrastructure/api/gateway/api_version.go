@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// LinkedIn versions the Marketing APIs monthly. Every proxied call pins the version in the
+// LinkedIn-Version header so the server does not depend on the version Jumon defaults to.
+// Reference: https://learn.microsoft.com/en-us/linkedin/marketing/versioning
+const (
+	HeaderLinkedInVersion = "LinkedIn-Version"
+
+	// DefaultAPIVersion matches the li-lms-2026-03 documentation the analytics resources link to.
+	DefaultAPIVersion = "202603"
+)
+
+type apiVersionKey struct{}
+
+// WithAPIVersionOverride returns a context whose LinkedIn calls use version instead of the
+// configured one, to try an upcoming version before switching the whole server over.
+func WithAPIVersionOverride(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// ResolveAPIVersion returns the override carried by ctx, or fallback when there is none.
+func ResolveAPIVersion(ctx context.Context, fallback string) string {
+	if version, ok := ctx.Value(apiVersionKey{}).(string); ok && version != "" {
+		return version
+	}
+	return fallback
+}
+
+// ValidateAPIVersion reports whether version has LinkedIn's YYYYMM form.
+func ValidateAPIVersion(version string) error {
+	if len(version) != 6 {
+		return fmt.Errorf("invalid LinkedIn API version %q: must be YYYYMM, e.g. %s", version, DefaultAPIVersion)
+	}
+	year, yearErr := strconv.Atoi(version[:4])
+	month, monthErr := strconv.Atoi(version[4:])
+	if yearErr != nil || monthErr != nil || year < 2000 || month < 1 || month > 12 {
+		return fmt.Errorf("invalid LinkedIn API version %q: must be YYYYMM, e.g. %s", version, DefaultAPIVersion)
+	}
+	return nil
+}
+
+// APIVersionDocsView returns the Microsoft Learn view name documenting version, e.g.
+// li-lms-2026-03 for 202603.
+func APIVersionDocsView(version string) string {
+	if len(version) != 6 {
+		return ""
+	}
+	return "li-lms-" + version[:4] + "-" + version[4:]
+}
+
+// withAPIVersion returns a copy of r sending version in the LinkedIn-Version header.
+func (r ProxyRequest) withAPIVersion(version string) ProxyRequest {
+	headers := make(map[string]string, len(r.Headers)+1)
+	for name, value := range r.Headers {
+		headers[name] = value
+	}
+	headers[HeaderLinkedInVersion] = version
+	r.Headers = headers
+	return r
+}
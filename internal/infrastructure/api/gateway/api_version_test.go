@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAPIVersion(t *testing.T) {
+	for _, version := range []string{"202603", "202412"} {
+		require.NoError(t, ValidateAPIVersion(version), version)
+	}
+	for _, version := range []string{"", "2026-03", "20263", "202613", "202600", "2026031", "v20263"} {
+		require.Error(t, ValidateAPIVersion(version), version)
+	}
+}
+
+func TestResolveAPIVersion(t *testing.T) {
+	require.Equal(t, "202603", ResolveAPIVersion(context.Background(), "202603"))
+	require.Equal(t, "202606", ResolveAPIVersion(WithAPIVersionOverride(context.Background(), "202606"), "202603"))
+	require.Equal(t, "202603", ResolveAPIVersion(WithAPIVersionOverride(context.Background(), ""), "202603"))
+}
+
+func TestAPIVersionDocsView(t *testing.T) {
+	require.Equal(t, "li-lms-2026-03", APIVersionDocsView("202603"))
+	require.Equal(t, "", APIVersionDocsView("2026"))
+}
@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// BatchGetResponse is the Rest.li BATCH_GET envelope. Results and Errors are keyed by the
+// entity key exactly as it was sent in ids=List(...); keys LinkedIn could not resolve are
+// absent from Results and usually carry a 404 in Statuses.
+type BatchGetResponse struct {
+	Results  map[string]map[string]any `json:"results"`
+	Statuses map[string]int            `json:"statuses"`
+	Errors   map[string]any            `json:"errors"`
+}
+
+// NewGetRequest builds a Rest.li GET call against a single entity resource.
+func NewGetRequest(resourcePath string) ProxyRequest {
+	return ProxyRequest{
+		Method:       http.MethodGet,
+		ResourcePath: resourcePath,
+		Headers:      map[string]string{HeaderRestLiMethod: RestLiMethodGet},
+	}
+}
+
+// NewBatchGetRequest builds a Rest.li BATCH_GET call (GET on the collection resource with
+// ids=List(...)). Keys must already be in the form LinkedIn expects for the resource.
+func NewBatchGetRequest(resourcePath string, keys []string) ProxyRequest {
+	return ProxyRequest{
+		Method:       http.MethodGet,
+		ResourcePath: resourcePath,
+		Query:        map[string]string{"ids": "List(" + strings.Join(keys, ",") + ")"},
+		Headers:      map[string]string{HeaderRestLiMethod: RestLiMethodBatchGet},
+	}
+}
+
+// DecodeBatchGetResponse decodes a BATCH_GET response body.
+func DecodeBatchGetResponse(body []byte) (*BatchGetResponse, error) {
+	var decoded BatchGetResponse
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode batch get response: %w", err)
+	}
+	return &decoded, nil
+}
@@ -0,0 +1,32 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBatchGetRequest(t *testing.T) {
+	request := NewBatchGetRequest("adAccounts/1/adCampaigns", []string{"10", "20"})
+
+	require.Equal(t, "GET", request.method())
+	require.Equal(t, map[string]string{"ids": "List(10,20)"}, request.Query)
+	require.Equal(t, RestLiMethodBatchGet, request.Headers[HeaderRestLiMethod])
+	require.Nil(t, request.Body)
+}
+
+func TestDecodeBatchGetResponse(t *testing.T) {
+	decoded, err := DecodeBatchGetResponse([]byte(`{
+		"results": {"10": {"id": 10, "name": "Brand"}},
+		"statuses": {"10": 200, "20": 404},
+		"errors": {"20": {"status": 404}}
+	}`))
+
+	require.NoError(t, err)
+	require.Equal(t, "Brand", decoded.Results["10"]["name"])
+	require.NotContains(t, decoded.Results, "20")
+	require.Equal(t, 404, decoded.Statuses["20"])
+
+	_, err = DecodeBatchGetResponse([]byte("not json"))
+	require.Error(t, err)
+}
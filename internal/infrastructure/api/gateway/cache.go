@@ -0,0 +1,158 @@
+package gateway
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/cache"
+)
+
+const (
+	analyticsResourcePath = "adAnalytics"
+
+	// analyticsSettleDays is how long LinkedIn keeps restating analytics after a day ends
+	// (late conversions, fraud filtering). Ranges ending within this window count as recent.
+	analyticsSettleDays = 3
+)
+
+var analyticsRangeEndPattern = regexp.MustCompile(`end:\(year:(\d+),month:(\d+),day:(\d+)\)`)
+
+// CacheTTLs sets how long successful GET responses stay cached, per kind of resource.
+// A zero TTL disables caching for that kind.
+type CacheTTLs struct {
+	// RecentAnalytics applies to adAnalytics ranges that are open-ended or end within the
+	// last few days, whose numbers still change.
+	RecentAnalytics time.Duration
+	// HistoricalAnalytics applies to adAnalytics ranges that ended before the settle window.
+	HistoricalAnalytics time.Duration
+	// Metadata applies to every other read: accounts, campaign groups, campaigns, creatives
+	// and lookups.
+	Metadata time.Duration
+}
+
+// Option configures optional behaviour of a [Client].
+type Option func(*Client)
+
+// WithResponseCache caches successful GET proxy responses in backend. Entries are scoped to
+// the user and keyed by resource path, query and headers. Any successful write proxied for a
+// user purges that user's entries, so reads after an update never see stale entities.
+func WithResponseCache(backend cache.Backend, ttls CacheTTLs) Option {
+	return func(c *Client) {
+		c.cache = backend
+		c.cacheTTLs = ttls
+	}
+}
+
+type cacheBypassKey struct{}
+
+// WithCacheBypass returns a context whose proxy reads skip the response cache. Fresh
+// responses are still stored, so a bypassing call also refreshes the cache.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypass
+}
+
+func (r ProxyRequest) cacheable() bool {
+	return r.method() == http.MethodGet && r.Body == nil
+}
+
+// cacheKey canonicalizes the request so that equivalent calls share an entry regardless of
+// map iteration order or header name casing.
+func (r ProxyRequest) cacheKey() string {
+	var builder strings.Builder
+	builder.WriteString(r.method())
+	builder.WriteByte('\n')
+	builder.WriteString(strings.Trim(r.ResourcePath, "/"))
+	builder.WriteByte('\n')
+	writeSortedPairs(&builder, r.Query, false)
+	builder.WriteByte('\n')
+	writeSortedPairs(&builder, r.Headers, true)
+
+	sum := sha256.Sum256([]byte(builder.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeSortedPairs(builder *strings.Builder, values map[string]string, foldKeys bool) {
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		if foldKeys {
+			key = strings.ToLower(key)
+		}
+		pairs = append(pairs, strconv.Quote(key)+"="+strconv.Quote(value))
+	}
+	sort.Strings(pairs)
+	builder.WriteString(strings.Join(pairs, "&"))
+}
+
+// forRequest picks the TTL for a request. Analytics of closed historical ranges never change,
+// so they can be kept much longer than ranges that include today.
+func (ttls CacheTTLs) forRequest(request ProxyRequest, now time.Time) time.Duration {
+	if strings.Trim(request.ResourcePath, "/") != analyticsResourcePath {
+		return ttls.Metadata
+	}
+	match := analyticsRangeEndPattern.FindStringSubmatch(request.Query["dateRange"])
+	if match == nil {
+		return ttls.RecentAnalytics
+	}
+	year, _ := strconv.Atoi(match[1])
+	month, _ := strconv.Atoi(match[2])
+	day, _ := strconv.Atoi(match[3])
+	end := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	settled := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -analyticsSettleDays)
+	if end.Before(settled) {
+		return ttls.HistoricalAnalytics
+	}
+	return ttls.RecentAnalytics
+}
+
+func (c *Client) cachedResponse(ctx context.Context, userID string, request ProxyRequest) (*api.Response, bool) {
+	if c.cache == nil || !request.cacheable() || cacheBypassed(ctx) {
+		return nil, false
+	}
+	data, ok := c.cache.Get(userID, request.cacheKey())
+	if !ok {
+		return nil, false
+	}
+	var response api.Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, false
+	}
+	return &response, true
+}
+
+// storeResponse caches successful reads and purges the user's entries after a successful
+// write. Error responses are never cached.
+func (c *Client) storeResponse(userID string, request ProxyRequest, response *api.Response) {
+	if c.cache == nil || response == nil || response.StatusCode < 200 || response.StatusCode >= 300 {
+		return
+	}
+	if !request.cacheable() {
+		c.cache.Purge(userID)
+		return
+	}
+	if response.StatusCode != http.StatusOK {
+		return
+	}
+	ttl := c.cacheTTLs.forRequest(request, c.now())
+	if ttl <= 0 {
+		return
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return
+	}
+	c.cache.Set(userID, request.cacheKey(), data, ttl)
+}
@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/cache"
+	customhttp "linkedin-mcp/internal/infrastructure/http"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newCachingTestClient(t *testing.T, status int) (*Client, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		var body map[string]any
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		if body["method"] != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"elements":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	ttls := CacheTTLs{RecentAnalytics: time.Minute, HistoricalAnalytics: time.Hour, Metadata: time.Hour}
+	client := NewClient(customhttp.NewClient(nil), server.URL, "secret", WithResponseCache(cache.NewMemory(1<<20), ttls))
+	return client, &calls
+}
+
+func TestProxyLinkedIn_ServesRepeatedReadsFromCache(t *testing.T) {
+	client, calls := newCachingTestClient(t, http.StatusOK)
+	ctx := context.Background()
+
+	first, err := client.ProxyLinkedIn(ctx, "user_1", "adCampaigns", map[string]string{"q": "search", "pageSize": "10"}, map[string]string{"X-RestLi-Method": "FINDER"})
+	require.NoError(t, err)
+	second, err := client.ProxyLinkedIn(ctx, "user_1", "/adCampaigns", map[string]string{"pageSize": "10", "q": "search"}, map[string]string{"x-restli-method": "FINDER"})
+	require.NoError(t, err)
+
+	require.Equal(t, int32(1), calls.Load())
+	require.Equal(t, first.StatusCode, second.StatusCode)
+	require.Equal(t, first.Body, second.Body)
+
+	_, err = client.ProxyLinkedIn(ctx, "user_2", "adCampaigns", map[string]string{"q": "search", "pageSize": "10"}, map[string]string{"X-RestLi-Method": "FINDER"})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load(), "entries are scoped to the user")
+
+	_, err = client.ProxyLinkedIn(ctx, "user_1", "adCampaigns", map[string]string{"q": "search", "pageSize": "20"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), calls.Load(), "a different query is a different entry")
+}
+
+func TestProxyLinkedIn_BypassRefreshesCache(t *testing.T) {
+	client, calls := newCachingTestClient(t, http.StatusOK)
+	ctx := context.Background()
+
+	_, err := client.ProxyLinkedIn(ctx, "user_1", "adAccounts", nil, nil)
+	require.NoError(t, err)
+	_, err = client.ProxyLinkedIn(WithCacheBypass(ctx), "user_1", "adAccounts", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+
+	_, err = client.ProxyLinkedIn(ctx, "user_1", "adAccounts", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestProxyLinkedIn_DoesNotCacheErrors(t *testing.T) {
+	client, calls := newCachingTestClient(t, http.StatusBadRequest)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := client.ProxyLinkedIn(ctx, "user_1", "adAccounts", nil, nil)
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestProxyLinkedInRequest_WritePurgesUserEntries(t *testing.T) {
+	client, calls := newCachingTestClient(t, http.StatusOK)
+	ctx := context.Background()
+
+	_, err := client.ProxyLinkedIn(ctx, "user_1", "adAccounts/1/adCampaigns/2", nil, nil)
+	require.NoError(t, err)
+	_, err = client.ProxyLinkedInRequest(ctx, "user_1", NewPartialUpdateRequest("adAccounts/1/adCampaigns/2", map[string]any{"status": "PAUSED"}, nil))
+	require.NoError(t, err)
+	_, err = client.ProxyLinkedIn(ctx, "user_1", "adAccounts/1/adCampaigns/2", nil, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestCacheTTLs_ForRequest(t *testing.T) {
+	ttls := CacheTTLs{RecentAnalytics: time.Minute, HistoricalAnalytics: 24 * time.Hour, Metadata: time.Hour}
+	now := time.Date(2026, time.March, 10, 15, 0, 0, 0, time.UTC)
+	analytics := func(dateRange string) ProxyRequest {
+		return ProxyRequest{ResourcePath: "adAnalytics", Query: map[string]string{"q": "analytics", "dateRange": dateRange}}
+	}
+
+	require.Equal(t, time.Minute, ttls.forRequest(analytics("(start:(year:2026,month:3,day:1))"), now))
+	require.Equal(t, time.Minute, ttls.forRequest(analytics("(start:(year:2026,month:3,day:1),end:(year:2026,month:3,day:10))"), now))
+	require.Equal(t, time.Minute, ttls.forRequest(analytics("(start:(year:2026,month:3,day:1),end:(year:2026,month:3,day:7))"), now))
+	require.Equal(t, 24*time.Hour, ttls.forRequest(analytics("(start:(year:2026,month:2,day:1),end:(year:2026,month:2,day:28))"), now))
+	require.Equal(t, time.Hour, ttls.forRequest(NewGetRequest("adAccounts/1"), now))
+}
@@ -0,0 +1,219 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// Gateway endpoints with independent circuit breakers: a failing proxy must not stop
+// connection checks or token refreshes, and the other way round.
+const (
+	EndpointConnections = "connections"
+	EndpointProxy       = "proxy"
+	EndpointRefresh     = "refresh"
+)
+
+// Circuit breaker states reported by [Client.BreakerStates].
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+)
+
+// BreakerSettings configures [WithCircuitBreaker].
+type BreakerSettings struct {
+	// FailureThreshold is the number of consecutive 5xx responses or transport failures
+	// (timeouts, refused connections) that opens an endpoint's breaker. 5xx errors LinkedIn
+	// returned through the proxy do not count: the proxy breaker is shared by all users.
+	FailureThreshold int
+	// OpenDuration is how long an open breaker fails calls fast before letting one probe
+	// call through (half-open). A successful probe closes it, a failed one reopens it.
+	OpenDuration time.Duration
+}
+
+// WithCircuitBreaker fails gateway calls fast while an endpoint keeps failing, instead of
+// letting every tool call wait through the HTTP client's retries and timeouts.
+func WithCircuitBreaker(settings BreakerSettings) Option {
+	return func(c *Client) {
+		c.breakers = map[string]*circuitBreaker{}
+		for _, endpoint := range []string{EndpointConnections, EndpointProxy, EndpointRefresh} {
+			c.breakers[endpoint] = &circuitBreaker{settings: settings, state: BreakerClosed}
+		}
+	}
+}
+
+// GatewayUnavailableError means a call was not attempted because the endpoint's breaker is
+// open. RetryAfter is when the next probe call will be allowed.
+type GatewayUnavailableError struct {
+	Endpoint   string
+	RetryAfter time.Duration
+}
+
+func (e *GatewayUnavailableError) Error() string {
+	return fmt.Sprintf("LinkedIn gateway temporarily unavailable (%s endpoint failing), retry after %d seconds", e.Endpoint, e.RetryAfterSeconds())
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds, and at least one.
+func (e *GatewayUnavailableError) RetryAfterSeconds() int {
+	return max(1, int(math.Ceil(e.RetryAfter.Seconds())))
+}
+
+func AsGatewayUnavailable(err error) (*GatewayUnavailableError, bool) {
+	var target *GatewayUnavailableError
+	if !errors.As(err, &target) {
+		return nil, false
+	}
+	return target, true
+}
+
+// BreakerState is a snapshot of one endpoint's breaker.
+type BreakerState struct {
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+}
+
+// BreakerStates reports the breaker of every endpoint, or nil when no breaker is configured.
+func (c *Client) BreakerStates() map[string]BreakerState {
+	if c.breakers == nil {
+		return nil
+	}
+	now := c.now()
+	states := make(map[string]BreakerState, len(c.breakers))
+	for endpoint, breaker := range c.breakers {
+		states[endpoint] = breaker.snapshot(now)
+	}
+	return states
+}
+
+// guard runs call unless endpoint's breaker is open and records its outcome.
+func (c *Client) guard(ctx context.Context, endpoint string, call func() (*api.Response, error)) (*api.Response, error) {
+	breaker, ok := c.breakers[endpoint]
+	if !ok {
+		return call()
+	}
+	if err := breaker.allow(endpoint, c.now()); err != nil {
+		return nil, err
+	}
+	response, err := call()
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up; that says nothing about the gateway's health.
+		breaker.release()
+		return response, err
+	}
+	now := c.now()
+	breaker.record(failed(endpoint, response, err, now), now)
+	return response, err
+}
+
+// failed reports whether a call counts against the gateway's health. A proxied call that
+// reached LinkedIn says nothing about Jumon: one user's failing LinkedIn resource must not
+// open the breaker every other user's calls go through.
+func failed(endpoint string, response *api.Response, err error, now time.Time) bool {
+	if err != nil {
+		return true
+	}
+	if _, throttled := rateLimitFromResponse(RateLimitSourceGateway, response, now); throttled {
+		return false
+	}
+	if response.StatusCode < http.StatusInternalServerError {
+		return false
+	}
+	return endpoint != EndpointProxy || !relayedFromLinkedIn(response.Body)
+}
+
+// relayedFromLinkedIn recognizes an error body that came from LinkedIn rather than from Jumon
+// itself: Jumon's relay envelope carries providerStatus, and LinkedIn's own error payload
+// carries status or serviceErrorCode. Jumon's failures and those of proxies in front of it
+// have neither.
+func relayedFromLinkedIn(body []byte) bool {
+	var payload struct {
+		ProviderStatus   *int `json:"providerStatus"`
+		Status           *int `json:"status"`
+		ServiceErrorCode *int `json:"serviceErrorCode"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return false
+	}
+	return payload.ProviderStatus != nil || payload.Status != nil || payload.ServiceErrorCode != nil
+}
+
+type circuitBreaker struct {
+	settings BreakerSettings
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (b *circuitBreaker) allow(endpoint string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		reopensAt := b.openedAt.Add(b.settings.OpenDuration)
+		if now.Before(reopensAt) {
+			return &GatewayUnavailableError{Endpoint: endpoint, RetryAfter: reopensAt.Sub(now)}
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return &GatewayUnavailableError{Endpoint: endpoint, RetryAfter: time.Second}
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *circuitBreaker) record(failure bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failure {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.settings.FailureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = now
+	}
+}
+
+// release frees a half-open probe slot without counting the call either way.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) snapshot(now time.Time) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := BreakerState{State: b.state, ConsecutiveFailures: b.failures}
+	if b.state == BreakerOpen && !now.Before(b.openedAt.Add(b.settings.OpenDuration)) {
+		snapshot.State = BreakerHalfOpen
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		snapshot.OpenedAt = &openedAt
+	}
+	return snapshot
+}
@@ -0,0 +1,148 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	customhttp "linkedin-mcp/internal/infrastructure/http"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newBreakerClient(t *testing.T, status *atomic.Int32, calls *atomic.Int32) (*Client, *time.Time) {
+	t.Helper()
+	return newBreakerClientWithBody(t, status, calls, "")
+}
+
+func newBreakerClientWithBody(t *testing.T, status *atomic.Int32, calls *atomic.Int32, body string) (*Client, *time.Time) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	config := customhttp.DefaultConfig()
+	config.MaxRetries = 0
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := NewClient(customhttp.NewClient(config), server.URL, "secret", WithCircuitBreaker(BreakerSettings{
+		FailureThreshold: 2,
+		OpenDuration:     30 * time.Second,
+	}))
+	client.now = func() time.Time { return now }
+	return client, &now
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailuresAndFailsFast(t *testing.T) {
+	var status, calls atomic.Int32
+	status.Store(http.StatusBadGateway)
+	client, now := newBreakerClient(t, &status, &calls)
+	ctx := context.Background()
+
+	for range 2 {
+		response, err := client.ProxyLinkedInRequest(ctx, "user_1", NewGetRequest("adAccounts/1"))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadGateway, response.StatusCode)
+	}
+	_, err := client.ProxyLinkedInRequest(ctx, "user_1", NewGetRequest("adAccounts/1"))
+	unavailable, ok := AsGatewayUnavailable(err)
+	require.True(t, ok)
+	require.Equal(t, EndpointProxy, unavailable.Endpoint)
+	require.Equal(t, 30, unavailable.RetryAfterSeconds())
+	require.Equal(t, int32(2), calls.Load(), "an open breaker does not call the gateway")
+
+	_, err = client.GetLinkedInConnection(ctx, "user_1")
+	require.NoError(t, err, "other endpoints keep their own state")
+	require.Equal(t, BreakerOpen, client.BreakerStates()[EndpointProxy].State)
+	require.Equal(t, BreakerClosed, client.BreakerStates()[EndpointConnections].State)
+
+	*now = now.Add(10 * time.Second)
+	_, err = client.ProxyLinkedInRequest(ctx, "user_1", NewGetRequest("adAccounts/1"))
+	unavailable, _ = AsGatewayUnavailable(err)
+	require.Equal(t, 20, unavailable.RetryAfterSeconds())
+}
+
+func TestCircuitBreaker_HalfOpenProbeClosesOrReopens(t *testing.T) {
+	var status, calls atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	client, now := newBreakerClient(t, &status, &calls)
+	ctx := context.Background()
+	for range 2 {
+		_, _ = client.RefreshLinkedIn(ctx, "user_1")
+	}
+
+	*now = now.Add(31 * time.Second)
+	require.Equal(t, BreakerHalfOpen, client.BreakerStates()[EndpointRefresh].State)
+	_, err := client.RefreshLinkedIn(ctx, "user_1")
+	require.NoError(t, err, "the probe reaches the gateway")
+	require.Equal(t, BreakerOpen, client.BreakerStates()[EndpointRefresh].State, "a failed probe reopens the breaker")
+	_, err = client.RefreshLinkedIn(ctx, "user_1")
+	require.Error(t, err)
+
+	*now = now.Add(31 * time.Second)
+	status.Store(http.StatusOK)
+	_, err = client.RefreshLinkedIn(ctx, "user_1")
+	require.NoError(t, err)
+	state := client.BreakerStates()[EndpointRefresh]
+	require.Equal(t, BreakerClosed, state.State)
+	require.Zero(t, state.ConsecutiveFailures)
+	require.Equal(t, int32(4), calls.Load())
+}
+
+func TestCircuitBreaker_IgnoresClientErrorsAndCancelledCalls(t *testing.T) {
+	var status, calls atomic.Int32
+	client, _ := newBreakerClient(t, &status, &calls)
+	ctx := context.Background()
+
+	status.Store(http.StatusBadRequest)
+	for range 3 {
+		_, err := client.GetLinkedInConnection(ctx, "user_1")
+		require.NoError(t, err)
+	}
+	require.Equal(t, BreakerClosed, client.BreakerStates()[EndpointConnections].State)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	for range 3 {
+		_, err := client.GetLinkedInConnection(cancelled, "user_1")
+		require.Error(t, err)
+	}
+	require.Equal(t, BreakerClosed, client.BreakerStates()[EndpointConnections].State, "cancelled calls are not failures")
+}
+
+func TestCircuitBreaker_IgnoresLinkedInServerErrorsRelayedByTheProxy(t *testing.T) {
+	for name, body := range map[string]string{
+		"linkedin payload": `{"status":500,"serviceErrorCode":0,"message":"Internal Server Error"}`,
+		"relay envelope":   `{"code":"LINKEDIN_API_ERROR","message":"Service unavailable","providerStatus":503}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var status, calls atomic.Int32
+			status.Store(http.StatusInternalServerError)
+			client, _ := newBreakerClientWithBody(t, &status, &calls, body)
+			ctx := context.Background()
+
+			for range 3 {
+				response, err := client.ProxyLinkedInRequest(ctx, "user_1", NewGetRequest("adAnalytics"))
+				require.NoError(t, err)
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			}
+			require.Equal(t, int32(3), calls.Load())
+			require.Equal(t, BreakerClosed, client.BreakerStates()[EndpointProxy].State)
+			require.Zero(t, client.BreakerStates()[EndpointProxy].ConsecutiveFailures)
+
+			for range 2 {
+				_, _ = client.GetLinkedInConnection(ctx, "user_1")
+			}
+			require.Equal(t, BreakerOpen, client.BreakerStates()[EndpointConnections].State, "only proxied LinkedIn errors are exempt")
+		})
+	}
+}
+
+func TestBreakerStates_NilWithoutBreaker(t *testing.T) {
+	require.Nil(t, NewClient(customhttp.NewClient(nil), "http://gateway", "secret").BreakerStates())
+}
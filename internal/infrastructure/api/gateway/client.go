@@ -3,10 +3,14 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/cache"
+	customhttp "linkedin-mcp/internal/infrastructure/http"
 )
 
 const (
@@ -17,33 +21,76 @@ type Client struct {
 	httpClient     api.Client
 	baseURL        string
 	internalSecret string
+	cache          cache.Backend
+	cacheTTLs      CacheTTLs
+	breakers       map[string]*circuitBreaker
+	now            func() time.Time
 }
 
-func NewClient(httpClient api.Client, baseURL, internalSecret string) *Client {
-	return &Client{
+func NewClient(httpClient api.Client, baseURL, internalSecret string, options ...Option) *Client {
+	client := &Client{
 		httpClient:     httpClient,
 		baseURL:        strings.TrimRight(baseURL, "/"),
 		internalSecret: internalSecret,
+		now:            time.Now,
 	}
+	for _, option := range options {
+		option(client)
+	}
+	return client
 }
 
 func (c *Client) GetLinkedInConnection(ctx context.Context, userID string) (*api.Response, error) {
 	path := fmt.Sprintf("%s/api/internal/connections/linkedin/current?userId=%s", c.baseURL, url.QueryEscape(userID))
-	return c.httpClient.Get(ctx, path, c.authHeaders())
+	return c.guard(ctx, EndpointConnections, func() (*api.Response, error) {
+		return c.httpClient.Get(ctx, path, c.authHeaders())
+	})
 }
 
 func (c *Client) ProxyLinkedIn(ctx context.Context, userID, resourcePath string, query map[string]string, headers map[string]string) (*api.Response, error) {
+	return c.ProxyLinkedInRequest(ctx, userID, ProxyRequest{
+		Method:       http.MethodGet,
+		ResourcePath: resourcePath,
+		Query:        query,
+		Headers:      headers,
+	})
+}
+
+// ProxyLinkedInRequest proxies an arbitrary LinkedIn REST call (GET, POST, PATCH, DELETE)
+// through Jumon. The request body, when present, is forwarded verbatim under "body".
+// GET requests are served from the response cache when one is configured.
+func (c *Client) ProxyLinkedInRequest(ctx context.Context, userID string, request ProxyRequest) (*api.Response, error) {
+	if response, ok := c.cachedResponse(ctx, userID, request); ok {
+		return response, nil
+	}
+	response, err := c.proxy(ctx, userID, request)
+	if err != nil {
+		return nil, err
+	}
+	c.storeResponse(userID, request, response)
+	return response, nil
+}
+
+func (c *Client) proxy(ctx context.Context, userID string, request ProxyRequest) (*api.Response, error) {
 	path := fmt.Sprintf("%s/api/internal/providers/linkedin/proxy", c.baseURL)
 	body := map[string]interface{}{
 		"userId": userID,
-		"method": "GET",
-		"path":   strings.TrimLeft(resourcePath, "/"),
-		"query":  query,
+		"method": request.method(),
+		"path":   strings.TrimLeft(request.ResourcePath, "/"),
+		"query":  request.Query,
+	}
+	if len(request.Headers) > 0 {
+		body["headers"] = request.Headers
 	}
-	if len(headers) > 0 {
-		body["headers"] = headers
+	if request.Body != nil {
+		body["body"] = request.Body
 	}
-	return c.httpClient.Post(ctx, path, body, c.authHeaders())
+	if request.idempotent() {
+		ctx = customhttp.WithRetryPolicy(ctx, customhttp.RetryIdempotent)
+	}
+	return c.guard(ctx, EndpointProxy, func() (*api.Response, error) {
+		return c.httpClient.Post(ctx, path, body, c.authHeaders())
+	})
 }
 
 func (c *Client) RefreshLinkedIn(ctx context.Context, userID string) (*api.Response, error) {
@@ -51,14 +98,28 @@ func (c *Client) RefreshLinkedIn(ctx context.Context, userID string) (*api.Respo
 	body := map[string]string{
 		"userId": userID,
 	}
-	return c.httpClient.Post(ctx, path, body, c.authHeaders())
+	return c.guard(ctx, EndpointRefresh, func() (*api.Response, error) {
+		return c.httpClient.Post(ctx, path, body, c.authHeaders())
+	})
 }
 
 // ProxyLinkedInOrRefresh proxies the REST call through Jumon and retries once after a token
 // refresh if the gateway responds with 401. Call [Client.GetLinkedInConnection] first if you
 // need to verify the user has a LinkedIn connection before proxying.
 func (c *Client) ProxyLinkedInOrRefresh(ctx context.Context, userID, resourcePath string, query map[string]string, headers map[string]string) (*api.Response, error) {
-	resp, err := c.ProxyLinkedIn(ctx, userID, resourcePath, query, headers)
+	return c.ProxyLinkedInRequestOrRefresh(ctx, userID, ProxyRequest{
+		Method:       http.MethodGet,
+		ResourcePath: resourcePath,
+		Query:        query,
+		Headers:      headers,
+	})
+}
+
+// ProxyLinkedInRequestOrRefresh is the method-aware variant of [Client.ProxyLinkedInOrRefresh].
+// A 401 means LinkedIn rejected the token before executing anything, so replaying a write
+// after the refresh cannot apply it twice.
+func (c *Client) ProxyLinkedInRequestOrRefresh(ctx context.Context, userID string, request ProxyRequest) (*api.Response, error) {
+	resp, err := c.ProxyLinkedInRequest(ctx, userID, request)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +129,7 @@ func (c *Client) ProxyLinkedInOrRefresh(ctx context.Context, userID, resourcePat
 	if _, err := c.RefreshLinkedIn(ctx, userID); err != nil {
 		return resp, nil
 	}
-	return c.ProxyLinkedIn(ctx, userID, resourcePath, query, headers)
+	return c.ProxyLinkedInRequest(ctx, userID, request)
 }
 
 func (c *Client) authHeaders() map[string]string {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	customhttp "linkedin-mcp/internal/infrastructure/http"
 
@@ -61,3 +62,123 @@ func TestProxyLinkedIn_IncludesHeadersInBodyWhenNonEmpty(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "FINDER", raw["X-RestLi-Method"])
 }
+
+func TestProxyLinkedIn_DefaultsToGETWithoutBody(t *testing.T) {
+	var gotBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(customhttp.NewClient(nil), server.URL, "super-secret")
+	_, err := client.ProxyLinkedIn(context.Background(), "user_123", "adAccounts", nil, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, "GET", gotBody["method"])
+	_, hasBody := gotBody["body"]
+	require.False(t, hasBody)
+}
+
+func TestProxyLinkedInRequest_ForwardsPartialUpdate(t *testing.T) {
+	var gotBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(customhttp.NewClient(nil), server.URL, "super-secret")
+	request := NewPartialUpdateRequest("/adAccounts/1/adCampaigns/2", map[string]any{"status": "PAUSED"}, nil)
+	resp, err := client.ProxyLinkedInRequest(context.Background(), "user_123", request)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.Equal(t, "POST", gotBody["method"])
+	require.Equal(t, "adAccounts/1/adCampaigns/2", gotBody["path"])
+	headers, ok := gotBody["headers"].(map[string]interface{})
+	require.True(t, ok)
+	require.Equal(t, "PARTIAL_UPDATE", headers["X-RestLi-Method"])
+	require.Equal(t, map[string]interface{}{
+		"patch": map[string]interface{}{
+			"$set": map[string]interface{}{"status": "PAUSED"},
+		},
+	}, gotBody["body"])
+}
+
+func TestProxyLinkedInRequestOrRefresh_RetriesWriteAfterRefresh(t *testing.T) {
+	var proxyCalls, refreshCalls int
+	var lastMethod string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/internal/providers/linkedin/refresh":
+			refreshCalls++
+			w.WriteHeader(http.StatusOK)
+		case "/api/internal/providers/linkedin/proxy":
+			proxyCalls++
+			var body map[string]interface{}
+			raw, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(raw, &body)
+			lastMethod, _ = body["method"].(string)
+			if proxyCalls == 1 {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-RestLi-Id", "987")
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(customhttp.NewClient(nil), server.URL, "super-secret")
+	resp, err := client.ProxyLinkedInRequestOrRefresh(context.Background(), "user_123", ProxyRequest{
+		Method:       http.MethodDelete,
+		ResourcePath: "adAccounts/1/adCampaigns/2",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, 2, proxyCalls)
+	require.Equal(t, 1, refreshCalls)
+	require.Equal(t, "DELETE", lastMethod)
+	require.Equal(t, "987", CreatedEntityID(resp))
+}
+
+func TestProxyLinkedInRequest_RetriesByUnderlyingMethod(t *testing.T) {
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &body)
+		method, _ := body["method"].(string)
+		attempts[method]++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := customhttp.DefaultConfig()
+	config.MaxRetries = 2
+	config.RetryDelay = time.Millisecond
+	config.MaxRetryDelay = time.Millisecond
+	client := NewClient(customhttp.NewClient(config), server.URL, "super-secret")
+
+	_, err := client.ProxyLinkedInRequest(context.Background(), "user_123", ProxyRequest{Method: http.MethodGet, ResourcePath: "adCampaigns/1"})
+	require.NoError(t, err)
+	_, err = client.ProxyLinkedInRequest(context.Background(), "user_123", ProxyRequest{Method: http.MethodPost, ResourcePath: "adCampaigns/1", Body: map[string]any{}})
+	require.NoError(t, err)
+	_, err = client.ProxyLinkedInRequest(context.Background(), "user_123", ProxyRequest{
+		Method:       http.MethodPost,
+		ResourcePath: "adCampaigns",
+		Headers:      map[string]string{"Idempotency-Key": "create-1"},
+		Body:         map[string]any{},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 3, attempts[http.MethodGet])
+	require.Equal(t, 2, attempts[http.MethodPost], "writes are sent once, even with an Idempotency-Key for LinkedIn")
+}
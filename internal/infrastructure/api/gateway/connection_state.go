@@ -21,12 +21,19 @@ func IsLinkedInNotConnectedResponse(response *api.Response) bool {
 	if response.StatusCode == 404 {
 		return true
 	}
-	if len(response.Body) == 0 {
+	return IsLinkedInNotConnectedBody(response.Body)
+}
+
+// IsLinkedInNotConnectedBody reports whether body explicitly says the user has no LinkedIn
+// connection, e.g. {"connected":false} or {"code":"LINKEDIN_NOT_CONNECTED"}, whatever the
+// status code.
+func IsLinkedInNotConnectedBody(body []byte) bool {
+	if len(body) == 0 {
 		return false
 	}
 
 	var payload map[string]interface{}
-	if err := json.Unmarshal(response.Body, &payload); err != nil {
+	if err := json.Unmarshal(body, &payload); err != nil {
 		return false
 	}
 
@@ -0,0 +1,341 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/middleware"
+)
+
+const (
+	logMessageFailedRequest        = "failed to make request"
+	logMessageLinkedInAPIError     = "linkedin api responded with error"
+	logMessageFailedDecodeResponse = "failed to decode response"
+	logMessageRateLimited          = "linkedin api rate limited the request"
+
+	logTagURL    = "url"
+	logTagError  = "error"
+	logTagStatus = "status"
+	logTagBody   = "body"
+
+	// defaultConnectionStateTTL is how long a confirmed LinkedIn connection is trusted before
+	// the gateway is asked again.
+	defaultConnectionStateTTL = time.Minute
+)
+
+type Logger interface {
+	Error(ctx context.Context, message string, tags map[string]string)
+}
+
+// Executor runs LinkedIn REST calls for the authenticated user. It owns the pipeline every
+// repository needs: resolve the user from the context, verify the LinkedIn connection with
+// the provider (cached per user), pin the LinkedIn API version, execute the call with a token
+// refresh on 401, map failures to typed errors and decode the JSON response.
+type Executor struct {
+	client      Provider
+	logger      Logger
+	connections *connectionStates
+	apiVersion  string
+	now         func() time.Time
+}
+
+// ExecutorOption configures optional behaviour of an [Executor].
+type ExecutorOption func(*Executor)
+
+// WithConnectionStateTTL sets how long a confirmed connection is cached per user. A zero TTL
+// checks the connection before every call.
+func WithConnectionStateTTL(ttl time.Duration) ExecutorOption {
+	return func(e *Executor) {
+		e.connections.ttl = ttl
+	}
+}
+
+// WithAPIVersion sets the LinkedIn-Version sent on every call without an override in its
+// context (see [WithAPIVersionOverride]). It defaults to [DefaultAPIVersion].
+func WithAPIVersion(version string) ExecutorOption {
+	return func(e *Executor) {
+		if version != "" {
+			e.apiVersion = version
+		}
+	}
+}
+
+func NewExecutor(client Provider, logger Logger, options ...ExecutorOption) *Executor {
+	executor := &Executor{
+		client:      client,
+		logger:      logger,
+		connections: newConnectionStates(defaultConnectionStateTTL),
+		apiVersion:  DefaultAPIVersion,
+		now:         time.Now,
+	}
+	for _, option := range options {
+		option(executor)
+	}
+	return executor
+}
+
+// Do executes request and returns the 2xx response. requestURL is the full LinkedIn URL the
+// request was built from; it only labels logs.
+func (e *Executor) Do(ctx context.Context, requestURL string, request ProxyRequest) (*api.Response, error) {
+	return e.execute(ctx, requestURL, request, false)
+}
+
+// GetJSON issues a GET (typically a Rest.li finder) for requestURL and decodes the response
+// body into target.
+func (e *Executor) GetJSON(ctx context.Context, requestURL string, headers map[string]string, target any) error {
+	resourcePath, query, err := ParseLinkedInRESTProxyTarget(requestURL)
+	if err != nil {
+		return fmt.Errorf("failed to build gateway proxy target: %w", err)
+	}
+	response, err := e.Do(ctx, requestURL, ProxyRequest{
+		Method:       http.MethodGet,
+		ResourcePath: resourcePath,
+		Query:        query,
+		Headers:      headers,
+	})
+	if err != nil {
+		return err
+	}
+	return e.decode(ctx, requestURL, response, target)
+}
+
+// GetEntity issues a Rest.li GET for the entity at requestURL and returns the decoded entity,
+// or nil when LinkedIn answers 404.
+func (e *Executor) GetEntity(ctx context.Context, requestURL string) (map[string]any, error) {
+	resourcePath, _, err := ParseLinkedInRESTProxyTarget(requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build gateway proxy target: %w", err)
+	}
+	response, err := e.execute(ctx, requestURL, NewGetRequest(resourcePath), true)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	var entity map[string]any
+	if err := e.decode(ctx, requestURL, response, &entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+// BatchGet issues a Rest.li BATCH_GET for keys against the collection at requestURL.
+func (e *Executor) BatchGet(ctx context.Context, requestURL string, keys []string) (*BatchGetResponse, error) {
+	resourcePath, _, err := ParseLinkedInRESTProxyTarget(requestURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build gateway proxy target: %w", err)
+	}
+	response, err := e.Do(ctx, requestURL, NewBatchGetRequest(resourcePath, keys))
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := DecodeBatchGetResponse(response.Body)
+	if err != nil {
+		e.logError(ctx, logMessageFailedDecodeResponse, map[string]string{
+			logTagURL:   requestURL,
+			logTagError: err.Error(),
+		})
+		return nil, err
+	}
+	return batch, nil
+}
+
+// execute runs the pipeline. With allowNotFound a 404 response is returned as is instead of
+// being treated as a missing LinkedIn connection, for GETs of entities that may not exist,
+// unless its body says the connection is gone: the connection check is cached, so a user who
+// disconnected since must still be asked to reconnect.
+func (e *Executor) execute(ctx context.Context, requestURL string, request ProxyRequest, allowNotFound bool) (*api.Response, error) {
+	userID, err := e.connectedUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	request = request.withAPIVersion(ResolveAPIVersion(ctx, e.apiVersion))
+	response, err := e.client.ProxyLinkedInRequestOrRefresh(ctx, userID, request)
+	if err != nil {
+		e.logError(ctx, logMessageFailedRequest, map[string]string{
+			logTagURL:   requestURL,
+			logTagError: err.Error(),
+		})
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	if allowNotFound && response.StatusCode == http.StatusNotFound && !IsLinkedInNotConnectedBody(response.Body) {
+		return response, nil
+	}
+	if err := e.checkResponse(ctx, requestURL, response); err != nil {
+		if IsLinkedInNotConnected(err) {
+			e.connections.forget(userID)
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+// connectedUserID resolves the authenticated user and verifies with the gateway that the
+// user has a LinkedIn connection before any LinkedIn call is proxied.
+func (e *Executor) connectedUserID(ctx context.Context) (string, error) {
+	userID, ok := middleware.UserIDFromContext(ctx)
+	if !ok {
+		return "", ErrMissingUser
+	}
+	if e.connections.connected(userID) {
+		return userID, nil
+	}
+
+	connectionResponse, err := e.client.GetLinkedInConnection(ctx, userID)
+	if err != nil {
+		return "", &ConnectionStateError{Err: err}
+	}
+	if e.notConnectedResponse(connectionResponse) {
+		return "", e.notConnected(userID)
+	}
+	if rateLimitErr, ok := rateLimitFromResponse(RateLimitSourceGateway, connectionResponse, e.now()); ok {
+		return "", rateLimitErr
+	}
+	if connectionResponse.StatusCode < 200 || connectionResponse.StatusCode >= 300 {
+		return "", &ConnectionStateError{StatusCode: connectionResponse.StatusCode}
+	}
+
+	e.connections.remember(userID)
+	return userID, nil
+}
+
+// checkResponse maps a proxied LinkedIn response to the repository error contract:
+// not-connected, throttling and parameter validation errors are typed, other non-2xx
+// statuses are logged and returned as a [LinkedInAPIError] carrying the provider body.
+func (e *Executor) checkResponse(ctx context.Context, requestURL string, response *api.Response) error {
+	if e.notConnectedResponse(response) {
+		userID, _ := middleware.UserIDFromContext(ctx)
+		return e.notConnected(userID)
+	}
+	if rateLimitErr, ok := rateLimitFromResponse(RateLimitSourceLinkedIn, response, e.now()); ok {
+		e.logError(ctx, logMessageRateLimited, map[string]string{
+			logTagURL:    requestURL,
+			logTagStatus: strconv.Itoa(response.StatusCode),
+		})
+		return rateLimitErr
+	}
+	if validationErr, ok := ParseLinkedInParamValidationResponse(response); ok {
+		return validationErr
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	bodyString := strings.TrimSpace(string(response.Body))
+	tags := map[string]string{
+		logTagURL:    requestURL,
+		logTagStatus: strconv.Itoa(response.StatusCode),
+	}
+	if bodyString != "" {
+		tags[logTagBody] = bodyString
+	}
+	e.logError(ctx, logMessageLinkedInAPIError, tags)
+
+	return &LinkedInAPIError{StatusCode: response.StatusCode, Body: bodyString}
+}
+
+// notConnectedResponse reports whether response means the user has no LinkedIn connection.
+// Providers that mark it in the body pass LinkedIn's own 404s through unchanged.
+func (e *Executor) notConnectedResponse(response *api.Response) bool {
+	if provider, ok := e.client.(ExplicitNotConnectedProvider); ok && provider.MarksNotConnectedInBody() {
+		return response != nil && IsLinkedInNotConnectedBody(response.Body)
+	}
+	return IsLinkedInNotConnectedResponse(response)
+}
+
+// notConnected returns a [NotConnectedError] with the user's connect link when the provider
+// hands one out, and [ErrLinkedInNotConnected] otherwise.
+func (e *Executor) notConnected(userID string) error {
+	if provider, ok := e.client.(ConnectURLProvider); ok && userID != "" {
+		return &NotConnectedError{ConnectURL: provider.ConnectURL(userID)}
+	}
+	return ErrLinkedInNotConnected
+}
+
+func (e *Executor) decode(ctx context.Context, requestURL string, response *api.Response, target any) error {
+	if err := json.Unmarshal(response.Body, target); err != nil {
+		e.logError(ctx, logMessageFailedDecodeResponse, map[string]string{
+			logTagURL:   requestURL,
+			logTagError: err.Error(),
+		})
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+func (e *Executor) logError(ctx context.Context, message string, tags map[string]string) {
+	if e.logger == nil {
+		return
+	}
+
+	e.logger.Error(ctx, message, tags)
+}
+
+// connectionStates remembers users whose LinkedIn connection the gateway recently confirmed.
+// Only positive answers are cached so a user who just connected is never turned away.
+type connectionStates struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	confirmed map[string]time.Time
+	now       func() time.Time
+}
+
+func newConnectionStates(ttl time.Duration) *connectionStates {
+	return &connectionStates{
+		ttl:       ttl,
+		confirmed: map[string]time.Time{},
+		now:       time.Now,
+	}
+}
+
+func (s *connectionStates) connected(userID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.confirmed[userID]
+	if !ok {
+		return false
+	}
+	if !s.now().Before(expiresAt) {
+		delete(s.confirmed, userID)
+		return false
+	}
+	return true
+}
+
+func (s *connectionStates) remember(userID string) {
+	if s.ttl <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	// Drop expired users so the map does not grow with every user ever seen.
+	for id, expiresAt := range s.confirmed {
+		if !now.Before(expiresAt) {
+			delete(s.confirmed, id)
+		}
+	}
+	s.confirmed[userID] = now.Add(s.ttl)
+}
+
+func (s *connectionStates) forget(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.confirmed, userID)
+}
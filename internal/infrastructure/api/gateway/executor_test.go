@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	customhttp "linkedin-mcp/internal/infrastructure/http"
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRequestURL = "https://api.linkedin.com/rest/adCampaigns?q=search"
+
+// fakeGateway answers connection checks with connectionStatus and proxy calls with
+// proxyStatus and proxyBody, counting both. retryAfter, when set, is sent on every response.
+type fakeGateway struct {
+	connectionStatus int
+	proxyStatus      int
+	proxyBody        string
+	retryAfter       string
+	connectionCalls  atomic.Int32
+	proxyCalls       atomic.Int32
+	lastProxyBody    map[string]any
+}
+
+func (g *fakeGateway) serve(t *testing.T, options ...ExecutorOption) *Executor {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if g.retryAfter != "" {
+			w.Header().Set("Retry-After", g.retryAfter)
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/internal/connections/") {
+			g.connectionCalls.Add(1)
+			w.WriteHeader(g.connectionStatus)
+			_, _ = w.Write([]byte(`{"connected":true}`))
+			return
+		}
+		g.proxyCalls.Add(1)
+		raw, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(raw, &g.lastProxyBody)
+		w.WriteHeader(g.proxyStatus)
+		_, _ = w.Write([]byte(g.proxyBody))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewExecutor(NewClient(customhttp.NewClient(nil), server.URL, "secret"), nil, options...)
+}
+
+func userContext() context.Context {
+	return middleware.ContextWithUserID(context.Background(), "user_1")
+}
+
+func TestExecutor_GetJSONDecodesResponse(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{"elements":[{"id":1}]}`}
+	executor := fake.serve(t)
+
+	var decoded struct {
+		Elements []map[string]any `json:"elements"`
+	}
+	err := executor.GetJSON(userContext(), testRequestURL, map[string]string{HeaderRestLiMethod: RestLiMethodFinder}, &decoded)
+
+	require.NoError(t, err)
+	require.Len(t, decoded.Elements, 1)
+	require.Equal(t, "adCampaigns", fake.lastProxyBody["path"])
+	require.Equal(t, map[string]any{"q": "search"}, fake.lastProxyBody["query"])
+	require.Equal(t, map[string]any{
+		HeaderRestLiMethod:    RestLiMethodFinder,
+		HeaderLinkedInVersion: DefaultAPIVersion,
+	}, fake.lastProxyBody["headers"])
+}
+
+func TestExecutor_PinsAPIVersion(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{}`}
+	executor := fake.serve(t, WithAPIVersion("202601"))
+	headers := map[string]string{HeaderRestLiMethod: RestLiMethodFinder}
+
+	var decoded map[string]any
+	require.NoError(t, executor.GetJSON(userContext(), testRequestURL, headers, &decoded))
+	require.Equal(t, "202601", fake.lastProxyBody["headers"].(map[string]any)[HeaderLinkedInVersion])
+
+	ctx := WithAPIVersionOverride(userContext(), "202606")
+	require.NoError(t, executor.GetJSON(ctx, testRequestURL, headers, &decoded))
+	require.Equal(t, "202606", fake.lastProxyBody["headers"].(map[string]any)[HeaderLinkedInVersion])
+	require.Equal(t, map[string]string{HeaderRestLiMethod: RestLiMethodFinder}, headers, "caller headers must not be modified")
+}
+
+func TestExecutor_RequiresAuthenticatedUser(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{}`}
+	executor := fake.serve(t)
+
+	err := executor.GetJSON(context.Background(), testRequestURL, nil, &map[string]any{})
+
+	require.ErrorIs(t, err, ErrMissingUser)
+	require.Zero(t, fake.connectionCalls.Load())
+}
+
+func TestExecutor_CachesConfirmedConnection(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{}`}
+	executor := fake.serve(t)
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	executor.connections.now = func() time.Time { return now }
+
+	for range 3 {
+		require.NoError(t, executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{}))
+	}
+	require.Equal(t, int32(1), fake.connectionCalls.Load())
+
+	now = now.Add(defaultConnectionStateTTL)
+	require.NoError(t, executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{}))
+	require.Equal(t, int32(2), fake.connectionCalls.Load())
+}
+
+func TestExecutor_ForgetsConnectionWhenProxyReportsNotConnected(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{}`}
+	executor := fake.serve(t)
+
+	require.NoError(t, executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{}))
+
+	fake.proxyStatus = http.StatusBadRequest
+	fake.proxyBody = `{"code":"LINKEDIN_NOT_CONNECTED"}`
+	err := executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{})
+	require.ErrorIs(t, err, ErrLinkedInNotConnected)
+
+	fake.proxyStatus = http.StatusOK
+	fake.proxyBody = `{}`
+	require.NoError(t, executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{}))
+	require.Equal(t, int32(2), fake.connectionCalls.Load())
+}
+
+func TestExecutor_ConnectionStateError(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusForbidden}
+	executor := fake.serve(t)
+
+	err := executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{})
+
+	var stateErr *ConnectionStateError
+	require.ErrorAs(t, err, &stateErr)
+	require.Equal(t, http.StatusForbidden, stateErr.StatusCode)
+	require.Zero(t, fake.proxyCalls.Load())
+}
+
+func TestExecutor_TypedErrors(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusBadRequest, proxyBody: `{"code":"LINKEDIN_PARAM_INVALID","message":"bad pivot"}`}
+	executor := fake.serve(t)
+
+	err := executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{})
+	validationErr, ok := AsLinkedInParamValidation(err)
+	require.True(t, ok)
+	require.Equal(t, "bad pivot", validationErr.Message)
+
+	fake.proxyStatus = http.StatusForbidden
+	fake.proxyBody = `{"message":"not allowed"}`
+	err = executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{})
+	apiErr, ok := AsLinkedInAPIError(err)
+	require.True(t, ok)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Equal(t, "linkedin api error: status 403, body: map[message:not allowed]", apiErr.Error())
+}
+
+func TestExecutor_GetEntityReturnsNilOnNotFound(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusNotFound, proxyBody: `{}`}
+	executor := fake.serve(t)
+
+	entity, err := executor.GetEntity(userContext(), "https://api.linkedin.com/rest/adAccounts/1")
+
+	require.NoError(t, err)
+	require.Nil(t, entity)
+}
+
+func TestExecutor_GetEntityReportsDisconnectDespiteCachedConnection(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{"id":1}`}
+	executor := fake.serve(t)
+	entity, err := executor.GetEntity(userContext(), "https://api.linkedin.com/rest/adAccounts/1")
+	require.NoError(t, err)
+	require.NotNil(t, entity)
+
+	fake.proxyStatus = http.StatusNotFound
+	fake.proxyBody = `{"connected":false,"code":"LINKEDIN_NOT_CONNECTED"}`
+	_, err = executor.GetEntity(userContext(), "https://api.linkedin.com/rest/adAccounts/1")
+	require.True(t, IsLinkedInNotConnected(err))
+
+	_, _ = executor.GetEntity(userContext(), "https://api.linkedin.com/rest/adAccounts/1")
+	require.Equal(t, int32(2), fake.connectionCalls.Load(), "the cached connection is dropped")
+}
+
+func TestExecutor_BatchGet(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusOK, proxyBody: `{"results":{"1":{"name":"A"}},"statuses":{"1":200},"errors":{}}`}
+	executor := fake.serve(t)
+
+	batch, err := executor.BatchGet(userContext(), "https://api.linkedin.com/rest/adAccounts", []string{"1", "2"})
+
+	require.NoError(t, err)
+	require.Equal(t, "A", batch.Results["1"]["name"])
+	require.Equal(t, map[string]any{"ids": "List(1,2)"}, fake.lastProxyBody["query"])
+}
+
+func TestExecutor_RateLimitErrors(t *testing.T) {
+	fake := &fakeGateway{connectionStatus: http.StatusOK, proxyStatus: http.StatusTooManyRequests, proxyBody: `{"message":"throttled"}`, retryAfter: "120"}
+	executor := fake.serve(t)
+
+	err := executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{})
+	rateLimitErr, ok := AsRateLimit(err)
+	require.True(t, ok)
+	require.Equal(t, RateLimitSourceLinkedIn, rateLimitErr.Source)
+	require.Equal(t, 2*time.Minute, rateLimitErr.RetryAfter)
+	require.Equal(t, "linkedin rate limit exceeded (status 429), retry after 120 seconds", rateLimitErr.Error())
+	require.Equal(t, int32(1), fake.proxyCalls.Load(), "waits beyond MaxRetryDelay are not slept")
+
+	fake = &fakeGateway{connectionStatus: http.StatusServiceUnavailable, retryAfter: "90"}
+	executor = fake.serve(t)
+
+	err = executor.GetJSON(userContext(), testRequestURL, nil, &map[string]any{})
+	rateLimitErr, ok = AsRateLimit(err)
+	require.True(t, ok)
+	require.Equal(t, RateLimitSourceGateway, rateLimitErr.Source)
+	require.Equal(t, 90, rateLimitErr.RetryAfterSeconds())
+}
@@ -0,0 +1,223 @@
+package fakegateway
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxAnalyticsBuckets bounds DAILY responses for long ranges.
+const maxAnalyticsBuckets = 400
+
+var datePattern = regexp.MustCompile(`(start|end):\(year:(\d+),month:(\d+),day:(\d+)\)`)
+
+// metricRatios derive common metrics from impressions so reports look plausible and derived
+// ratios (CTR, CPC, conversion rate) stay consistent.
+var metricRatios = map[string]float64{
+	"clicks":                     0.012,
+	"landingPageClicks":          0.010,
+	"totalEngagements":           0.025,
+	"likes":                      0.006,
+	"comments":                   0.001,
+	"shares":                     0.0008,
+	"follows":                    0.0005,
+	"videoViews":                 0.2,
+	"videoCompletions":           0.08,
+	"externalWebsiteConversions": 0.0015,
+	"oneClickLeads":              0.0009,
+	"oneClickLeadFormOpens":      0.003,
+	"approximateMemberReach":     0.55,
+	"sends":                      0.3,
+	"opens":                      0.15,
+}
+
+type bucket struct {
+	start, end time.Time
+}
+
+// analytics answers an adAnalytics analytics or statistics finder with deterministic numbers:
+// one element per time bucket and pivot value combination, carrying every requested field.
+func (s *Server) analytics(query map[string]string) map[string]any {
+	start, end := analyticsRange(query["dateRange"])
+	buckets := analyticsBuckets(start, end, strings.ToUpper(query["timeGranularity"]))
+
+	pivots := listValues(query["pivots"])
+	if pivot := strings.TrimSpace(query["pivot"]); pivot != "" {
+		pivots = []string{pivot}
+	}
+	accounts := listValues(query["accounts"])
+	combinations := [][]string{nil}
+	for _, pivot := range pivots {
+		var next [][]string
+		for _, combination := range combinations {
+			for _, value := range s.pivotValues(pivot, accounts, listValues(query["campaigns"])) {
+				next = append(next, append(append([]string(nil), combination...), value))
+			}
+		}
+		combinations = next
+	}
+
+	fields := strings.Split(query["fields"], ",")
+	var elements []map[string]any
+	for _, b := range buckets {
+		for _, combination := range combinations {
+			element := map[string]any{}
+			seed := b.start.Format(time.DateOnly) + strings.Join(combination, "|")
+			impressions := 500 + hash(seed)%1500
+			for _, field := range fields {
+				field = strings.TrimSpace(field)
+				switch field {
+				case "":
+				case "dateRange":
+					element["dateRange"] = map[string]any{"start": dateValue(b.start), "end": dateValue(b.end)}
+				case "pivotValues":
+					element["pivotValues"] = combination
+				default:
+					element[field] = metricValue(field, impressions, seed)
+				}
+			}
+			elements = append(elements, element)
+		}
+	}
+
+	first, count := paging(query, len(elements))
+	return map[string]any{
+		"elements": elements[first : first+count],
+		"paging":   map[string]any{"start": first, "count": count, "links": []any{}},
+	}
+}
+
+// pivotValues lists the URNs a pivot breaks results down by, limited to the requested accounts
+// and campaigns where the fixtures know them.
+func (s *Server) pivotValues(pivot string, accounts, campaigns []string) []string {
+	inAccounts := func(entity map[string]any) bool {
+		if len(accounts) == 0 {
+			return true
+		}
+		for _, account := range accounts {
+			if entity["account"] == account {
+				return true
+			}
+		}
+		return false
+	}
+	inCampaigns := func(urn string) bool {
+		if len(campaigns) == 0 {
+			return true
+		}
+		for _, campaign := range campaigns {
+			if campaign == urn {
+				return true
+			}
+		}
+		return false
+	}
+
+	var values []string
+	switch strings.ToUpper(pivot) {
+	case "ACCOUNT":
+		values = accounts
+	case "CAMPAIGN":
+		for _, campaign := range s.fixtures.AdCampaigns {
+			urn := "urn:li:sponsoredCampaign:" + entityKey(campaign)
+			if inAccounts(campaign) && inCampaigns(urn) {
+				values = append(values, urn)
+			}
+		}
+	case "CAMPAIGN_GROUP":
+		for _, group := range s.fixtures.AdCampaignGroups {
+			if inAccounts(group) {
+				values = append(values, "urn:li:sponsoredCampaignGroup:"+entityKey(group))
+			}
+		}
+	case "CREATIVE":
+		for _, creative := range s.fixtures.Creatives {
+			if inAccounts(creative) && inCampaigns(stringValue(creative["campaign"])) {
+				values = append(values, entityKey(creative))
+			}
+		}
+	case "COMPANY", "MEMBER_COMPANY":
+		values = []string{"urn:li:organization:1001"}
+	case "MEMBER_SENIORITY":
+		values = []string{"urn:li:seniority:3", "urn:li:seniority:4"}
+	case "MEMBER_INDUSTRY":
+		values = []string{"urn:li:industry:4"}
+	default:
+		values = []string{fmt.Sprintf("urn:li:%s:1", strings.ToLower(pivot))}
+	}
+	if len(values) == 0 {
+		return []string{}
+	}
+	return values
+}
+
+func metricValue(field string, impressions uint32, seed string) any {
+	switch {
+	case field == "impressions":
+		return impressions
+	case strings.HasPrefix(field, "cost"):
+		clicks := float64(impressions) * metricRatios["clicks"]
+		return strconv.FormatFloat(clicks*4.25+float64(hash(seed+field)%100)/100, 'f', 2, 64)
+	}
+	if ratio, ok := metricRatios[field]; ok {
+		return int(float64(impressions) * ratio)
+	}
+	return hash(seed+field) % 50
+}
+
+func analyticsRange(raw string) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, match := range datePattern.FindAllStringSubmatch(raw, -1) {
+		year, _ := strconv.Atoi(match[2])
+		month, _ := strconv.Atoi(match[3])
+		day, _ := strconv.Atoi(match[4])
+		date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+		if match[1] == "start" {
+			start = date
+		} else {
+			end = date
+		}
+	}
+	if start.IsZero() {
+		start = time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -7)
+	}
+	if end.IsZero() || end.Before(start) {
+		end = start.AddDate(0, 0, 6)
+	}
+	return start, end
+}
+
+func analyticsBuckets(start, end time.Time, granularity string) []bucket {
+	next := func(t time.Time) time.Time { return end.AddDate(0, 0, 1) }
+	switch granularity {
+	case "DAILY":
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+	case "MONTHLY":
+		next = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC) }
+	case "YEARLY":
+		next = func(t time.Time) time.Time { return time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC) }
+	}
+
+	var buckets []bucket
+	for from := start; !from.After(end) && len(buckets) < maxAnalyticsBuckets; from = next(from) {
+		to := next(from).AddDate(0, 0, -1)
+		if to.After(end) {
+			to = end
+		}
+		buckets = append(buckets, bucket{start: from, end: to})
+	}
+	return buckets
+}
+
+func dateValue(t time.Time) map[string]int {
+	return map[string]int{"year": t.Year(), "month": int(t.Month()), "day": t.Day()}
+}
+
+func hash(value string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+	return h.Sum32()
+}
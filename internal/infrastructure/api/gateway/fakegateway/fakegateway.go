@@ -0,0 +1,328 @@
+// Package fakegateway is an in-process stand-in for the Jumon gateway. It serves the internal
+// connection, proxy and refresh endpoints from canned LinkedIn fixtures and can inject
+// 401/404/429/5xx and PARAM_INVALID responses, so MCP tool calls can be exercised end to end
+// without network access:
+//
+//	fake := fakegateway.New("secret")
+//	server := httptest.NewServer(fake)
+//	fake.Inject(fakegateway.RateLimited(30).On("adAnalytics").Once())
+//
+// Every user is connected to LinkedIn unless disconnected with [Server.Disconnect].
+package fakegateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	pathConnection = "/api/internal/connections/linkedin/current"
+	pathProxy      = "/api/internal/providers/linkedin/proxy"
+	pathRefresh    = "/api/internal/providers/linkedin/refresh"
+
+	// pathFaults and pathConnections let out-of-process callers (the fakegateway command)
+	// drive the fake: POST a Fault JSON or DELETE all faults; POST or DELETE /_fake/connections/{userID}.
+	pathFaults      = "/_fake/faults"
+	pathConnections = "/_fake/connections/"
+
+	headerGatewaySecret = "x-gateway-secret"
+)
+
+// Request is one call received by the fake. Method, Path, Query and Headers describe the
+// proxied LinkedIn call for proxy requests.
+type Request struct {
+	Endpoint string
+	UserID   string
+	Method   string
+	Path     string
+	Query    map[string]string
+	Headers  map[string]string
+	Body     json.RawMessage
+}
+
+// Server is an http.Handler implementing the Jumon internal API.
+type Server struct {
+	secret       string
+	mu           sync.Mutex
+	fixtures     Fixtures
+	disconnected map[string]bool
+	faults       []*Fault
+	requests     []Request
+}
+
+// New returns a fake serving [DefaultFixtures]. An empty secret accepts every caller.
+func New(secret string) *Server {
+	return NewWithFixtures(secret, DefaultFixtures())
+}
+
+// NewWithFixtures returns a fake serving fixtures.
+func NewWithFixtures(secret string, fixtures Fixtures) *Server {
+	return &Server{
+		secret:       secret,
+		fixtures:     fixtures,
+		disconnected: map[string]bool{},
+	}
+}
+
+// Connect marks userID as having a LinkedIn connection (the default).
+func (s *Server) Connect(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.disconnected, userID)
+}
+
+// Disconnect marks userID as having no LinkedIn connection.
+func (s *Server) Disconnect(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disconnected[userID] = true
+}
+
+// Inject adds a fault. Faults are matched in the order they were added.
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the calls received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Count returns how many calls endpoint received.
+func (s *Server) Count(endpoint string) int {
+	count := 0
+	for _, request := range s.Requests() {
+		if request.Endpoint == endpoint {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == pathFaults:
+		s.serveFaults(w, r)
+		return
+	case strings.HasPrefix(r.URL.Path, pathConnections):
+		s.serveConnections(w, r)
+		return
+	}
+
+	if s.secret != "" && r.Header.Get(headerGatewaySecret) != s.secret {
+		writeJSON(w, http.StatusUnauthorized, nil, map[string]any{"code": "UNAUTHORIZED", "message": "invalid gateway secret"})
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == pathConnection:
+		s.serveConnection(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == pathProxy:
+		s.serveProxy(w, r)
+	case r.Method == http.MethodPost && r.URL.Path == pathRefresh:
+		s.serveRefresh(w, r)
+	default:
+		writeJSON(w, http.StatusNotFound, nil, map[string]any{"code": "NOT_FOUND", "message": "unknown gateway endpoint"})
+	}
+}
+
+func (s *Server) serveConnection(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("userId")
+	s.record(Request{Endpoint: EndpointConnections, UserID: userID})
+	if s.writeFault(w, EndpointConnections, "", "") {
+		return
+	}
+	if !s.connected(userID) {
+		writeJSON(w, http.StatusNotFound, nil, notConnectedBody())
+		return
+	}
+	writeJSON(w, http.StatusOK, nil, map[string]any{
+		"connected": true,
+		"connection": map[string]any{
+			"provider":  "linkedin",
+			"connected": true,
+			"scopes":    []string{"r_ads", "r_ads_reporting", "rw_ads"},
+		},
+	})
+}
+
+type proxyBody struct {
+	UserID  string            `json:"userId"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   map[string]string `json:"query"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+func (s *Server) serveProxy(w http.ResponseWriter, r *http.Request) {
+	var body proxyBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.UserID == "" {
+		writeJSON(w, http.StatusBadRequest, nil, map[string]any{"code": "BAD_REQUEST", "message": "userId, method and path are required"})
+		return
+	}
+	if body.Method == "" {
+		body.Method = http.MethodGet
+	}
+	s.record(Request{
+		Endpoint: EndpointProxy,
+		UserID:   body.UserID,
+		Method:   body.Method,
+		Path:     body.Path,
+		Query:    body.Query,
+		Headers:  body.Headers,
+		Body:     body.Body,
+	})
+	if s.writeFault(w, EndpointProxy, body.Method, body.Path) {
+		return
+	}
+	if !s.connected(body.UserID) {
+		writeJSON(w, http.StatusNotFound, nil, notConnectedBody())
+		return
+	}
+
+	s.mu.Lock()
+	status, response := s.linkedIn(linkedInCall{
+		method:  strings.ToUpper(body.Method),
+		path:    strings.Trim(body.Path, "/"),
+		query:   body.Query,
+		restLi:  restLiMethod(body.Headers),
+		payload: body.Body,
+	})
+	s.mu.Unlock()
+	writeJSON(w, status, nil, response)
+}
+
+func (s *Server) serveRefresh(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		UserID string `json:"userId"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	s.record(Request{Endpoint: EndpointRefresh, UserID: body.UserID})
+	if s.writeFault(w, EndpointRefresh, "", "") {
+		return
+	}
+	if !s.connected(body.UserID) {
+		writeJSON(w, http.StatusNotFound, nil, notConnectedBody())
+		return
+	}
+	writeJSON(w, http.StatusOK, nil, map[string]any{"ok": true})
+}
+
+func (s *Server) serveFaults(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var fault Fault
+		if err := json.NewDecoder(r.Body).Decode(&fault); err != nil || fault.Status == 0 {
+			writeJSON(w, http.StatusBadRequest, nil, map[string]any{"message": "expected a fault with a status"})
+			return
+		}
+		s.Inject(fault)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		s.ClearFaults()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) serveConnections(w http.ResponseWriter, r *http.Request) {
+	userID := strings.TrimPrefix(r.URL.Path, pathConnections)
+	switch r.Method {
+	case http.MethodPost:
+		s.Connect(userID)
+	case http.MethodDelete:
+		s.Disconnect(userID)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) record(request Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+func (s *Server) connected(userID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.disconnected[userID]
+}
+
+// writeFault answers with the first matching fault, if any.
+func (s *Server) writeFault(w http.ResponseWriter, endpoint, method, path string) bool {
+	s.mu.Lock()
+	var matched *Fault
+	for i, fault := range s.faults {
+		if !fault.matches(endpoint, method, path) {
+			continue
+		}
+		matched = fault
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		break
+	}
+	s.mu.Unlock()
+	if matched == nil {
+		return false
+	}
+
+	header := http.Header{}
+	if matched.RetryAfterSeconds > 0 {
+		header.Set("Retry-After", strconv.Itoa(matched.RetryAfterSeconds))
+	}
+	writeJSON(w, matched.Status, header, matched.Body)
+	return true
+}
+
+func notConnectedBody() map[string]any {
+	return map[string]any{
+		"connected": false,
+		"code":      "LINKEDIN_NOT_CONNECTED",
+		"message":   "LinkedIn is not connected for this user",
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, header http.Header, body any) {
+	for key, values := range header {
+		w.Header()[key] = values
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func restLiMethod(headers map[string]string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, "X-RestLi-Method") {
+			return strings.ToUpper(strings.TrimSpace(value))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+package fakegateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	customhttp "linkedin-mcp/internal/infrastructure/http"
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+const restBaseURL = "https://api.linkedin.com/rest"
+
+func serve(t *testing.T) (*Server, *gateway.Executor, *httptest.Server) {
+	t.Helper()
+	fake := New("secret")
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	config := customhttp.DefaultConfig()
+	config.MaxRetries = 0
+	client := gateway.NewClient(customhttp.NewClient(config), server.URL, "secret")
+	return fake, gateway.NewExecutor(client, nil), server
+}
+
+func userContext() context.Context {
+	return middleware.ContextWithUserID(context.Background(), "user_1")
+}
+
+func TestSearchFinder_FiltersByAccountAndStatus(t *testing.T) {
+	_, executor, _ := serve(t)
+
+	var response struct {
+		Elements []map[string]any `json:"elements"`
+	}
+	err := executor.GetJSON(userContext(), restBaseURL+"/adAccounts/512345678/adCampaigns?q=search&search=(status:(values:List(ACTIVE)))", nil, &response)
+
+	require.NoError(t, err)
+	require.Len(t, response.Elements, 1)
+	require.Equal(t, "Spring Launch - Sponsored Content", response.Elements[0]["name"])
+}
+
+func TestPartialUpdate_ChangesLaterReads(t *testing.T) {
+	_, executor, _ := serve(t)
+	ctx := userContext()
+	campaignURL := restBaseURL + "/adAccounts/512345678/adCampaigns/700000001"
+
+	_, err := executor.Do(ctx, campaignURL, gateway.NewPartialUpdateRequest("adAccounts/512345678/adCampaigns/700000001", map[string]any{"status": "PAUSED"}, nil))
+	require.NoError(t, err)
+
+	campaign, err := executor.GetEntity(ctx, campaignURL)
+	require.NoError(t, err)
+	require.Equal(t, "PAUSED", campaign["status"])
+	require.Equal(t, "ACTIVE", DefaultFixtures().AdCampaigns[0]["status"], "embedded fixtures are not mutated")
+
+	missing, err := executor.GetEntity(ctx, restBaseURL+"/adAccounts/512345678/adCampaigns/1")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+}
+
+func TestBatchGet_ResolvesCreativeURNs(t *testing.T) {
+	_, executor, _ := serve(t)
+
+	batch, err := executor.BatchGet(userContext(), restBaseURL+"/adAccounts/512345678/creatives", []string{
+		"urn%3Ali%3AsponsoredCreative%3A800000002",
+		"urn%3Ali%3AsponsoredCreative%3A1",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, batch.Results, 1)
+	require.Equal(t, http.StatusNotFound, batch.Statuses["urn:li:sponsoredCreative:1"])
+}
+
+func TestAnalytics_OneElementPerBucketAndPivotValue(t *testing.T) {
+	_, executor, _ := serve(t)
+
+	var response struct {
+		Elements []map[string]any `json:"elements"`
+	}
+	err := executor.GetJSON(userContext(), restBaseURL+"/adAnalytics?q=analytics&pivot=CAMPAIGN"+
+		"&dateRange=(start:(year:2026,month:3,day:1),end:(year:2026,month:3,day:3))&timeGranularity=DAILY"+
+		"&accounts=List(urn%3Ali%3AsponsoredAccount%3A512345678)&fields=impressions,clicks,costInLocalCurrency,dateRange,pivotValues", nil, &response)
+
+	require.NoError(t, err)
+	require.Len(t, response.Elements, 3*2)
+	first := response.Elements[0]
+	require.Equal(t, map[string]any{"year": float64(2026), "month": float64(3), "day": float64(1)}, first["dateRange"].(map[string]any)["start"])
+	require.Equal(t, []any{"urn:li:sponsoredCampaign:700000001"}, first["pivotValues"])
+	require.Greater(t, first["impressions"], first["clicks"])
+	require.IsType(t, "", first["costInLocalCurrency"])
+}
+
+func TestFaults_DriveClientErrorHandling(t *testing.T) {
+	fake, executor, _ := serve(t)
+	ctx := userContext()
+	searchURL := restBaseURL + "/adAccounts?q=search"
+	var response map[string]any
+
+	fake.Inject(Unauthorized().Once())
+	require.NoError(t, executor.GetJSON(ctx, searchURL, nil, &response), "the client refreshes the token and replays the call")
+	require.Equal(t, 1, fake.Count(EndpointRefresh))
+
+	fake.Inject(ParamInvalid("search.name", "wrong type").On("adAccounts").Once())
+	err := executor.GetJSON(ctx, searchURL, nil, &response)
+	validationErr, ok := gateway.AsLinkedInParamValidation(err)
+	require.True(t, ok)
+	require.Equal(t, "search.name", validationErr.InputErrors[0].FieldPath)
+
+	fake.Inject(RateLimited(120).Once())
+	_, ok = gateway.AsRateLimit(executor.GetJSON(ctx, searchURL, nil, &response))
+	require.True(t, ok)
+
+	fake.Inject(ServerError(http.StatusBadGateway).Once())
+	_, ok = gateway.AsLinkedInAPIError(executor.GetJSON(ctx, searchURL, nil, &response))
+	require.True(t, ok)
+
+	fake.Disconnect("user_2")
+	err = executor.GetJSON(middleware.ContextWithUserID(context.Background(), "user_2"), searchURL, nil, &response)
+	require.True(t, gateway.IsLinkedInNotConnected(err))
+}
+
+func TestServeHTTP_RejectsWrongSecretAndAcceptsControlCalls(t *testing.T) {
+	fake, _, server := serve(t)
+
+	response, err := http.Get(server.URL + pathConnection + "?userId=user_1")
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	response, err = http.Post(server.URL+pathFaults, "application/json", strings.NewReader(`{"endpoint":"refresh","status":503,"times":2}`))
+	require.NoError(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusNoContent, response.StatusCode)
+	require.Len(t, fake.faults, 1)
+
+	request, _ := http.NewRequest(http.MethodDelete, server.URL+pathConnections+"user_1", nil)
+	response, err = http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	response.Body.Close()
+	require.False(t, fake.connected("user_1"))
+}
@@ -0,0 +1,109 @@
+package fakegateway
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Gateway endpoints a fault can target.
+const (
+	EndpointConnections = "connections"
+	EndpointProxy       = "proxy"
+	EndpointRefresh     = "refresh"
+)
+
+// Fault replaces matching gateway responses with Status and Body. Proxy faults can be narrowed
+// to a LinkedIn resource path prefix and HTTP method. A zero Times applies the fault until it
+// is cleared; otherwise it is removed after that many matches.
+type Fault struct {
+	Endpoint          string `json:"endpoint,omitempty"`
+	Path              string `json:"path,omitempty"`
+	Method            string `json:"method,omitempty"`
+	Status            int    `json:"status"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds,omitempty"`
+	Body              any    `json:"body,omitempty"`
+	Times             int    `json:"times,omitempty"`
+}
+
+// Unauthorized makes LinkedIn reject the access token, which makes the client refresh it and
+// replay the call.
+func Unauthorized() Fault {
+	return Fault{Status: http.StatusUnauthorized, Body: map[string]any{
+		"status":  http.StatusUnauthorized,
+		"code":    "LINKEDIN_UNAUTHORIZED",
+		"message": "The LinkedIn access token has expired",
+	}}
+}
+
+// NotFound answers 404, which the server treats as a missing LinkedIn connection.
+func NotFound() Fault {
+	return Fault{Status: http.StatusNotFound, Body: map[string]any{
+		"status":  http.StatusNotFound,
+		"code":    "NOT_FOUND",
+		"message": "Not found",
+	}}
+}
+
+// RateLimited answers 429 with a Retry-After header.
+func RateLimited(retryAfterSeconds int) Fault {
+	return Fault{Status: http.StatusTooManyRequests, RetryAfterSeconds: retryAfterSeconds, Body: map[string]any{
+		"status":  http.StatusTooManyRequests,
+		"code":    "TOO_MANY_REQUESTS",
+		"message": "Resource level throttle APPLICATION_AND_MEMBER DAY limit for calls to this resource is reached.",
+	}}
+}
+
+// ServerError answers a 5xx status.
+func ServerError(status int) Fault {
+	return Fault{Status: status, Body: map[string]any{
+		"status":  status,
+		"code":    "INTERNAL_ERROR",
+		"message": http.StatusText(status),
+	}}
+}
+
+// ParamInvalid answers the gateway's LINKEDIN_PARAM_INVALID payload for fieldPath.
+func ParamInvalid(fieldPath, description string) Fault {
+	return Fault{Status: http.StatusBadRequest, Body: map[string]any{
+		"code":           "LINKEDIN_PARAM_INVALID",
+		"message":        "Invalid param",
+		"providerStatus": http.StatusBadRequest,
+		"inputErrors": []map[string]any{{
+			"code":        "PARAM_INVALID",
+			"description": description,
+			"fieldPath":   fieldPath,
+		}},
+	}}
+}
+
+// On narrows a proxy fault to LinkedIn resource paths starting with path, e.g. "adAnalytics".
+func (f Fault) On(path string) Fault {
+	f.Path = path
+	return f
+}
+
+// For targets another gateway endpoint than the proxy.
+func (f Fault) For(endpoint string) Fault {
+	f.Endpoint = endpoint
+	return f
+}
+
+// Once removes the fault after its first match.
+func (f Fault) Once() Fault {
+	f.Times = 1
+	return f
+}
+
+func (f *Fault) matches(endpoint, method, path string) bool {
+	target := f.Endpoint
+	if target == "" {
+		target = EndpointProxy
+	}
+	if target != endpoint {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, method) {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimLeft(path, "/"), strings.TrimLeft(f.Path, "/"))
+}
@@ -0,0 +1,33 @@
+package fakegateway
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed fixtures.json
+var defaultFixtures []byte
+
+// Fixtures are the LinkedIn entities the fake serves. Campaign groups, campaigns and creatives
+// belong to the ad account in their "account" URN. Lookups are keyed by collection (e.g.
+// organizationsLookup, seniorities) and then by entity ID.
+type Fixtures struct {
+	AdAccounts       []map[string]any                     `json:"adAccounts"`
+	AdCampaignGroups []map[string]any                     `json:"adCampaignGroups"`
+	AdCampaigns      []map[string]any                     `json:"adCampaigns"`
+	Creatives        []map[string]any                     `json:"creatives"`
+	Lookups          map[string]map[string]map[string]any `json:"lookups"`
+}
+
+// DefaultFixtures returns a fresh copy of the canned fixtures: two ad accounts (512345678 in
+// USD and 598765432 in EUR), their campaign groups, campaigns 700000001-700000003 and
+// creatives 800000001-800000003. Partial updates mutate the copy a server holds, never the
+// embedded data.
+func DefaultFixtures() Fixtures {
+	var fixtures Fixtures
+	if err := json.Unmarshal(defaultFixtures, &fixtures); err != nil {
+		panic(fmt.Sprintf("fakegateway: invalid embedded fixtures: %v", err))
+	}
+	return fixtures
+}
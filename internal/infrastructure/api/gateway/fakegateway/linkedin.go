@@ -0,0 +1,260 @@
+package fakegateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const accountURNPrefix = "urn:li:sponsoredAccount:"
+
+// searchFieldPattern matches one field of a Rest.li search=(field:(values:List(...)),...) finder.
+var searchFieldPattern = regexp.MustCompile(`(\w+):\(values:List\(([^)]*)\)\)`)
+
+type linkedInCall struct {
+	method  string
+	path    string
+	query   map[string]string
+	restLi  string
+	payload json.RawMessage
+}
+
+// linkedIn answers one proxied LinkedIn REST call from the fixtures. The caller holds s.mu.
+func (s *Server) linkedIn(call linkedInCall) (int, any) {
+	segments := strings.Split(call.path, "/")
+	for i, segment := range segments {
+		if decoded, err := url.PathUnescape(segment); err == nil {
+			segments[i] = decoded
+		}
+	}
+
+	switch {
+	case segments[0] == "adAnalytics" && len(segments) == 1:
+		return http.StatusOK, s.analytics(call.query)
+	case segments[0] == "adAccounts" && len(segments) <= 2:
+		return s.entities(call, s.fixtures.AdAccounts, segments[1:], nil)
+	case segments[0] == "adAccounts" && len(segments) <= 4:
+		owner := accountURNPrefix + segments[1]
+		switch segments[2] {
+		case "adCampaignGroups":
+			return s.entities(call, s.fixtures.AdCampaignGroups, segments[3:], ownedBy(owner))
+		case "adCampaigns":
+			return s.entities(call, s.fixtures.AdCampaigns, segments[3:], ownedBy(owner))
+		case "creatives":
+			return s.entities(call, s.fixtures.Creatives, segments[3:], ownedBy(owner))
+		}
+	case len(segments) == 1:
+		if lookup, ok := s.fixtures.Lookups[segments[0]]; ok {
+			entities := make([]map[string]any, 0, len(lookup))
+			for _, entity := range lookup {
+				entities = append(entities, entity)
+			}
+			return s.entities(call, entities, nil, nil)
+		}
+	}
+	return notFound()
+}
+
+func ownedBy(accountURN string) func(map[string]any) bool {
+	return func(entity map[string]any) bool {
+		return entity["account"] == accountURN
+	}
+}
+
+// entities serves a collection (finder, BATCH_GET, BATCH_PARTIAL_UPDATE) when key is empty and
+// one entity (GET, PARTIAL_UPDATE) otherwise.
+func (s *Server) entities(call linkedInCall, all []map[string]any, key []string, owned func(map[string]any) bool) (int, any) {
+	var scoped []map[string]any
+	for _, entity := range all {
+		if owned == nil || owned(entity) {
+			scoped = append(scoped, entity)
+		}
+	}
+
+	if len(key) == 1 {
+		entity := findEntity(scoped, key[0])
+		if entity == nil {
+			return notFound()
+		}
+		if call.method == http.MethodPost {
+			applyPatch(entity, call.payload)
+			return http.StatusNoContent, nil
+		}
+		return http.StatusOK, entity
+	}
+
+	if ids, ok := call.query["ids"]; ok {
+		if call.method == http.MethodPost {
+			return batchPartialUpdate(scoped, call.payload)
+		}
+		return batchGet(scoped, listValues(ids))
+	}
+	if call.method != http.MethodGet {
+		return http.StatusMethodNotAllowed, map[string]any{"status": http.StatusMethodNotAllowed, "message": "unsupported method"}
+	}
+
+	elements := filterEntities(scoped, call.query)
+	if call.query["q"] == "criteria" {
+		return http.StatusOK, map[string]any{"elements": elements, "metadata": map[string]any{}}
+	}
+	start, count := paging(call.query, len(elements))
+	return http.StatusOK, map[string]any{
+		"elements": elements[start : start+count],
+		"paging":   map[string]any{"start": start, "count": count, "total": len(elements), "links": []any{}},
+	}
+}
+
+// filterEntities applies the search=(...) finder fields and the creatives criteria facets.
+func filterEntities(entities []map[string]any, query map[string]string) []map[string]any {
+	filters := map[string][]string{}
+	for _, match := range searchFieldPattern.FindAllStringSubmatch(query["search"], -1) {
+		filters[match[1]] = listItems(match[2])
+	}
+	if campaigns, ok := query["campaigns"]; ok {
+		filters["campaign"] = listValues(campaigns)
+	}
+
+	elements := []map[string]any{}
+	for _, entity := range entities {
+		if matchesFilters(entity, filters) {
+			elements = append(elements, entity)
+		}
+	}
+	return elements
+}
+
+func matchesFilters(entity map[string]any, filters map[string][]string) bool {
+	for field, values := range filters {
+		actual := entityKey(entity)
+		if field != "id" {
+			actual = stringValue(entity[field])
+		}
+		matched := false
+		for _, value := range values {
+			if field == "name" && strings.Contains(strings.ToLower(actual), strings.ToLower(value)) || actual == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func batchGet(entities []map[string]any, keys []string) (int, any) {
+	results := map[string]any{}
+	statuses := map[string]int{}
+	failures := map[string]any{}
+	for _, key := range keys {
+		entity := findEntity(entities, key)
+		if entity == nil {
+			statuses[key] = http.StatusNotFound
+			failures[key] = map[string]any{"status": http.StatusNotFound, "message": "Not found"}
+			continue
+		}
+		results[key] = entity
+		statuses[key] = http.StatusOK
+	}
+	return http.StatusOK, map[string]any{"results": results, "statuses": statuses, "errors": failures}
+}
+
+func batchPartialUpdate(entities []map[string]any, payload json.RawMessage) (int, any) {
+	var body struct {
+		Entities map[string]json.RawMessage `json:"entities"`
+	}
+	_ = json.Unmarshal(payload, &body)
+	results := map[string]any{}
+	for key, patch := range body.Entities {
+		entity := findEntity(entities, key)
+		if entity == nil {
+			results[key] = map[string]any{"status": http.StatusNotFound}
+			continue
+		}
+		applyPatch(entity, patch)
+		results[key] = map[string]any{"status": http.StatusNoContent}
+	}
+	return http.StatusOK, map[string]any{"results": results}
+}
+
+// applyPatch applies a Rest.li {"patch": {"$set": {...}, "$delete": [...]}} document.
+func applyPatch(entity map[string]any, payload json.RawMessage) {
+	var body struct {
+		Patch struct {
+			Set    map[string]any `json:"$set"`
+			Delete []string       `json:"$delete"`
+		} `json:"patch"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return
+	}
+	for field, value := range body.Patch.Set {
+		entity[field] = value
+	}
+	for _, field := range body.Patch.Delete {
+		delete(entity, field)
+	}
+}
+
+func findEntity(entities []map[string]any, key string) map[string]any {
+	for _, entity := range entities {
+		if entityKey(entity) == key {
+			return entity
+		}
+	}
+	return nil
+}
+
+func entityKey(entity map[string]any) string {
+	return stringValue(entity["id"])
+}
+
+func stringValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// listValues parses a Rest.li List(a,b) parameter, URL-decoding each item.
+func listValues(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	raw = strings.TrimSuffix(strings.TrimPrefix(raw, "List("), ")")
+	return listItems(raw)
+}
+
+func listItems(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if decoded, err := url.QueryUnescape(item); err == nil {
+			item = decoded
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func paging(query map[string]string, total int) (int, int) {
+	start, _ := strconv.Atoi(query["start"])
+	start = min(max(start, 0), total)
+	count, err := strconv.Atoi(query["count"])
+	if err != nil || count <= 0 || start+count > total {
+		count = total - start
+	}
+	return start, count
+}
+
+func notFound() (int, any) {
+	return http.StatusNotFound, map[string]any{"status": http.StatusNotFound, "code": "NOT_FOUND", "message": "Not found"}
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// Provider executes LinkedIn REST calls on behalf of a user. [Client] goes through the Jumon
+// gateway; other implementations call LinkedIn directly. Implementations follow the gateway
+// response contract: a 404 or a {"connected":false} body means the user has no LinkedIn
+// connection (only the body for an [ExplicitNotConnectedProvider]), and parameter errors use
+// the LINKEDIN_PARAM_INVALID payload.
+type Provider interface {
+	// GetLinkedInConnection reports whether userID has a usable LinkedIn connection.
+	GetLinkedInConnection(ctx context.Context, userID string) (*api.Response, error)
+	// ProxyLinkedInRequestOrRefresh executes request with the user's token, refreshing the
+	// token once if LinkedIn rejects it.
+	ProxyLinkedInRequestOrRefresh(ctx context.Context, userID string, request ProxyRequest) (*api.Response, error)
+}
+
+// ExplicitNotConnectedProvider is implemented by providers that always mark a missing
+// connection in the response body, such as the direct OAuth flow. A bare 404 from them is
+// LinkedIn's own answer for a missing or inaccessible entity, not a missing connection.
+type ExplicitNotConnectedProvider interface {
+	MarksNotConnectedInBody() bool
+}
+
+// ConnectURLProvider is implemented by providers that hand out a user-specific link for
+// connecting LinkedIn, such as the direct OAuth flow. Without it, tools show the configured
+// connect URL.
+type ConnectURLProvider interface {
+	ConnectURL(userID string) string
+}
+
+// NotConnectedError is an [ErrLinkedInNotConnected] carrying the link the user should open
+// to connect LinkedIn.
+type NotConnectedError struct {
+	ConnectURL string
+}
+
+func (e *NotConnectedError) Error() string {
+	return ErrLinkedInNotConnected.Error()
+}
+
+func (e *NotConnectedError) Unwrap() error {
+	return ErrLinkedInNotConnected
+}
+
+// ConnectURLFromError returns the user-specific connect link carried by err, if any.
+func ConnectURLFromError(err error) string {
+	var target *NotConnectedError
+	if errors.As(err, &target) {
+		return target.ConnectURL
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// Rest.li method names sent in the X-RestLi-Method header. LinkedIn uses the header to
+// disambiguate operations that share an HTTP verb (e.g. POST for CREATE and PARTIAL_UPDATE).
+// Reference: https://learn.microsoft.com/en-us/linkedin/shared/api-guide/concepts/methods
+const (
+	HeaderRestLiMethod = "X-RestLi-Method"
+
+	RestLiMethodFinder             = "FINDER"
+	RestLiMethodGet                = "GET"
+	RestLiMethodBatchGet           = "BATCH_GET"
+	RestLiMethodCreate             = "CREATE"
+	RestLiMethodBatchCreate        = "BATCH_CREATE"
+	RestLiMethodPartialUpdate      = "PARTIAL_UPDATE"
+	RestLiMethodBatchPartialUpdate = "BATCH_PARTIAL_UPDATE"
+	RestLiMethodDelete             = "DELETE"
+	RestLiMethodBatchDelete        = "BATCH_DELETE"
+
+	// LinkedIn echoes the ID of a newly created entity in one of these response headers.
+	headerRestLiID   = "X-RestLi-Id"
+	headerLinkedInID = "X-LinkedIn-Id"
+)
+
+// ProxyRequest describes one LinkedIn REST call executed by Jumon on behalf of a user.
+// Method is the HTTP verb Jumon uses against LinkedIn; it defaults to GET when empty.
+// Body is forwarded as the JSON request body for write operations and omitted when nil.
+type ProxyRequest struct {
+	Method       string
+	ResourcePath string
+	Query        map[string]string
+	Headers      map[string]string
+	Body         any
+}
+
+// NewCreateRequest builds a Rest.li CREATE call (POST to the collection resource).
+func NewCreateRequest(resourcePath string, entity map[string]any) ProxyRequest {
+	return ProxyRequest{
+		Method:       http.MethodPost,
+		ResourcePath: resourcePath,
+		Headers:      map[string]string{HeaderRestLiMethod: RestLiMethodCreate},
+		Body:         entity,
+	}
+}
+
+// NewPartialUpdateRequest builds a Rest.li PARTIAL_UPDATE call (POST to the entity resource)
+// that sets the given fields and removes the listed ones. LinkedIn only touches fields present
+// in the patch document, so callers never need to read-modify-write the full entity.
+func NewPartialUpdateRequest(resourcePath string, set map[string]any, deleteFields []string) ProxyRequest {
+	return ProxyRequest{
+		Method:       http.MethodPost,
+		ResourcePath: resourcePath,
+		Headers:      map[string]string{HeaderRestLiMethod: RestLiMethodPartialUpdate},
+		Body:         map[string]any{"patch": buildPatch(set, deleteFields)},
+	}
+}
+
+// NewBatchPartialUpdateRequest builds a Rest.li BATCH_PARTIAL_UPDATE call applying the same
+// patch to every key. Keys are the raw entity IDs as they appear in the ids=List(...) parameter.
+func NewBatchPartialUpdateRequest(resourcePath string, keys []string, set map[string]any) ProxyRequest {
+	entities := make(map[string]any, len(keys))
+	for _, key := range keys {
+		entities[key] = map[string]any{"patch": buildPatch(set, nil)}
+	}
+	return ProxyRequest{
+		Method:       http.MethodPost,
+		ResourcePath: resourcePath,
+		Query:        map[string]string{"ids": "List(" + strings.Join(keys, ",") + ")"},
+		Headers:      map[string]string{HeaderRestLiMethod: RestLiMethodBatchPartialUpdate},
+		Body:         map[string]any{"entities": entities},
+	}
+}
+
+// NewDeleteRequest builds a Rest.li DELETE call against a single entity resource.
+func NewDeleteRequest(resourcePath string) ProxyRequest {
+	return ProxyRequest{
+		Method:       http.MethodDelete,
+		ResourcePath: resourcePath,
+		Headers:      map[string]string{HeaderRestLiMethod: RestLiMethodDelete},
+	}
+}
+
+// CreatedEntityID returns the ID LinkedIn assigned to an entity created via Rest.li CREATE,
+// or an empty string when the response does not carry one.
+func CreatedEntityID(response *api.Response) string {
+	if response == nil {
+		return ""
+	}
+	for key, values := range response.Headers {
+		if !strings.EqualFold(key, headerRestLiID) && !strings.EqualFold(key, headerLinkedInID) {
+			continue
+		}
+		for _, value := range values {
+			if trimmed := strings.TrimSpace(value); trimmed != "" {
+				return trimmed
+			}
+		}
+	}
+	return ""
+}
+
+func buildPatch(set map[string]any, deleteFields []string) map[string]any {
+	patch := map[string]any{}
+	if len(set) > 0 {
+		patch["$set"] = set
+	}
+	if len(deleteFields) > 0 {
+		patch["$delete"] = deleteFields
+	}
+	return patch
+}
+
+func (r ProxyRequest) method() string {
+	method := strings.ToUpper(strings.TrimSpace(r.Method))
+	if method == "" {
+		return http.MethodGet
+	}
+	return method
+}
+
+// idempotent reports whether replaying the proxied LinkedIn call cannot apply it twice. The
+// gateway call itself is always a POST, so its retry policy follows the wrapped method: reads,
+// PUT and DELETE are retried like any idempotent call, other writes only when the gateway was
+// never reached. An Idempotency-Key among the LinkedIn headers does not count: it only
+// travels inside the proxy body and LinkedIn does not de-duplicate on it.
+func (r ProxyRequest) idempotent() bool {
+	switch r.method() {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
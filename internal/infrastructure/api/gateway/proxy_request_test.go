@@ -0,0 +1,41 @@
+package gateway
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBatchPartialUpdateRequest(t *testing.T) {
+	request := NewBatchPartialUpdateRequest("adAccounts/1/adCampaigns", []string{"10", "11"}, map[string]any{"status": "ARCHIVED"})
+
+	require.Equal(t, "POST", request.method())
+	require.Equal(t, "List(10,11)", request.Query["ids"])
+	require.Equal(t, RestLiMethodBatchPartialUpdate, request.Headers[HeaderRestLiMethod])
+
+	body, ok := request.Body.(map[string]any)
+	require.True(t, ok)
+	entities, ok := body["entities"].(map[string]any)
+	require.True(t, ok)
+	require.Len(t, entities, 2)
+	require.Equal(t, map[string]any{"patch": map[string]any{"$set": map[string]any{"status": "ARCHIVED"}}}, entities["10"])
+}
+
+func TestNewPartialUpdateRequest_IncludesDeleteFields(t *testing.T) {
+	request := NewPartialUpdateRequest("adAccounts/1/adCampaigns/2", nil, []string{"totalBudget"})
+
+	body, ok := request.Body.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"$delete": []string{"totalBudget"}}, body["patch"])
+}
+
+func TestCreatedEntityID(t *testing.T) {
+	require.Equal(t, "", CreatedEntityID(nil))
+	require.Equal(t, "", CreatedEntityID(&api.Response{StatusCode: 201}))
+	require.Equal(t, "123", CreatedEntityID(&api.Response{
+		StatusCode: 201,
+		Headers:    map[string][]string{"X-Linkedin-Id": {"123"}},
+	}))
+}
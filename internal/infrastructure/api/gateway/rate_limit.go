@@ -0,0 +1,62 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// Where a throttling response came from: Jumon itself, or LinkedIn behind the proxy.
+const (
+	RateLimitSourceGateway  = "gateway"
+	RateLimitSourceLinkedIn = "linkedin"
+)
+
+// RateLimitError means the call was throttled and retries were exhausted or the server asked
+// for a longer wait than the HTTP client is willing to sleep. RetryAfter is zero when the
+// server gave no hint.
+type RateLimitError struct {
+	Source     string
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s rate limit exceeded (status %d), retry after %d seconds", e.Source, e.StatusCode, e.RetryAfterSeconds())
+	}
+	return fmt.Sprintf("%s rate limit exceeded (status %d)", e.Source, e.StatusCode)
+}
+
+// RetryAfterSeconds rounds RetryAfter up to whole seconds.
+func (e *RateLimitError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+func AsRateLimit(err error) (*RateLimitError, bool) {
+	var target *RateLimitError
+	if !errors.As(err, &target) {
+		return nil, false
+	}
+	return target, true
+}
+
+// rateLimitFromResponse recognizes throttling: any 429, and a 503 that carries a retry hint
+// (LinkedIn and Jumon both use 503 + Retry-After when shedding load).
+func rateLimitFromResponse(source string, response *api.Response, now time.Time) (*RateLimitError, bool) {
+	if response == nil {
+		return nil, false
+	}
+	retryAfter, hasHint := response.RetryAfter(now)
+	switch {
+	case response.StatusCode == http.StatusTooManyRequests:
+	case response.StatusCode == http.StatusServiceUnavailable && hasHint:
+	default:
+		return nil, false
+	}
+	return &RateLimitError{Source: source, StatusCode: response.StatusCode, RetryAfter: retryAfter}, true
+}
@@ -0,0 +1,267 @@
+// Package recording captures gateway request/response pairs into JSON fixture files and serves
+// them back deterministically. Record once against a real Jumon deployment, commit the file,
+// and replay it in tests to pin down real LinkedIn payload shapes.
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// Redacted replaces user IDs, tokens and secrets in recorded fixtures.
+const Redacted = "REDACTED"
+
+// Cassette is the on-disk fixture format: interactions in the order they were recorded.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one gateway call and the response it received.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a gateway call. Body holds the gateway payload, e.g. the proxied LinkedIn method,
+// path, query and headers; its userId is redacted. Gateway headers are never recorded since
+// they carry the internal secret.
+type Request struct {
+	Method string            `json:"method"`
+	Path   string            `json:"path"`
+	Query  map[string]string `json:"query,omitempty"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+}
+
+// Response is the recorded gateway answer. JSON bodies are kept as-is in Body so fixtures stay
+// readable and editable; anything else is stored in BodyText.
+type Response struct {
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       json.RawMessage     `json:"body,omitempty"`
+	BodyText   string              `json:"bodyText,omitempty"`
+}
+
+// Load reads a cassette file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette to path, replacing it atomically so a concurrent reader never sees
+// a partial file.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func newRequest(method, rawURL string, body any) (Request, string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Request{}, "", fmt.Errorf("invalid gateway URL: %w", err)
+	}
+	request := Request{Method: strings.ToUpper(method), Path: parsed.Path}
+
+	var userID string
+	if values := parsed.Query(); len(values) > 0 {
+		request.Query = make(map[string]string, len(values))
+		for key := range values {
+			value := values.Get(key)
+			if key == "userId" {
+				userID, value = value, Redacted
+			}
+			request.Query[key] = value
+		}
+	}
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return Request{}, "", fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		var fields map[string]any
+		if json.Unmarshal(data, &fields) == nil {
+			if id, ok := fields["userId"].(string); ok {
+				userID = id
+				fields["userId"] = Redacted
+				data, _ = json.Marshal(fields)
+			}
+		}
+		request.Body = data
+	}
+	return request, userID, nil
+}
+
+// key canonicalizes the request for replay: the gateway endpoint and query plus, for proxy
+// calls, the LinkedIn method, Rest.li method, resource path and query. Map order, header name
+// casing and surrounding slashes do not matter; the request body sent to LinkedIn does.
+func (r Request) key() string {
+	var builder strings.Builder
+	builder.WriteString(r.Method + " " + r.Path + "\n")
+	writeSortedPairs(&builder, r.Query)
+
+	var proxied struct {
+		Method  string            `json:"method"`
+		Path    string            `json:"path"`
+		Query   map[string]string `json:"query"`
+		Headers map[string]string `json:"headers"`
+		Body    json.RawMessage   `json:"body"`
+	}
+	if len(r.Body) == 0 || json.Unmarshal(r.Body, &proxied) != nil || proxied.Path == "" {
+		return builder.String()
+	}
+	builder.WriteString("\n" + strings.ToUpper(proxied.Method) + " " + strings.Trim(proxied.Path, "/") + "\n")
+	writeSortedPairs(&builder, proxied.Query)
+	for name, value := range proxied.Headers {
+		if strings.EqualFold(name, "X-RestLi-Method") {
+			builder.WriteString("\n" + strings.ToUpper(value))
+		}
+	}
+	if len(proxied.Body) > 0 {
+		var canonical any
+		if json.Unmarshal(proxied.Body, &canonical) == nil {
+			// Re-encoding sorts object keys.
+			data, _ := json.Marshal(canonical)
+			builder.WriteString("\n")
+			builder.Write(data)
+		}
+	}
+	return builder.String()
+}
+
+func writeSortedPairs(builder *strings.Builder, values map[string]string) {
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		pairs = append(pairs, strconv.Quote(key)+"="+strconv.Quote(value))
+	}
+	sort.Strings(pairs)
+	builder.WriteString(strings.Join(pairs, "&"))
+}
+
+// droppedHeaders are left out of recorded responses: credentials, and Content-Length, which no
+// longer matches once the body is re-encoded.
+var droppedHeaders = []string{"authorization", "cookie", "secret", "token", "content-length"}
+
+// sensitiveFields are JSON keys whose values are redacted in recorded response bodies.
+var sensitiveFields = map[string]bool{
+	"accesstoken":   true,
+	"access_token":  true,
+	"refreshtoken":  true,
+	"refresh_token": true,
+	"idtoken":       true,
+	"id_token":      true,
+	"clientsecret":  true,
+	"client_secret": true,
+	"userid":        true,
+	"email":         true,
+}
+
+func newResponse(response *api.Response, userID string) Response {
+	recorded := Response{StatusCode: response.StatusCode}
+	for name, values := range response.Headers {
+		if isDroppedHeader(name) {
+			continue
+		}
+		if recorded.Headers == nil {
+			recorded.Headers = map[string][]string{}
+		}
+		recorded.Headers[name] = values
+	}
+
+	if len(response.Body) == 0 {
+		return recorded
+	}
+	var decoded any
+	if json.Unmarshal(response.Body, &decoded) != nil {
+		recorded.BodyText = redactString(string(response.Body), userID)
+		return recorded
+	}
+	data, err := json.Marshal(redactValue(decoded, userID))
+	if err != nil {
+		recorded.BodyText = redactString(string(response.Body), userID)
+		return recorded
+	}
+	recorded.Body = data
+	return recorded
+}
+
+func (r Response) apiResponse() *api.Response {
+	response := &api.Response{StatusCode: r.StatusCode, Headers: map[string][]string{}}
+	for name, values := range r.Headers {
+		response.Headers[name] = append([]string(nil), values...)
+	}
+	if len(r.Body) > 0 {
+		response.Body = append([]byte(nil), r.Body...)
+	} else {
+		response.Body = []byte(r.BodyText)
+	}
+	return response
+}
+
+func isDroppedHeader(name string) bool {
+	lower := strings.ToLower(name)
+	for _, fragment := range droppedHeaders {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactValue(value any, userID string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if sensitiveFields[strings.ToLower(key)] {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redactValue(field, userID)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, userID)
+		}
+		return v
+	case string:
+		return redactString(v, userID)
+	default:
+		return v
+	}
+}
+
+func redactString(value, userID string) string {
+	if userID == "" {
+		return value
+	}
+	return strings.ReplaceAll(value, userID, Redacted)
+}
@@ -0,0 +1,86 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"sync"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// Recorder is an [api.Client] decorator that forwards every call and appends the redacted
+// request/response pair to a cassette file. Calls that fail before a response arrives are not
+// recorded. Writing the file is best effort: a recording problem never fails the call.
+type Recorder struct {
+	next api.Client
+	path string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder records calls made through next into the cassette at path, appending to the
+// interactions already stored there.
+func NewRecorder(next api.Client, path string) (*Recorder, error) {
+	recorder := &Recorder{next: next, path: path}
+	existing, err := Load(path)
+	switch {
+	case err == nil:
+		recorder.cassette = *existing
+	case !errors.Is(err, fs.ErrNotExist):
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func (r *Recorder) Get(ctx context.Context, url string, headers map[string]string) (*api.Response, error) {
+	return r.record(http.MethodGet, url, nil, func() (*api.Response, error) {
+		return r.next.Get(ctx, url, headers)
+	})
+}
+
+func (r *Recorder) Post(ctx context.Context, url string, body interface{}, headers map[string]string) (*api.Response, error) {
+	return r.record(http.MethodPost, url, body, func() (*api.Response, error) {
+		return r.next.Post(ctx, url, body, headers)
+	})
+}
+
+func (r *Recorder) Put(ctx context.Context, url string, body interface{}, headers map[string]string) (*api.Response, error) {
+	return r.record(http.MethodPut, url, body, func() (*api.Response, error) {
+		return r.next.Put(ctx, url, body, headers)
+	})
+}
+
+func (r *Recorder) Delete(ctx context.Context, url string, headers map[string]string) (*api.Response, error) {
+	return r.record(http.MethodDelete, url, nil, func() (*api.Response, error) {
+		return r.next.Delete(ctx, url, headers)
+	})
+}
+
+func (r *Recorder) Patch(ctx context.Context, url string, body interface{}, headers map[string]string) (*api.Response, error) {
+	return r.record(http.MethodPatch, url, body, func() (*api.Response, error) {
+		return r.next.Patch(ctx, url, body, headers)
+	})
+}
+
+func (r *Recorder) record(method, url string, body any, call func() (*api.Response, error)) (*api.Response, error) {
+	response, err := call()
+	if err != nil || response == nil {
+		return response, err
+	}
+	request, userID, requestErr := newRequest(method, url, body)
+	if requestErr != nil {
+		return response, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  request,
+		Response: newResponse(response, userID),
+	})
+	_ = r.cassette.Save(r.path)
+	return response, nil
+}
@@ -0,0 +1,138 @@
+package recording
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/api/gateway/fakegateway"
+	customhttp "linkedin-mcp/internal/infrastructure/http"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "user_2xYzSecretish"
+
+func recordAgainstFake(t *testing.T) (string, *fakegateway.Server) {
+	t.Helper()
+	fake := fakegateway.New("prod-secret")
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(customhttp.NewClient(nil), path)
+	require.NoError(t, err)
+	client := gateway.NewClient(recorder, server.URL, "prod-secret")
+
+	ctx := context.Background()
+	_, err = client.GetLinkedInConnection(ctx, testUserID)
+	require.NoError(t, err)
+	_, err = client.ProxyLinkedIn(ctx, testUserID, "adAccounts", map[string]string{"q": "search", "count": "10"}, map[string]string{
+		gateway.HeaderRestLiMethod: gateway.RestLiMethodFinder,
+	})
+	require.NoError(t, err)
+	return path, fake
+}
+
+func TestRecorder_WritesRedactedCassette(t *testing.T) {
+	path, _ := recordAgainstFake(t)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), testUserID)
+	require.NotContains(t, string(data), "prod-secret")
+	require.Contains(t, string(data), Redacted)
+
+	cassette, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 2)
+	require.Equal(t, "/api/internal/connections/linkedin/current", cassette.Interactions[0].Request.Path)
+	require.Equal(t, Redacted, cassette.Interactions[0].Request.Query["userId"])
+	proxy := cassette.Interactions[1]
+	require.Equal(t, "/api/internal/providers/linkedin/proxy", proxy.Request.Path)
+	require.Equal(t, 200, proxy.Response.StatusCode)
+	require.Contains(t, string(proxy.Response.Body), "Acme Demo Account")
+}
+
+func TestRecorder_AppendsToExistingCassette(t *testing.T) {
+	path, fake := recordAgainstFake(t)
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	recorder, err := NewRecorder(customhttp.NewClient(nil), path)
+	require.NoError(t, err)
+	_, err = gateway.NewClient(recorder, server.URL, "prod-secret").GetLinkedInConnection(context.Background(), testUserID)
+	require.NoError(t, err)
+
+	cassette, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 3)
+}
+
+func TestReplayer_ServesRecordingForAnyUserAndQueryOrder(t *testing.T) {
+	path, fake := recordAgainstFake(t)
+	recordedCalls := len(fake.Requests())
+
+	replayer, err := NewReplayerFromFile(path)
+	require.NoError(t, err)
+	client := gateway.NewClient(replayer, "http://gateway.invalid", "other-secret")
+
+	connection, err := client.GetLinkedInConnection(context.Background(), "user_other")
+	require.NoError(t, err)
+	require.Equal(t, 200, connection.StatusCode)
+
+	response, err := client.ProxyLinkedIn(context.Background(), "user_other", "/adAccounts/", map[string]string{"count": "10", "q": "search"}, map[string]string{
+		"x-restli-method": gateway.RestLiMethodFinder,
+	})
+	require.NoError(t, err)
+	require.Contains(t, string(response.Body), "Acme Demo Account")
+	require.Equal(t, recordedCalls, len(fake.Requests()))
+}
+
+func TestReplayer_UnmatchedRequestFails(t *testing.T) {
+	path, _ := recordAgainstFake(t)
+	replayer, err := NewReplayerFromFile(path)
+	require.NoError(t, err)
+
+	_, err = gateway.NewClient(replayer, "http://gateway.invalid", "").ProxyLinkedIn(context.Background(), testUserID, "adAccounts", map[string]string{"q": "search", "count": "20"}, nil)
+
+	require.ErrorIs(t, err, ErrNoRecording)
+	require.Contains(t, err.Error(), "LinkedIn GET adAccounts")
+}
+
+func TestReplayer_ServesRepeatedInteractionsInOrder(t *testing.T) {
+	request := Request{Method: "GET", Path: "/api/internal/connections/linkedin/current", Query: map[string]string{"userId": Redacted}}
+	replayer := NewReplayer(&Cassette{Interactions: []Interaction{
+		{Request: request, Response: Response{StatusCode: 503, BodyText: "unavailable"}},
+		{Request: request, Response: Response{StatusCode: 200, Body: []byte(`{"connected":true}`)}},
+	}})
+	client := gateway.NewClient(replayer, "http://gateway.invalid", "")
+
+	statuses := make([]int, 0, 3)
+	for range 3 {
+		response, err := client.GetLinkedInConnection(context.Background(), testUserID)
+		require.NoError(t, err)
+		statuses = append(statuses, response.StatusCode)
+	}
+
+	require.Equal(t, []int{503, 200, 200}, statuses)
+}
+
+func TestNewResponse_RedactsCredentials(t *testing.T) {
+	recorded := newResponse(&api.Response{
+		StatusCode: 200,
+		Headers: map[string][]string{
+			"Set-Cookie":     {"session=abc"},
+			"Content-Length": {"120"},
+			"X-RestLi-Id":    {"700000009"},
+		},
+		Body: []byte(`{"accessToken":"AQV-live","owner":"user_42","metadata":{"nextPageToken":"DgEA"},"notes":["created by user_42"]}`),
+	}, "user_42")
+
+	require.Equal(t, map[string][]string{"X-RestLi-Id": {"700000009"}}, recorded.Headers)
+	require.JSONEq(t, `{"accessToken":"REDACTED","owner":"REDACTED","metadata":{"nextPageToken":"DgEA"},"notes":["created by REDACTED"]}`, string(recorded.Body))
+}
@@ -0,0 +1,96 @@
+package recording
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"linkedin-mcp/internal/infrastructure/api"
+)
+
+// ErrNoRecording is returned by [Replayer] for a call that matches no recorded interaction.
+var ErrNoRecording = errors.New("no recorded gateway response")
+
+// Replayer is an [api.Client] that serves recorded interactions instead of calling the
+// gateway. Calls are matched on the canonical request, ignoring the user ID. Interactions
+// recorded for the same request are served in recording order, and the last one repeats once
+// they are used up, so replays are deterministic however often a call is made.
+type Replayer struct {
+	mu     sync.Mutex
+	byKey  map[string][]Response
+	served map[string]int
+}
+
+// NewReplayer serves the interactions of cassette.
+func NewReplayer(cassette *Cassette) *Replayer {
+	replayer := &Replayer{byKey: map[string][]Response{}, served: map[string]int{}}
+	for _, interaction := range cassette.Interactions {
+		key := interaction.Request.key()
+		replayer.byKey[key] = append(replayer.byKey[key], interaction.Response)
+	}
+	return replayer
+}
+
+// NewReplayerFromFile loads the cassette at path and serves it.
+func NewReplayerFromFile(path string) (*Replayer, error) {
+	cassette, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(cassette), nil
+}
+
+func (r *Replayer) Get(ctx context.Context, url string, headers map[string]string) (*api.Response, error) {
+	return r.replay(http.MethodGet, url, nil)
+}
+
+func (r *Replayer) Post(ctx context.Context, url string, body interface{}, headers map[string]string) (*api.Response, error) {
+	return r.replay(http.MethodPost, url, body)
+}
+
+func (r *Replayer) Put(ctx context.Context, url string, body interface{}, headers map[string]string) (*api.Response, error) {
+	return r.replay(http.MethodPut, url, body)
+}
+
+func (r *Replayer) Delete(ctx context.Context, url string, headers map[string]string) (*api.Response, error) {
+	return r.replay(http.MethodDelete, url, nil)
+}
+
+func (r *Replayer) Patch(ctx context.Context, url string, body interface{}, headers map[string]string) (*api.Response, error) {
+	return r.replay(http.MethodPatch, url, body)
+}
+
+func (r *Replayer) replay(method, url string, body any) (*api.Response, error) {
+	request, _, err := newRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	key := request.key()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	responses := r.byKey[key]
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoRecording, method, describe(request))
+	}
+	index := min(r.served[key], len(responses)-1)
+	r.served[key]++
+	return responses[index].apiResponse(), nil
+}
+
+func describe(request Request) string {
+	var proxied struct {
+		Method string `json:"method"`
+		Path   string `json:"path"`
+	}
+	if len(request.Body) > 0 {
+		_ = json.Unmarshal(request.Body, &proxied)
+	}
+	if proxied.Path == "" {
+		return request.Path
+	}
+	return fmt.Sprintf("%s (LinkedIn %s %s)", request.Path, proxied.Method, proxied.Path)
+}
@@ -20,6 +20,8 @@ type LinkedInParamValidationError struct {
 	Message        string
 	ProviderStatus int
 	InputErrors    []LinkedInInputError
+	// ProviderPayload is the raw provider response body, for diagnostics.
+	ProviderPayload string
 }
 
 func (e *LinkedInParamValidationError) Error() string {
@@ -92,9 +94,10 @@ func ParseLinkedInParamValidationResponse(response *api.Response) (*LinkedInPara
 	}
 
 	return &LinkedInParamValidationError{
-		Message:        trimmedMessage,
-		ProviderStatus: response.StatusCode,
-		InputErrors:    inputErrors,
+		Message:         trimmedMessage,
+		ProviderStatus:  response.StatusCode,
+		InputErrors:     inputErrors,
+		ProviderPayload: strings.TrimSpace(string(response.Body)),
 	}, true
 }
 
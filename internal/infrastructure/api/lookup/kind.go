@@ -0,0 +1,70 @@
+package lookup
+
+import (
+	"sort"
+	"strings"
+)
+
+// Kind identifies a LinkedIn reference entity whose URNs can be resolved to a display name.
+// Organizations come from organizationsLookup; the other kinds are Standardized Data
+// taxonomies that back the MEMBER_* demographic pivots of adAnalytics.
+// Reference: https://learn.microsoft.com/en-us/linkedin/shared/references/v2/standardized-data
+type Kind string
+
+const (
+	KindOrganization Kind = "organization"
+	KindIndustry     Kind = "industry"
+	KindSeniority    Kind = "seniority"
+	KindFunction     Kind = "function"
+	KindTitle        Kind = "title"
+	KindGeo          Kind = "geo"
+)
+
+// kindResources maps every kind to its BATCH_GET collection path under the REST base URL.
+var kindResources = map[Kind]string{
+	KindOrganization: "organizationsLookup",
+	KindIndustry:     "industries",
+	KindSeniority:    "seniorities",
+	KindFunction:     "functions",
+	KindTitle:        "titles",
+	KindGeo:          "geo",
+}
+
+// KindForURNType returns the lookup kind for the entity type segment of a URN
+// (urn:li:{type}:{id}), or false when the type cannot be resolved here.
+func KindForURNType(urnType string) (Kind, bool) {
+	kind := Kind(urnType)
+	_, ok := kindResources[kind]
+	return kind, ok
+}
+
+// entityLabel extracts the display name from a lookup entity. Organizations carry
+// localizedName, geo entities defaultLocalizedName.value and the remaining taxonomies a
+// multi-locale name.localized map, where en_US is preferred.
+func entityLabel(entity map[string]any) string {
+	if name, ok := entity["localizedName"].(string); ok && strings.TrimSpace(name) != "" {
+		return strings.TrimSpace(name)
+	}
+	if name, ok := entity["defaultLocalizedName"].(map[string]any); ok {
+		if value, ok := name["value"].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	name, _ := entity["name"].(map[string]any)
+	localized, _ := name["localized"].(map[string]any)
+	if value, ok := localized["en_US"].(string); ok && strings.TrimSpace(value) != "" {
+		return strings.TrimSpace(value)
+	}
+
+	locales := make([]string, 0, len(localized))
+	for locale := range localized {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	for _, locale := range locales {
+		if value, ok := localized[locale].(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
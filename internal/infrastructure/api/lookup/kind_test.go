@@ -0,0 +1,30 @@
+package lookup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindForURNType(t *testing.T) {
+	kind, ok := KindForURNType("seniority")
+	require.True(t, ok)
+	require.Equal(t, KindSeniority, kind)
+
+	_, ok = KindForURNType("sponsoredCampaign")
+	require.False(t, ok)
+}
+
+func TestEntityLabel(t *testing.T) {
+	require.Equal(t, "Acme", entityLabel(map[string]any{"localizedName": "Acme"}))
+	require.Equal(t, "Germany", entityLabel(map[string]any{
+		"defaultLocalizedName": map[string]any{"locale": map[string]any{}, "value": "Germany"},
+	}))
+	require.Equal(t, "Senior", entityLabel(map[string]any{
+		"name": map[string]any{"localized": map[string]any{"de_DE": "Senior (DE)", "en_US": "Senior"}},
+	}))
+	require.Equal(t, "Ingénieur", entityLabel(map[string]any{
+		"name": map[string]any{"localized": map[string]any{"fr_FR": "Ingénieur"}},
+	}))
+	require.Empty(t, entityLabel(map[string]any{"id": float64(1)}))
+}
@@ -0,0 +1,23 @@
+package lookup
+
+import (
+	"fmt"
+	"strings"
+)
+
+type QueryBuilder struct {
+	baseURL string
+}
+
+func NewQueryBuilder(baseURL string) *QueryBuilder {
+	return &QueryBuilder{baseURL: baseURL}
+}
+
+// BuildCollectionQuery builds the collection URL of a lookup kind, used for BATCH_GET.
+func (qb *QueryBuilder) BuildCollectionQuery(kind Kind) (string, error) {
+	resource, ok := kindResources[kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported lookup kind %q", kind)
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimRight(qb.baseURL, "/"), resource), nil
+}
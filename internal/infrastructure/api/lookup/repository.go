@@ -0,0 +1,44 @@
+package lookup
+
+import (
+	"context"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+)
+
+type Repository struct {
+	executor     *gateway.Executor
+	queryBuilder *QueryBuilder
+}
+
+func NewRepository(executor *gateway.Executor, queryBuilder *QueryBuilder) *Repository {
+	return &Repository{
+		executor:     executor,
+		queryBuilder: queryBuilder,
+	}
+}
+
+// GetLabels resolves numeric IDs of one lookup kind to display names with a single BATCH_GET.
+// IDs LinkedIn does not return, or returns without a name, are absent from the result.
+func (r *Repository) GetLabels(ctx context.Context, kind Kind, ids []string) (map[string]string, error) {
+	labels := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return labels, nil
+	}
+
+	requestURL, err := r.queryBuilder.BuildCollectionQuery(kind)
+	if err != nil {
+		return nil, err
+	}
+	batch, err := r.executor.BatchGet(ctx, requestURL, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if label := entityLabel(batch.Results[id]); label != "" {
+			labels[id] = label
+		}
+	}
+	return labels, nil
+}
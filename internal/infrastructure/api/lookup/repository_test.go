@@ -0,0 +1,64 @@
+package lookup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLabels_BatchGetsKindCollection(t *testing.T) {
+	provider := &fakeProvider{body: `{
+		"results": {
+			"103644278": {"defaultLocalizedName": {"value": "United States"}},
+			"101282230": {"id": 101282230}
+		},
+		"statuses": {"999": 404},
+		"errors": {}
+	}`}
+	repository := NewRepository(gateway.NewExecutor(provider, nil), NewQueryBuilder("https://api.linkedin.com/rest"))
+
+	labels, err := repository.GetLabels(middleware.ContextWithUserID(context.Background(), "user_1"), KindGeo, []string{"103644278", "101282230", "999"})
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"103644278": "United States"}, labels)
+	require.Len(t, provider.requests, 1)
+	require.Equal(t, "geo", provider.requests[0].ResourcePath)
+	require.Equal(t, "List(103644278,101282230,999)", provider.requests[0].Query["ids"])
+}
+
+func TestGetLabels_ReturnsLinkedInError(t *testing.T) {
+	provider := &fakeProvider{status: http.StatusForbidden, body: `{"status":403,"message":"Not enough permissions"}`}
+	repository := NewRepository(gateway.NewExecutor(provider, nil), NewQueryBuilder("https://api.linkedin.com/rest"))
+
+	_, err := repository.GetLabels(middleware.ContextWithUserID(context.Background(), "user_1"), KindTitle, []string{"1"})
+
+	var apiErr *gateway.LinkedInAPIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+}
+
+// fakeProvider is a connected [gateway.Provider] answering every proxied call with body.
+type fakeProvider struct {
+	status   int
+	body     string
+	requests []gateway.ProxyRequest
+}
+
+func (f *fakeProvider) GetLinkedInConnection(context.Context, string) (*api.Response, error) {
+	return &api.Response{StatusCode: http.StatusOK, Body: []byte(`{"connected":true}`)}, nil
+}
+
+func (f *fakeProvider) ProxyLinkedInRequestOrRefresh(_ context.Context, _ string, request gateway.ProxyRequest) (*api.Response, error) {
+	f.requests = append(f.requests, request)
+	status := f.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &api.Response{StatusCode: status, Body: []byte(f.body)}, nil
+}
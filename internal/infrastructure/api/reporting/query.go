@@ -1,8 +1,13 @@
 package reporting
 
+// AnalyticsInput describes one adAnalytics finder call. Setting Pivots switches the request
+// to the q=statistics finder, which accepts up to three pivots and returns one pivotValues
+// entry per pivot; Pivot is ignored then. Start and Count page through the finder and are
+// omitted from the request when zero.
 type AnalyticsInput struct {
 	AccountID       string    `json:"accountID"`
 	Pivot           string    `json:"pivot,omitempty"`
+	Pivots          []string  `json:"pivots,omitempty"`
 	DateRange       DateRange `json:"dateRange"`
 	TimeGranularity string    `json:"timeGranularity"`
 	CampaignType    string    `json:"campaignType,omitempty"`
@@ -13,6 +18,8 @@ type AnalyticsInput struct {
 	Companies       []string  `json:"companies,omitempty"`
 	SortBy          SortBy    `json:"sortBy,omitempty"`
 	Fields          []string  `json:"fields"`
+	Start           int       `json:"start,omitempty"`
+	Count           int       `json:"count,omitempty"`
 }
 
 type DateRange struct {
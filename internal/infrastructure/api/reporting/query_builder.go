@@ -25,12 +25,17 @@ func (qb *QueryBuilder) BuildAnalyticsQuery(input AnalyticsInput) string {
 func (qb *QueryBuilder) buildQueryParams(input AnalyticsInput) string {
 	var params []string
 
-	// Required parameters
-	params = append(params, "q=analytics")
-
-	// Pivot parameter as plain enum symbol.
-	if input.Pivot != "" {
-		params = append(params, fmt.Sprintf("pivot=%s", strings.TrimSpace(input.Pivot)))
+	// Required parameters. The statistics finder takes a pivots list (up to three), the
+	// analytics finder a single pivot enum symbol.
+	pivots := cleanPivots(input.Pivots)
+	if len(pivots) > 0 {
+		params = append(params, "q=statistics")
+		params = append(params, fmt.Sprintf("pivots=List(%s)", strings.Join(pivots, ",")))
+	} else {
+		params = append(params, "q=analytics")
+		if input.Pivot != "" {
+			params = append(params, fmt.Sprintf("pivot=%s", strings.TrimSpace(input.Pivot)))
+		}
 	}
 
 	// Date range as RestLi tuple syntax required by LinkedIn analytics finder.
@@ -89,13 +94,24 @@ func (qb *QueryBuilder) buildQueryParams(input AnalyticsInput) string {
 	// `dateRange` (time-bucketed responses) and `pivotValues` (pivoted responses)
 	// is only returned when the caller lists it explicitly. We inject those
 	// transparently so the tool always returns bucket/pivot labels when applicable.
-	fields := augmentFieldsProjection(input.Fields, input.TimeGranularity, input.Pivot)
+	pivot := input.Pivot
+	if len(pivots) > 0 {
+		pivot = strings.Join(pivots, ",")
+	}
+	fields := augmentFieldsProjection(input.Fields, input.TimeGranularity, pivot)
 
 	if len(fields) > 0 {
 		fieldsList := strings.Join(fields, ",")
 		params = append(params, fmt.Sprintf("fields=%s", fieldsList))
 	}
 
+	if input.Start > 0 {
+		params = append(params, fmt.Sprintf("start=%d", input.Start))
+	}
+	if input.Count > 0 {
+		params = append(params, fmt.Sprintf("count=%d", input.Count))
+	}
+
 	return strings.Join(params, "&")
 }
 
@@ -137,6 +153,16 @@ func augmentFieldsProjection(requested []string, timeGranularity, pivot string)
 	return out
 }
 
+func cleanPivots(pivots []string) []string {
+	cleaned := make([]string, 0, len(pivots))
+	for _, pivot := range pivots {
+		if trimmed := strings.TrimSpace(pivot); trimmed != "" {
+			cleaned = append(cleaned, trimmed)
+		}
+	}
+	return cleaned
+}
+
 func (qb *QueryBuilder) buildListParam(items []string) string {
 	encoded := make([]string, 0, len(items))
 	for _, item := range items {
@@ -286,3 +286,48 @@ func TestBuildAnalyticsQuery_MergesDefaultAndExplicitAccountsIntoSingleFacet(t *
 		t.Fatalf("expected a single accounts facet, got query: %s", query)
 	}
 }
+
+func TestBuildAnalyticsQuery_AddsPagingOnlyWhenSet(t *testing.T) {
+	qb := NewQueryBuilder("https://api.linkedin.com/rest")
+	input := AnalyticsInput{
+		AccountID:       "512247261",
+		DateRange:       DateRange{Start: Date{Year: 2026, Month: 1, Day: 1}},
+		TimeGranularity: "ALL",
+		Fields:          []string{"impressions"},
+	}
+
+	query := qb.BuildAnalyticsQuery(input)
+	if strings.Contains(query, "start=") || strings.Contains(query, "count=") {
+		t.Fatalf("expected no paging params by default, got query: %s", query)
+	}
+
+	input.Start = 1000
+	input.Count = 1000
+	query = qb.BuildAnalyticsQuery(input)
+	if !strings.HasSuffix(query, "&start=1000&count=1000") {
+		t.Fatalf("expected start and count params, got query: %s", query)
+	}
+}
+
+func TestBuildAnalyticsQuery_UsesStatisticsFinderForPivots(t *testing.T) {
+	qb := NewQueryBuilder("https://api.linkedin.com/rest")
+
+	query := qb.BuildAnalyticsQuery(AnalyticsInput{
+		AccountID:       "512247261",
+		Pivot:           "ACCOUNT",
+		Pivots:          []string{"CAMPAIGN", " MEMBER_SENIORITY "},
+		DateRange:       DateRange{Start: Date{Year: 2026, Month: 1, Day: 1}},
+		TimeGranularity: "ALL",
+		Fields:          []string{"impressions"},
+	})
+
+	if !strings.Contains(query, "?q=statistics&pivots=List(CAMPAIGN,MEMBER_SENIORITY)&") {
+		t.Fatalf("expected statistics finder with pivots list, got query: %s", query)
+	}
+	if strings.Contains(query, "q=analytics") || strings.Contains(query, "pivot=") {
+		t.Fatalf("expected no analytics finder or single pivot param, got query: %s", query)
+	}
+	if !strings.Contains(query, "fields=impressions,pivotValues") {
+		t.Fatalf("expected pivotValues projection for pivots, got query: %s", query)
+	}
+}
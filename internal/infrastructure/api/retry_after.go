@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// epochThreshold separates X-RateLimit-Reset values that are Unix timestamps from those that
+// are a number of seconds to wait; no server asks callers to wait 30+ years.
+const epochThreshold = 1_000_000_000
+
+// RetryAfter reports how long the server asked the caller to wait before retrying, read from
+// Retry-After (delay seconds or an HTTP-date) and, failing that, from the RateLimit-Reset and
+// X-RateLimit-Reset rate-limit headers. now anchors absolute dates.
+func (r *Response) RetryAfter(now time.Time) (time.Duration, bool) {
+	if r == nil {
+		return 0, false
+	}
+	if value := r.header("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return nonNegative(date.Sub(now)), true
+		}
+	}
+	if value := r.header("RateLimit-Reset"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if value := r.header("X-RateLimit-Reset"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			if seconds >= epochThreshold {
+				return nonNegative(time.Unix(seconds, 0).Sub(now)), true
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+func (r *Response) header(name string) string {
+	for key, values := range r.Headers {
+		if strings.EqualFold(key, name) && len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+	}
+	return ""
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseRetryAfter(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		headers  map[string][]string
+		expected time.Duration
+		ok       bool
+	}{
+		"seconds":             {headers: map[string][]string{"Retry-After": {"30"}}, expected: 30 * time.Second, ok: true},
+		"http date":           {headers: map[string][]string{"Retry-After": {"Sun, 01 Mar 2026 12:01:30 GMT"}}, expected: 90 * time.Second, ok: true},
+		"past date":           {headers: map[string][]string{"Retry-After": {"Sun, 01 Mar 2026 11:00:00 GMT"}}, expected: 0, ok: true},
+		"lower case key":      {headers: map[string][]string{"retry-after": {"5"}}, expected: 5 * time.Second, ok: true},
+		"ratelimit reset":     {headers: map[string][]string{"RateLimit-Reset": {"12"}}, expected: 12 * time.Second, ok: true},
+		"x-ratelimit delta":   {headers: map[string][]string{"X-RateLimit-Reset": {"7"}}, expected: 7 * time.Second, ok: true},
+		"x-ratelimit epoch":   {headers: map[string][]string{"X-RateLimit-Reset": {"1772366460"}}, expected: time.Minute, ok: true},
+		"retry-after wins":    {headers: map[string][]string{"Retry-After": {"3"}, "RateLimit-Reset": {"60"}}, expected: 3 * time.Second, ok: true},
+		"invalid retry-after": {headers: map[string][]string{"Retry-After": {"soon"}}},
+		"negative seconds":    {headers: map[string][]string{"Retry-After": {"-5"}}},
+		"missing":             {headers: map[string][]string{"Content-Type": {"application/json"}}},
+	}
+
+	for name, tc := range cases {
+		response := &Response{StatusCode: 429, Headers: tc.headers}
+		delay, ok := response.RetryAfter(now)
+		require.Equal(t, tc.ok, ok, name)
+		require.Equal(t, tc.expected, delay, name)
+	}
+
+	var missing *Response
+	_, ok := missing.RetryAfter(now)
+	require.False(t, ok)
+}
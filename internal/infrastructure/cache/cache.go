@@ -0,0 +1,16 @@
+// Package cache provides byte-oriented response caches with per-entry TTLs and a total size
+// bound. Entries live in namespaces (e.g. one per user) so a namespace can be purged at once.
+package cache
+
+import "time"
+
+// Backend stores cached values. Implementations are safe for concurrent use.
+type Backend interface {
+	// Get returns the value stored under namespace and key when it has not expired.
+	Get(namespace, key string) ([]byte, bool)
+	// Set stores value for ttl, evicting older entries when the size bound is exceeded.
+	// Values larger than the bound are not stored.
+	Set(namespace, key string, value []byte, ttl time.Duration)
+	// Purge drops every entry of namespace.
+	Purge(namespace string)
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// backendCase exercises a Backend with a controllable clock.
+type backendCase struct {
+	name    string
+	backend Backend
+	advance func(time.Duration)
+}
+
+func backends(t *testing.T, maxBytes int64) []backendCase {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	memory := NewMemory(maxBytes)
+	memoryNow := now
+	memory.now = func() time.Time { return memoryNow }
+
+	disk, err := NewDisk(t.TempDir(), maxBytes+expiryHeaderSize*2)
+	require.NoError(t, err)
+	diskNow := now
+	disk.now = func() time.Time { return diskNow }
+
+	return []backendCase{
+		{name: "memory", backend: memory, advance: func(d time.Duration) { memoryNow = memoryNow.Add(d) }},
+		{name: "disk", backend: disk, advance: func(d time.Duration) { diskNow = diskNow.Add(d) }},
+	}
+}
+
+func TestBackend_SetGetAndExpiry(t *testing.T) {
+	for _, tc := range backends(t, 1024) {
+		tc.backend.Set("user_1", "k", []byte("value"), time.Minute)
+
+		value, ok := tc.backend.Get("user_1", "k")
+		require.True(t, ok, tc.name)
+		require.Equal(t, []byte("value"), value, tc.name)
+
+		_, ok = tc.backend.Get("user_2", "k")
+		require.False(t, ok, tc.name)
+
+		tc.advance(time.Minute)
+		_, ok = tc.backend.Get("user_1", "k")
+		require.False(t, ok, tc.name)
+	}
+}
+
+func TestBackend_Purge(t *testing.T) {
+	for _, tc := range backends(t, 1024) {
+		tc.backend.Set("user_1", "a", []byte("1"), time.Minute)
+		tc.backend.Set("user_1", "b", []byte("2"), time.Minute)
+		tc.backend.Set("user_2", "a", []byte("3"), time.Minute)
+
+		tc.backend.Purge("user_1")
+
+		_, ok := tc.backend.Get("user_1", "a")
+		require.False(t, ok, tc.name)
+		_, ok = tc.backend.Get("user_1", "b")
+		require.False(t, ok, tc.name)
+		value, ok := tc.backend.Get("user_2", "a")
+		require.True(t, ok, tc.name)
+		require.Equal(t, []byte("3"), value, tc.name)
+	}
+}
+
+func TestBackend_SizeBound(t *testing.T) {
+	for _, tc := range backends(t, 10) {
+		tc.backend.Set("user_1", "a", []byte("12345"), time.Minute)
+		tc.advance(time.Second)
+		tc.backend.Set("user_1", "b", []byte("12345"), time.Minute)
+		tc.advance(time.Second)
+		tc.backend.Set("user_1", "c", []byte("12345"), time.Minute)
+
+		_, ok := tc.backend.Get("user_1", "a")
+		require.False(t, ok, "%s: oldest entry evicted", tc.name)
+		_, ok = tc.backend.Get("user_1", "c")
+		require.True(t, ok, tc.name)
+
+		tc.backend.Set("user_1", "huge", make([]byte, 1024), time.Minute)
+		_, ok = tc.backend.Get("user_1", "huge")
+		require.False(t, ok, "%s: values above the bound are not stored", tc.name)
+	}
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	memory := NewMemory(10)
+	memory.Set("u", "a", []byte("12345"), time.Minute)
+	memory.Set("u", "b", []byte("12345"), time.Minute)
+	_, _ = memory.Get("u", "a")
+	memory.Set("u", "c", []byte("12345"), time.Minute)
+
+	_, ok := memory.Get("u", "a")
+	require.True(t, ok)
+	_, ok = memory.Get("u", "b")
+	require.False(t, ok)
+}
+
+func TestDisk_SurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	disk, err := NewDisk(dir, 1024)
+	require.NoError(t, err)
+	disk.Set("user_1", "k", []byte("value"), time.Hour)
+
+	reopened, err := NewDisk(dir, 1024)
+	require.NoError(t, err)
+	value, ok := reopened.Get("user_1", "k")
+	require.True(t, ok)
+	require.Equal(t, []byte("value"), value)
+	require.Equal(t, int64(len("value")+expiryHeaderSize), reopened.size)
+}
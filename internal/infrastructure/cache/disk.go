@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// expiryHeaderSize is the length of the big-endian Unix nanosecond expiry that prefixes
+// every cache file.
+const expiryHeaderSize = 8
+
+// Disk stores entries as files under dir, one directory per namespace, so cached responses
+// survive restarts. When the total size exceeds maxBytes the least recently written files
+// are removed.
+type Disk struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+	size     int64
+	now      func() time.Time
+}
+
+// NewDisk creates dir if needed and accounts for the entries already in it.
+func NewDisk(dir string, maxBytes int64) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	d := &Disk{dir: dir, maxBytes: maxBytes, now: time.Now}
+	files, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		d.size += file.size
+	}
+	return d, nil
+}
+
+func (d *Disk) Get(namespace, key string) ([]byte, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path := d.path(namespace, key)
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) < expiryHeaderSize {
+		return nil, false
+	}
+	expiresAt := time.Unix(0, int64(binary.BigEndian.Uint64(data[:expiryHeaderSize])))
+	if !d.now().Before(expiresAt) {
+		d.removeLocked(path, int64(len(data)))
+		return nil, false
+	}
+	return data[expiryHeaderSize:], true
+}
+
+func (d *Disk) Set(namespace, key string, value []byte, ttl time.Duration) {
+	size := int64(len(value) + expiryHeaderSize)
+	if ttl <= 0 || size > d.maxBytes {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path := d.path(namespace, key)
+	if info, err := os.Stat(path); err == nil {
+		d.removeLocked(path, info.Size())
+	}
+	if d.size+size > d.maxBytes {
+		d.evictLocked(d.size + size - d.maxBytes)
+	}
+
+	data := make([]byte, expiryHeaderSize, size)
+	binary.BigEndian.PutUint64(data, uint64(d.now().Add(ttl).UnixNano()))
+	data = append(data, value...)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	// Write to a temporary file and rename so readers never see a partial entry.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return
+	}
+	// The modification time orders eviction; stamp it with the cache clock.
+	now := d.now()
+	_ = os.Chtimes(path, now, now)
+	d.size += size
+}
+
+func (d *Disk) Purge(namespace string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	dir := filepath.Join(d.dir, hashName(namespace))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil {
+			d.removeLocked(filepath.Join(dir, entry.Name()), info.Size())
+		}
+	}
+	_ = os.Remove(dir)
+}
+
+type diskFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (d *Disk) files() ([]diskFile, error) {
+	var files []diskFile
+	err := filepath.WalkDir(d.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		files = append(files, diskFile{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan cache directory: %w", err)
+	}
+	return files, nil
+}
+
+// evictLocked removes the oldest files until at least need bytes are freed.
+func (d *Disk) evictLocked(need int64) {
+	files, err := d.files()
+	if err != nil {
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, file := range files {
+		if need <= 0 {
+			return
+		}
+		d.removeLocked(file.path, file.size)
+		need -= file.size
+	}
+}
+
+func (d *Disk) removeLocked(path string, size int64) {
+	if err := os.Remove(path); err == nil {
+		d.size -= size
+	}
+}
+
+// path hashes namespace and key so arbitrary strings map to safe file names.
+func (d *Disk) path(namespace, key string) string {
+	return filepath.Join(d.dir, hashName(namespace), hashName(key))
+}
+
+func hashName(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	namespace string
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// Memory is an in-process LRU cache bounded by the total size of its values.
+type Memory struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	lru      *list.List
+	entries  map[string]map[string]*list.Element
+	now      func() time.Time
+}
+
+func NewMemory(maxBytes int64) *Memory {
+	return &Memory{
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  map[string]map[string]*list.Element{},
+		now:      time.Now,
+	}
+}
+
+func (m *Memory) Get(namespace, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[namespace][key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.removeLocked(element)
+		return nil, false
+	}
+	m.lru.MoveToFront(element)
+	return entry.value, true
+}
+
+func (m *Memory) Set(namespace, key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 || int64(len(value)) > m.maxBytes {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[namespace][key]; ok {
+		m.removeLocked(element)
+	}
+	for m.size+int64(len(value)) > m.maxBytes && m.lru.Len() > 0 {
+		m.removeLocked(m.lru.Back())
+	}
+
+	element := m.lru.PushFront(&memoryEntry{
+		namespace: namespace,
+		key:       key,
+		value:     value,
+		expiresAt: m.now().Add(ttl),
+	})
+	if m.entries[namespace] == nil {
+		m.entries[namespace] = map[string]*list.Element{}
+	}
+	m.entries[namespace][key] = element
+	m.size += int64(len(value))
+}
+
+func (m *Memory) Purge(namespace string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, element := range m.entries[namespace] {
+		m.removeLocked(element)
+	}
+}
+
+func (m *Memory) removeLocked(element *list.Element) {
+	entry := m.lru.Remove(element).(*memoryEntry)
+	m.size -= int64(len(entry.value))
+	delete(m.entries[entry.namespace], entry.key)
+	if len(m.entries[entry.namespace]) == 0 {
+		delete(m.entries, entry.namespace)
+	}
+}
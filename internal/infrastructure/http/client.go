@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/rand/v2"
 	"net/http"
 	"time"
 
@@ -14,10 +15,15 @@ import (
 )
 
 type Config struct {
-	Timeout        time.Duration
-	MaxRetries     int
-	RetryDelay     time.Duration
-	MaxRetryDelay  time.Duration
+	Timeout       time.Duration
+	MaxRetries    int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// RetryJitter spreads exponential backoff delays by up to this fraction of the delay so
+	// concurrent callers do not retry in lockstep.
+	RetryJitter float64
+	// RetryPolicies overrides DefaultRetryPolicies per HTTP method.
+	RetryPolicies  map[string]RetryPolicy
 	UserAgent      string
 	DefaultHeaders map[string]string
 }
@@ -29,6 +35,7 @@ func DefaultConfig() *Config {
 		MaxRetries:     3,
 		RetryDelay:     1 * time.Second,
 		MaxRetryDelay:  30 * time.Second,
+		RetryJitter:    0.2,
 		UserAgent:      "linkedin-mcp-client/1.0",
 		DefaultHeaders: make(map[string]string),
 	}
@@ -37,6 +44,8 @@ func DefaultConfig() *Config {
 type httpClient struct {
 	client *http.Client
 	config *Config
+	now    func() time.Time
+	random func() float64
 }
 
 func NewClient(config *Config) api.Client {
@@ -51,6 +60,8 @@ func NewClient(config *Config) api.Client {
 	return &httpClient{
 		client: client,
 		config: config,
+		now:    time.Now,
+		random: rand.Float64,
 	}
 }
 
@@ -76,6 +87,8 @@ func (c *httpClient) Patch(ctx context.Context, url string, body interface{}, he
 
 func (c *httpClient) doRequest(ctx context.Context, method, url string, body interface{}, headers map[string]string) (*api.Response, error) {
 	var lastErr error
+	policy := c.retryPolicy(ctx, method, headers)
+	key := idempotencyKey(ctx, headers)
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		var bodyReader io.Reader
@@ -93,12 +106,17 @@ func (c *httpClient) doRequest(ctx context.Context, method, url string, body int
 		}
 
 		c.setHeaders(req, headers)
+		if key != "" {
+			req.Header.Set(HeaderIdempotencyKey, key)
+		}
 
 		resp, err := c.client.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("request failed: %w", err)
-			if attempt < c.config.MaxRetries {
-				c.waitBeforeRetry(attempt)
+			if attempt < c.config.MaxRetries && policy.retriesTransportError(err) {
+				if err := c.waitBeforeRetry(ctx, c.backoff(attempt)); err != nil {
+					return nil, err
+				}
 
 				continue
 			}
@@ -110,8 +128,10 @@ func (c *httpClient) doRequest(ctx context.Context, method, url string, body int
 		if err != nil {
 			resp.Body.Close()
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
-			if attempt < c.config.MaxRetries {
-				c.waitBeforeRetry(attempt)
+			if attempt < c.config.MaxRetries && policy.retriesResponse() {
+				if err := c.waitBeforeRetry(ctx, c.backoff(attempt)); err != nil {
+					return nil, err
+				}
 
 				continue
 			}
@@ -119,18 +139,26 @@ func (c *httpClient) doRequest(ctx context.Context, method, url string, body int
 		}
 		resp.Body.Close()
 
-		if c.shouldRetry(resp.StatusCode) && attempt < c.config.MaxRetries {
+		response := &api.Response{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       responseBody,
+		}
+
+		if c.shouldRetry(resp.StatusCode) && attempt < c.config.MaxRetries && policy.retriesResponse() {
+			delay, ok := c.retryDelay(response, attempt)
+			if !ok {
+				return response, nil
+			}
 			lastErr = fmt.Errorf("received status %d, retrying", resp.StatusCode)
-			c.waitBeforeRetry(attempt)
+			if err := c.waitBeforeRetry(ctx, delay); err != nil {
+				return nil, err
+			}
 
 			continue
 		}
 
-		return &api.Response{
-			StatusCode: resp.StatusCode,
-			Headers:    resp.Header,
-			Body:       responseBody,
-		}, nil
+		return response, nil
 	}
 
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
@@ -156,10 +184,43 @@ func (c *httpClient) shouldRetry(statusCode int) bool {
 	return statusCode >= 500 || statusCode == 429 || statusCode == 408
 }
 
-func (c *httpClient) waitBeforeRetry(attempt int) {
-	delay := time.Duration(float64(c.config.RetryDelay) * math.Pow(2, float64(attempt)))
+// retryDelay honours the server's Retry-After or rate-limit reset when it sends one and falls
+// back to exponential backoff otherwise. It reports false when the server asks for a longer
+// wait than MaxRetryDelay: the response is then returned so the caller can surface it.
+func (c *httpClient) retryDelay(response *api.Response, attempt int) (time.Duration, bool) {
+	delay, ok := response.RetryAfter(c.now())
+	if !ok {
+		return c.backoff(attempt), true
+	}
 	if delay > c.config.MaxRetryDelay {
-		delay = c.config.MaxRetryDelay
+		return 0, false
+	}
+	return delay, true
+}
+
+// backoff returns the exponential delay for attempt with up to RetryJitter of it added,
+// capped at MaxRetryDelay.
+func (c *httpClient) backoff(attempt int) time.Duration {
+	delay := float64(c.config.RetryDelay) * math.Pow(2, float64(attempt))
+	if c.config.RetryJitter > 0 {
+		delay += delay * c.config.RetryJitter * c.random()
+	}
+	if delay > float64(c.config.MaxRetryDelay) {
+		return c.config.MaxRetryDelay
+	}
+	return time.Duration(delay)
+}
+
+// waitBeforeRetry sleeps for delay unless ctx is done first, so a cancelled call stops
+// retrying immediately.
+func (c *httpClient) waitBeforeRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request cancelled while waiting to retry: %w", ctx.Err())
+	case <-timer.C:
+		return nil
 	}
-	time.Sleep(delay)
 }
@@ -3,6 +3,8 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -512,6 +514,166 @@ func (s *HTTPClientSuite) TestWhenResponseBodyReadFails_ThenRetries() {
 	s.Equal(2, attemptCount)
 }
 
+func (s *HTTPClientSuite) TestWhenRetryAfterSent_ThenWaitsForIt() {
+	config := *s.config
+	config.MaxRetries = 1
+	config.MaxRetryDelay = 5 * time.Second
+	s.client = NewClient(&config)
+	attemptCount := 0
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		if attemptCount == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	start := time.Now()
+	s.whenIGetRequest()
+
+	s.thenRequestSucceeds()
+	s.thenStatusCodeIs(http.StatusOK)
+	s.Equal(2, attemptCount)
+	s.GreaterOrEqual(time.Since(start), time.Second)
+}
+
+func (s *HTTPClientSuite) TestWhenRetryAfterExceedsMaxRetryDelay_ThenReturnsResponse() {
+	config := *s.config
+	config.MaxRetries = 1
+	s.client = NewClient(&config)
+	attemptCount := 0
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	s.whenIGetRequest()
+
+	s.thenRequestSucceeds()
+	s.thenStatusCodeIs(http.StatusTooManyRequests)
+	s.Equal(1, attemptCount)
+	s.thenResponseHasHeader("Retry-After", "120")
+}
+
+func (s *HTTPClientSuite) TestWhenContextCancelledWhileWaiting_ThenStopsRetrying() {
+	config := *s.config
+	config.MaxRetries = 1
+	config.RetryDelay = 10 * time.Second
+	config.MaxRetryDelay = 10 * time.Second
+	s.client = NewClient(&config)
+	s.givenServerReturnsStatus(http.StatusServiceUnavailable)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	response, err := s.client.Get(ctx, s.server.URL, nil)
+
+	s.Nil(response)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Less(time.Since(start), time.Second)
+}
+
+func (s *HTTPClientSuite) TestWhenPostReceivesRetryableStatus_ThenDoesNotRetry() {
+	attemptCount := 0
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	s.whenIPostRequest(map[string]string{"name": "campaign"})
+
+	s.thenRequestSucceeds()
+	s.thenStatusCodeIs(http.StatusServiceUnavailable)
+	s.Equal(1, attemptCount)
+}
+
+func (s *HTTPClientSuite) TestWhenPostConnectionDropped_ThenDoesNotRetry() {
+	attemptCount := 0
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		if hj, ok := w.(http.Hijacker); ok {
+			conn, _, _ := hj.Hijack()
+			conn.Close()
+		}
+	})
+
+	s.whenIPostRequest(map[string]string{"name": "campaign"})
+
+	s.thenRequestFails()
+	s.Equal(1, attemptCount)
+}
+
+func (s *HTTPClientSuite) TestWhenPostCannotConnect_ThenRetries() {
+	dialCount := 0
+	client := NewClient(s.config).(*httpClient)
+	client.client.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialCount++
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		},
+	}
+
+	_, err := client.Post(s.ctx, "http://gateway.invalid/proxy", map[string]string{"name": "campaign"}, nil)
+
+	s.Error(err)
+	s.Equal(s.config.MaxRetries+1, dialCount)
+}
+
+func (s *HTTPClientSuite) TestWhenPostHasIdempotencyKey_ThenRetriesWithSameKey() {
+	var keys []string
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(HeaderIdempotencyKey))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ctx := WithIdempotencyKey(s.ctx, "create-42")
+	s.response, s.err = s.client.Post(ctx, s.server.URL, map[string]string{"name": "campaign"}, nil)
+
+	s.thenRequestSucceeds()
+	s.thenStatusCodeIs(http.StatusCreated)
+	s.Equal([]string{"create-42", "create-42"}, keys)
+}
+
+func (s *HTTPClientSuite) TestWhenPerCallPolicyOverridesMethod_ThenPolicyApplies() {
+	attemptCount := 0
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	s.response, s.err = s.client.Get(WithRetryPolicy(s.ctx, RetryNever), s.server.URL, nil)
+	s.thenStatusCodeIs(http.StatusInternalServerError)
+	s.Equal(1, attemptCount)
+
+	attemptCount = 0
+	s.response, s.err = s.client.Post(WithRetryPolicy(s.ctx, RetryIdempotent), s.server.URL, nil, nil)
+	s.thenStatusCodeIs(http.StatusInternalServerError)
+	s.Equal(2, attemptCount)
+}
+
+func (s *HTTPClientSuite) TestWhenMethodPolicyConfigured_ThenOverridesDefault() {
+	config := *s.config
+	config.RetryPolicies = map[string]RetryPolicy{http.MethodPatch: RetryIdempotent}
+	s.client = NewClient(&config)
+	attemptCount := 0
+	s.givenServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		attemptCount++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	s.whenIPatchRequest(map[string]string{"status": "PAUSED"})
+
+	s.thenStatusCodeIs(http.StatusBadGateway)
+	s.Equal(2, attemptCount)
+}
+
 func TestHTTPClientSuite(t *testing.T) {
 	suite.Run(t, new(HTTPClientSuite))
 }
@@ -540,3 +702,15 @@ func TestResponse(t *testing.T) {
 	assert.Equal(t, "application/json", response.Headers["Content-Type"][0])
 	assert.Equal(t, []byte("test"), response.Body)
 }
+
+func TestBackoff_AddsBoundedJitter(t *testing.T) {
+	client := NewClient(&Config{RetryDelay: time.Second, MaxRetryDelay: 3 * time.Second, RetryJitter: 0.5}).(*httpClient)
+
+	client.random = func() float64 { return 0 }
+	assert.Equal(t, time.Second, client.backoff(0))
+	assert.Equal(t, 2*time.Second, client.backoff(1))
+
+	client.random = func() float64 { return 0.999 }
+	assert.InDelta(t, float64(1500*time.Millisecond), float64(client.backoff(0)), float64(time.Millisecond))
+	assert.Equal(t, 3*time.Second, client.backoff(2), "capped at MaxRetryDelay")
+}
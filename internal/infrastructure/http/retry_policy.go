@@ -0,0 +1,116 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// HeaderIdempotencyKey lets a server deduplicate replays of a non-idempotent request.
+const HeaderIdempotencyKey = "Idempotency-Key"
+
+// RetryPolicy decides which failures of a call are retried.
+type RetryPolicy int
+
+const (
+	// RetryDefault picks the policy from the HTTP method (see [DefaultRetryPolicies]).
+	RetryDefault RetryPolicy = iota
+	// RetryIdempotent retries connection errors, timeouts, interrupted responses and
+	// retryable statuses (408, 429, 5xx): replaying the call cannot apply it twice.
+	RetryIdempotent
+	// RetryConnectOnly retries only when the connection could not be established, i.e. the
+	// server never saw the request. Used for writes that must not be applied twice.
+	RetryConnectOnly
+	// RetryNever makes exactly one attempt.
+	RetryNever
+)
+
+// DefaultRetryPolicies maps HTTP methods to their policy. Methods not listed use
+// RetryConnectOnly.
+func DefaultRetryPolicies() map[string]RetryPolicy {
+	return map[string]RetryPolicy{
+		http.MethodGet:     RetryIdempotent,
+		http.MethodHead:    RetryIdempotent,
+		http.MethodOptions: RetryIdempotent,
+		http.MethodPut:     RetryIdempotent,
+		http.MethodDelete:  RetryIdempotent,
+		http.MethodPost:    RetryConnectOnly,
+		http.MethodPatch:   RetryConnectOnly,
+	}
+}
+
+type retryPolicyKey struct{}
+type idempotencyKeyKey struct{}
+
+// WithRetryPolicy overrides the retry policy of calls made with the returned context. The
+// gateway uses it to retry a proxy POST according to the LinkedIn method it wraps.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// WithIdempotencyKey sends key in the Idempotency-Key header of calls made with the returned
+// context. Such calls are retried like idempotent ones because the server deduplicates them,
+// unless a per-call policy says otherwise.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyKey{}, key)
+}
+
+func idempotencyKey(ctx context.Context, headers map[string]string) string {
+	for name, value := range headers {
+		if strings.EqualFold(name, HeaderIdempotencyKey) && strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	key, _ := ctx.Value(idempotencyKeyKey{}).(string)
+	return strings.TrimSpace(key)
+}
+
+// retryPolicy resolves the policy of one call: a per-call override wins, then an idempotency
+// key, then the per-method configuration.
+func (c *httpClient) retryPolicy(ctx context.Context, method string, headers map[string]string) RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok && policy != RetryDefault {
+		return policy
+	}
+	if idempotencyKey(ctx, headers) != "" {
+		return RetryIdempotent
+	}
+	if policy, ok := c.config.RetryPolicies[strings.ToUpper(method)]; ok && policy != RetryDefault {
+		return policy
+	}
+	if policy, ok := DefaultRetryPolicies()[strings.ToUpper(method)]; ok {
+		return policy
+	}
+	return RetryConnectOnly
+}
+
+// retriesTransportError reports whether a failed round trip may be replayed under policy.
+func (p RetryPolicy) retriesTransportError(err error) bool {
+	switch p {
+	case RetryIdempotent:
+		return true
+	case RetryConnectOnly:
+		return isConnectError(err)
+	default:
+		return false
+	}
+}
+
+// retriesResponse reports whether a received response (a retryable status or a body that
+// could not be read) may be replayed: the server processed the request, so only when
+// replaying is harmless.
+func (p RetryPolicy) retriesResponse() bool {
+	return p == RetryIdempotent
+}
+
+// isConnectError reports whether err happened while dialing, before any byte of the request
+// reached the server.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return opErr.Op == "dial"
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
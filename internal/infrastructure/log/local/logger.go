@@ -2,6 +2,7 @@ package local
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 )
@@ -11,8 +12,13 @@ type LogService struct {
 }
 
 func NewLogger() *LogService {
+	return NewLoggerTo(os.Stdout)
+}
+
+// NewLoggerTo writes log lines to w, e.g. stderr when stdout carries the MCP stdio transport.
+func NewLoggerTo(w io.Writer) *LogService {
 	return &LogService{
-		client: slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		client: slog.New(slog.NewTextHandler(w, nil)),
 	}
 }
 
@@ -33,11 +33,16 @@ func RequireBearerAuth(verifier TokenVerifier, resourceMetadataURL, requiredScop
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), authContextKey{}, userID)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r.WithContext(ContextWithUserID(r.Context(), userID)))
 	})
 }
 
+// ContextWithUserID returns a context carrying the authenticated user ID that LinkedIn calls
+// are made on behalf of.
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, authContextKey{}, userID)
+}
+
 func UserIDFromContext(ctx context.Context) (string, bool) {
 	userID, ok := ctx.Value(authContextKey{}).(string)
 	return userID, ok && userID != ""
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FixedUserID is an MCP receiving middleware for transports without HTTP requests to
+// authenticate, such as stdio: every request runs as userID, as if [RequireBearerAuth] had
+// verified a token for that user.
+func FixedUserID(userID string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(ContextWithUserID(ctx, userID), method, req)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package ratelimit bounds how fast and how concurrently each user may call each MCP tool so
+// one session cannot exhaust the LinkedIn application quota shared by every user.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Rejection reasons reported by [RejectedError] and in metrics.
+const (
+	ReasonRate        = "rate"
+	ReasonConcurrency = "concurrency"
+)
+
+// concurrencyRetryAfter is suggested when a call is rejected for too many in-flight calls;
+// there is no way to know when one of them finishes.
+const concurrencyRetryAfter = time.Second
+
+// idleSweepInterval is how often entries of users who stopped calling are dropped.
+const idleSweepInterval = time.Minute
+
+// Limit bounds one user's calls to one tool. Zero fields disable that bound.
+type Limit struct {
+	// PerMinute is the token bucket refill rate and Burst its capacity.
+	PerMinute float64
+	Burst     int
+	// MaxInFlight caps the user's concurrent calls of the tool.
+	MaxInFlight int
+}
+
+func (l Limit) rateLimited() bool {
+	return l.PerMinute > 0
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// RejectedError reports a call refused by the limiter.
+type RejectedError struct {
+	Tool       string
+	Reason     string
+	Limit      Limit
+	RetryAfter time.Duration
+}
+
+func (e *RejectedError) Error() string {
+	if e.Reason == ReasonConcurrency {
+		return fmt.Sprintf("too many concurrent %s calls (at most %d at a time)", e.Tool, e.Limit.MaxInFlight)
+	}
+	return fmt.Sprintf("too many %s calls (at most %g per minute)", e.Tool, e.Limit.PerMinute)
+}
+
+// RetryAfterSeconds rounds RetryAfter up so callers never retry early.
+func (e *RejectedError) RetryAfterSeconds() int {
+	return int(math.Ceil(e.RetryAfter.Seconds()))
+}
+
+type key struct {
+	userID string
+	tool   string
+}
+
+type entry struct {
+	tokens   float64
+	updated  time.Time
+	inFlight int
+}
+
+// Limiter keeps a token bucket and an in-flight counter per user and tool.
+type Limiter struct {
+	defaults  Limit
+	tools     map[string]Limit
+	mu        sync.Mutex
+	entries   map[key]*entry
+	lastSweep time.Time
+	metrics   *Metrics
+	now       func() time.Time
+}
+
+// NewLimiter applies defaults to every tool except those listed in tools.
+func NewLimiter(defaults Limit, tools map[string]Limit) *Limiter {
+	return &Limiter{
+		defaults: defaults,
+		tools:    tools,
+		entries:  map[key]*entry{},
+		metrics:  newMetrics(),
+		now:      time.Now,
+	}
+}
+
+// Metrics returns the counters of allowed, rejected and in-flight calls.
+func (l *Limiter) Metrics() *Metrics {
+	return l.metrics
+}
+
+func (l *Limiter) limitFor(tool string) Limit {
+	if limit, ok := l.tools[tool]; ok {
+		return limit
+	}
+	return l.defaults
+}
+
+// Acquire admits one call of tool by userID or returns a *RejectedError. The returned release
+// function must be called when an admitted call finishes.
+func (l *Limiter) Acquire(userID, tool string) (func(), error) {
+	limit := l.limitFor(tool)
+	now := l.now()
+
+	l.mu.Lock()
+	l.sweep(now)
+	k := key{userID: userID, tool: tool}
+	current, ok := l.entries[k]
+	if !ok {
+		current = &entry{tokens: limit.capacity(), updated: now}
+		l.entries[k] = current
+	}
+	current.refill(limit, now)
+
+	if limit.MaxInFlight > 0 && current.inFlight >= limit.MaxInFlight {
+		l.mu.Unlock()
+		l.metrics.recordRejected(tool, ReasonConcurrency)
+		return nil, &RejectedError{Tool: tool, Reason: ReasonConcurrency, Limit: limit, RetryAfter: concurrencyRetryAfter}
+	}
+	if limit.rateLimited() {
+		if current.tokens < 1 {
+			wait := time.Duration((1 - current.tokens) / limit.PerMinute * float64(time.Minute))
+			l.mu.Unlock()
+			l.metrics.recordRejected(tool, ReasonRate)
+			return nil, &RejectedError{Tool: tool, Reason: ReasonRate, Limit: limit, RetryAfter: wait}
+		}
+		current.tokens--
+	}
+	current.inFlight++
+	l.mu.Unlock()
+	l.metrics.recordAllowed(tool)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			current.inFlight--
+			l.mu.Unlock()
+			l.metrics.recordFinished(tool)
+		})
+	}, nil
+}
+
+func (e *entry) refill(limit Limit, now time.Time) {
+	if limit.rateLimited() {
+		elapsed := now.Sub(e.updated).Minutes()
+		e.tokens = math.Min(limit.capacity(), e.tokens+elapsed*limit.PerMinute)
+	}
+	e.updated = now
+}
+
+// sweep drops idle entries whose bucket has refilled, bounding memory to active users. The
+// caller holds l.mu.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for k, current := range l.entries {
+		if current.inFlight > 0 {
+			continue
+		}
+		limit := l.limitFor(k.tool)
+		current.refill(limit, now)
+		if !limit.rateLimited() || current.tokens >= limit.capacity() {
+			delete(l.entries, k)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(defaults Limit, tools map[string]Limit) (*Limiter, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(defaults, tools)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestAcquire_TokenBucketRefillsPerUserAndTool(t *testing.T) {
+	limiter, now := newTestLimiter(Limit{PerMinute: 60, Burst: 2}, nil)
+
+	for range 2 {
+		release, err := limiter.Acquire("user_1", "get_analytics")
+		require.NoError(t, err)
+		release()
+	}
+	_, err := limiter.Acquire("user_1", "get_analytics")
+	rejected, ok := err.(*RejectedError)
+	require.True(t, ok)
+	require.Equal(t, ReasonRate, rejected.Reason)
+	require.Equal(t, 1, rejected.RetryAfterSeconds())
+
+	_, err = limiter.Acquire("user_2", "get_analytics")
+	require.NoError(t, err, "other users have their own bucket")
+	_, err = limiter.Acquire("user_1", "search_campaigns")
+	require.NoError(t, err, "other tools have their own bucket")
+
+	*now = now.Add(time.Second)
+	_, err = limiter.Acquire("user_1", "get_analytics")
+	require.NoError(t, err)
+}
+
+func TestAcquire_CapsInFlightCalls(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{MaxInFlight: 1}, nil)
+
+	release, err := limiter.Acquire("user_1", "export_analytics_report")
+	require.NoError(t, err)
+	_, err = limiter.Acquire("user_1", "export_analytics_report")
+	rejected, ok := err.(*RejectedError)
+	require.True(t, ok)
+	require.Equal(t, ReasonConcurrency, rejected.Reason)
+
+	release()
+	release()
+	_, err = limiter.Acquire("user_1", "export_analytics_report")
+	require.NoError(t, err, "release is idempotent and frees the slot")
+}
+
+func TestAcquire_ToolOverrideReplacesDefaults(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{PerMinute: 600, Burst: 100}, map[string]Limit{
+		"get_analytics": {PerMinute: 1, Burst: 1},
+	})
+
+	_, err := limiter.Acquire("user_1", "get_analytics")
+	require.NoError(t, err)
+	_, err = limiter.Acquire("user_1", "get_analytics")
+	require.Error(t, err)
+	require.Equal(t, "too many get_analytics calls (at most 1 per minute)", err.Error())
+	require.Equal(t, 60, err.(*RejectedError).RetryAfterSeconds())
+}
+
+func TestAcquire_SweepsIdleEntries(t *testing.T) {
+	limiter, now := newTestLimiter(Limit{PerMinute: 60, Burst: 1}, nil)
+
+	release, err := limiter.Acquire("user_1", "get_campaign")
+	require.NoError(t, err)
+	release()
+	*now = now.Add(2 * idleSweepInterval)
+	_, err = limiter.Acquire("user_2", "get_campaign")
+	require.NoError(t, err)
+
+	require.Len(t, limiter.entries, 1)
+}
+
+func TestMetrics_RendersPrometheusText(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{MaxInFlight: 1}, nil)
+	_, err := limiter.Acquire("user_1", "get_analytics")
+	require.NoError(t, err)
+	_, err = limiter.Acquire("user_1", "get_analytics")
+	require.Error(t, err)
+
+	recorder := httptest.NewRecorder()
+	limiter.Metrics().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	body := recorder.Body.String()
+	require.Contains(t, body, `mcp_tool_calls_allowed_total{tool="get_analytics"} 1`)
+	require.Contains(t, body, `mcp_tool_calls_rejected_total{tool="get_analytics",reason="concurrency"} 1`)
+	require.Contains(t, body, `mcp_tool_calls_in_flight{tool="get_analytics"} 1`)
+}
+
+func TestMiddleware_ReturnsRetryableToolError(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{PerMinute: 30, Burst: 1}, nil)
+	calls := 0
+	handler := Middleware(limiter, []string{"get_analytics"})(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		calls++
+		return &mcp.CallToolResult{}, nil
+	})
+	ctx := middleware.ContextWithUserID(context.Background(), "user_1")
+	request := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_analytics"}}
+
+	_, err := handler(ctx, methodCallTool, request)
+	require.NoError(t, err)
+	result, err := handler(ctx, methodCallTool, request)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, calls)
+	toolResult := result.(*mcp.CallToolResult)
+	require.True(t, toolResult.IsError)
+	require.Contains(t, toolResult.Content[0].(*mcp.TextContent).Text, "retry after 2 seconds")
+	require.Equal(t, map[string]any{"tool": "get_analytics", "reason": ReasonRate, "retryAfterSeconds": 2}, toolResult.Meta["rateLimit"])
+
+	_, err = handler(ctx, "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls, "other methods are not limited")
+}
+
+func TestMiddleware_CollapsesUnregisteredToolNames(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{}, nil)
+	handler := Middleware(limiter, []string{"get_analytics"})(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	})
+	ctx := middleware.ContextWithUserID(context.Background(), "user_1")
+
+	for _, name := range []string{"get_analytics", "random_1", "random_2", "x\"\n"} {
+		_, err := handler(ctx, methodCallTool, &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, map[string]uint64{"get_analytics": 1, UnknownTool: 3}, limiter.Metrics().allowed)
+	require.Len(t, limiter.entries, 2)
+}
+
+func TestMetrics_EscapesLabelValuesForPrometheus(t *testing.T) {
+	limiter, _ := newTestLimiter(Limit{}, nil)
+	_, err := limiter.Acquire("user_1", "a\"b\\c\nd\x00")
+	require.NoError(t, err)
+
+	require.Contains(t, limiter.Metrics().render(), `mcp_tool_calls_allowed_total{tool="a\"b\\c\nd`+"\x00"+`"} 1`)
+}
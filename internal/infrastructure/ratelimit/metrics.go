@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metrics counts tool calls per tool. Users are deliberately not a label: the number of users
+// is unbounded and per-user state is only kept while they are active.
+type Metrics struct {
+	mu       sync.Mutex
+	allowed  map[string]uint64
+	rejected map[rejectionKey]uint64
+	inFlight map[string]int64
+}
+
+type rejectionKey struct {
+	tool   string
+	reason string
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		allowed:  map[string]uint64{},
+		rejected: map[rejectionKey]uint64{},
+		inFlight: map[string]int64{},
+	}
+}
+
+func (m *Metrics) recordAllowed(tool string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.allowed[tool]++
+	m.inFlight[tool]++
+}
+
+func (m *Metrics) recordFinished(tool string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight[tool]--
+}
+
+func (m *Metrics) recordRejected(tool, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected[rejectionKey{tool: tool, reason: reason}]++
+}
+
+// ServeHTTP writes the counters in the Prometheus text exposition format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(m.render()))
+}
+
+func (m *Metrics) render() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("# HELP mcp_tool_calls_allowed_total Tool calls admitted by the per-user limiter.\n")
+	b.WriteString("# TYPE mcp_tool_calls_allowed_total counter\n")
+	for _, tool := range sortedKeys(m.allowed) {
+		fmt.Fprintf(&b, "mcp_tool_calls_allowed_total{tool=\"%s\"} %d\n", labelValue(tool), m.allowed[tool])
+	}
+
+	b.WriteString("# HELP mcp_tool_calls_rejected_total Tool calls refused by the per-user limiter, by reason (rate or concurrency).\n")
+	b.WriteString("# TYPE mcp_tool_calls_rejected_total counter\n")
+	rejections := make([]rejectionKey, 0, len(m.rejected))
+	for k := range m.rejected {
+		rejections = append(rejections, k)
+	}
+	sort.Slice(rejections, func(i, j int) bool {
+		if rejections[i].tool != rejections[j].tool {
+			return rejections[i].tool < rejections[j].tool
+		}
+		return rejections[i].reason < rejections[j].reason
+	})
+	for _, k := range rejections {
+		fmt.Fprintf(&b, "mcp_tool_calls_rejected_total{tool=\"%s\",reason=\"%s\"} %d\n", labelValue(k.tool), labelValue(k.reason), m.rejected[k])
+	}
+
+	b.WriteString("# HELP mcp_tool_calls_in_flight Tool calls currently executing.\n")
+	b.WriteString("# TYPE mcp_tool_calls_in_flight gauge\n")
+	for _, tool := range sortedKeys(m.inFlight) {
+		fmt.Fprintf(&b, "mcp_tool_calls_in_flight{tool=\"%s\"} %d\n", labelValue(tool), m.inFlight[tool])
+	}
+	return b.String()
+}
+
+// labelValue escapes a label value for the Prometheus text format, which only knows \\, \"
+// and \n (Go's %q would also write \x and \u escapes).
+func labelValue(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
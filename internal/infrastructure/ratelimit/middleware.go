@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const methodCallTool = "tools/call"
+
+// UnknownTool is the tool name calls to unregistered tools are limited and counted under.
+const UnknownTool = "unknown"
+
+// Middleware applies limiter to every tools/call request, keyed by the authenticated user.
+// Calls naming a tool outside tools share the [UnknownTool] name, so clients cannot grow the
+// limiter and metrics with arbitrary names before the SDK rejects them.
+// A rejected call returns a tool error result (not a protocol error) so the model sees when
+// it may retry; the same details are in the result's _meta under "rateLimit".
+func Middleware(limiter *Limiter, tools []string) mcp.Middleware {
+	registered := make(map[string]struct{}, len(tools))
+	for _, tool := range tools {
+		registered[tool] = struct{}{}
+	}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			call, ok := req.(*mcp.CallToolRequest)
+			if method != methodCallTool || !ok || call.Params == nil {
+				return next(ctx, method, req)
+			}
+
+			tool := call.Params.Name
+			if _, ok := registered[tool]; !ok {
+				tool = UnknownTool
+			}
+			userID, _ := middleware.UserIDFromContext(ctx)
+			release, err := limiter.Acquire(userID, tool)
+			if err != nil {
+				var rejected *RejectedError
+				if errors.As(err, &rejected) {
+					return rejectedResult(rejected), nil
+				}
+				return nil, err
+			}
+			defer release()
+			return next(ctx, method, req)
+		}
+	}
+}
+
+func rejectedResult(err *RejectedError) *mcp.CallToolResult {
+	message := fmt.Sprintf(
+		"cannot run %s because this user is sending %s. Rate limited, retry after %d seconds; do not retry this tool call sooner",
+		err.Tool,
+		err.Error(),
+		err.RetryAfterSeconds(),
+	)
+	return &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+		Meta: mcp.Meta{
+			"rateLimit": map[string]any{
+				"tool":              err.Tool,
+				"reason":            err.Reason,
+				"retryAfterSeconds": err.RetryAfterSeconds(),
+			},
+		},
+	}
+}
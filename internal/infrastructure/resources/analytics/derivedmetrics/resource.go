@@ -0,0 +1,62 @@
+package derivedmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const resourceURI = "linkedin://analytics/derived-metrics"
+
+// Metric describes one derived metric get_analytics can compute server-side.
+type Metric struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	Formula string   `json:"formula"`
+	// Unit is "currency", "rate" (a fraction, e.g. 0.05 for 5%) or "number".
+	Unit           string   `json:"unit"`
+	RequiredFields []string `json:"requiredFields,omitempty"`
+	// Source is "built-in" for metrics compiled into the server and "server" for metrics the
+	// operator configured.
+	Source string `json:"source"`
+}
+
+type Resource struct {
+	metrics []Metric
+}
+
+func NewResource(metrics []Metric) *Resource {
+	return &Resource{metrics: metrics}
+}
+
+func (r *Resource) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	if req.Params.URI != resourceURI {
+		return nil, fmt.Errorf("resource not found: %s", req.Params.URI)
+	}
+
+	payload := map[string]any{
+		"purpose": "Derived metrics get_analytics and compare_analytics compute server-side from raw LinkedIn fields.",
+		"metrics": r.metrics,
+		"notes": []string{
+			"Request a derived metric by name or alias in fields; its required raw fields are added to the LinkedIn request automatically.",
+			"A derived value is null when a required field is missing or the formula divides by zero.",
+			"For metrics not listed here, pass customMetrics with an expression in the same syntax as formula: raw fields, numbers, + - * /, parentheses, abs, min, max and coalesce.",
+		},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize LinkedIn derived metrics resource payload: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:  req.Params.URI,
+				Text: string(data),
+			},
+		},
+	}, nil
+}
@@ -5,15 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-const schemaURL = "https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads-reporting/ads-reporting-schema?view=li-lms-2026-03#metrics-available"
+const schemaURL = "https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads-reporting/ads-reporting-schema?view=%s#metrics-available"
 
-type Resource struct{}
+type Resource struct {
+	apiVersion string
+}
 
-func NewResource() *Resource {
-	return &Resource{}
+// NewResource links the documentation of apiVersion, the LinkedIn-Version the server calls.
+func NewResource(apiVersion string) *Resource {
+	return &Resource{apiVersion: apiVersion}
 }
 
 func (r *Resource) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
@@ -21,10 +26,14 @@ func (r *Resource) ReadResource(ctx context.Context, req *mcp.ReadResourceReques
 		return nil, fmt.Errorf("resource not found: %s", req.Params.URI)
 	}
 
+	// Link the documentation of the version this request actually calls LinkedIn with.
+	apiVersion := gateway.ResolveAPIVersion(ctx, r.apiVersion)
 	payload := map[string]any{
-		"source":  schemaURL,
-		"purpose": "Canonical LinkedIn Ads Reporting metrics documentation.",
+		"source":     fmt.Sprintf(schemaURL, gateway.APIVersionDocsView(apiVersion)),
+		"apiVersion": apiVersion,
+		"purpose":    "Canonical LinkedIn Ads Reporting metrics documentation.",
 		"notes": []string{
+			"The server calls LinkedIn with the LinkedIn-Version in apiVersion; the linked documentation is for that version.",
 			"LinkedIn documentation is the source of truth for available analytics metrics.",
 			"Metric names and availability can change over time.",
 			"Use exact metric field names from the linked table when building get_analytics requests.",
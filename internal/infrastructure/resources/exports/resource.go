@@ -0,0 +1,41 @@
+package exports
+
+import (
+	"context"
+
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// URITemplate matches every export URI handed out by Store.Put.
+const URITemplate = URIPrefix + "{id}"
+
+type Resource struct {
+	store *Store
+}
+
+func NewResource(store *Store) *Resource {
+	return &Resource{store: store}
+}
+
+// ReadResource serves an export to the user who created it. Expired exports and exports of
+// other users are reported as not found.
+func (r *Resource) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	userID, _ := middleware.UserIDFromContext(ctx)
+	export, ok := r.store.Get(userID, req.Params.URI)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(req.Params.URI)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      req.Params.URI,
+				MIMEType: export.MIMEType,
+				Text:     export.Text,
+				Blob:     export.Blob,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,109 @@
+package exports
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// URIPrefix is the scheme and path every export resource URI starts with.
+const URIPrefix = "linkedin://exports/"
+
+// Export is a rendered report kept for the client to read as a resource. Text exports set
+// Text; binary exports (e.g. XLSX) set Blob.
+type Export struct {
+	Name     string
+	MIMEType string
+	Text     string
+	Blob     []byte
+}
+
+type entry struct {
+	export    Export
+	owner     string
+	expiresAt time.Time
+}
+
+// Store keeps exports in memory for ttl. Each export is readable only by the user that
+// created it, and the oldest exports are evicted beyond maxEntries.
+type Store struct {
+	mu         sync.Mutex
+	entries    map[string]entry
+	order      []string
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+}
+
+func NewStore(ttl time.Duration, maxEntries int) *Store {
+	return &Store{
+		entries:    map[string]entry{},
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+// Put stores export for owner and returns its resource URI and expiry time.
+func (s *Store) Put(owner string, export Export) (string, time.Time, error) {
+	id, err := newID()
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate export id: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evictLocked(now)
+	for s.maxEntries > 0 && len(s.order) >= s.maxEntries {
+		delete(s.entries, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	expiresAt := now.Add(s.ttl)
+	s.entries[id] = entry{export: export, owner: owner, expiresAt: expiresAt}
+	s.order = append(s.order, id)
+	return URIPrefix + id, expiresAt, nil
+}
+
+// Get returns the export behind uri when it exists, has not expired and belongs to owner.
+func (s *Store) Get(owner, uri string) (Export, bool) {
+	if len(uri) <= len(URIPrefix) || uri[:len(URIPrefix)] != URIPrefix {
+		return Export{}, false
+	}
+	id := uri[len(URIPrefix):]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evictLocked(s.now())
+	stored, ok := s.entries[id]
+	if !ok || stored.owner != owner {
+		return Export{}, false
+	}
+	return stored.export, true
+}
+
+// evictLocked drops expired exports. Entries expire in insertion order, so it stops at the
+// first live one.
+func (s *Store) evictLocked(now time.Time) {
+	for len(s.order) > 0 {
+		stored, ok := s.entries[s.order[0]]
+		if ok && now.Before(stored.expiresAt) {
+			return
+		}
+		delete(s.entries, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+func newID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,64 @@
+package exports
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore_PutAndGet(t *testing.T) {
+	store := NewStore(time.Hour, 10)
+
+	uri, expiresAt, err := store.Put("user_1", Export{Name: "report.csv", MIMEType: "text/csv", Text: "a,b\n"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(uri, URIPrefix))
+	require.True(t, expiresAt.After(time.Now()))
+
+	export, ok := store.Get("user_1", uri)
+	require.True(t, ok)
+	require.Equal(t, "a,b\n", export.Text)
+
+	_, ok = store.Get("user_2", uri)
+	require.False(t, ok, "exports are private to their owner")
+	_, ok = store.Get("user_1", URIPrefix+"unknown")
+	require.False(t, ok)
+	_, ok = store.Get("user_1", "linkedin://analytics/metrics")
+	require.False(t, ok)
+}
+
+func TestStore_Expires(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	store := NewStore(time.Minute, 10)
+	store.now = func() time.Time { return now }
+
+	uri, _, err := store.Put("user_1", Export{Text: "x"})
+	require.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	_, ok := store.Get("user_1", uri)
+	require.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = store.Get("user_1", uri)
+	require.False(t, ok)
+}
+
+func TestStore_EvictsOldestBeyondMaxEntries(t *testing.T) {
+	store := NewStore(time.Hour, 2)
+
+	first, _, err := store.Put("user_1", Export{Text: "1"})
+	require.NoError(t, err)
+	second, _, err := store.Put("user_1", Export{Text: "2"})
+	require.NoError(t, err)
+	third, _, err := store.Put("user_1", Export{Text: "3"})
+	require.NoError(t, err)
+
+	_, ok := store.Get("user_1", first)
+	require.False(t, ok)
+	_, ok = store.Get("user_1", second)
+	require.True(t, ok)
+	_, ok = store.Get("user_1", third)
+	require.True(t, ok)
+}
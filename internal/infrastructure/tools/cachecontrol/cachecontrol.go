@@ -0,0 +1,40 @@
+// Package cachecontrol maps the `cache` argument of read tools onto the gateway response cache.
+package cachecontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+)
+
+const (
+	// ModeDefault serves identical reads from the response cache while the entry is fresh.
+	ModeDefault = "default"
+	// ModeBypass always fetches from LinkedIn and refreshes the cached entry.
+	ModeBypass = "bypass"
+)
+
+// Validate reports whether mode is empty or one of the supported cache modes.
+func Validate(mode string) error {
+	switch normalize(mode) {
+	case "", ModeDefault, ModeBypass:
+		return nil
+	default:
+		return fmt.Errorf("invalid cache: %s. Must be one of: %s, %s", mode, ModeDefault, ModeBypass)
+	}
+}
+
+// Apply returns the context LinkedIn reads should use for mode. Unknown modes behave like
+// ModeDefault; call [Validate] first to reject them.
+func Apply(ctx context.Context, mode string) context.Context {
+	if normalize(mode) == ModeBypass {
+		return gateway.WithCacheBypass(ctx)
+	}
+	return ctx
+}
+
+func normalize(mode string) string {
+	return strings.ToLower(strings.TrimSpace(mode))
+}
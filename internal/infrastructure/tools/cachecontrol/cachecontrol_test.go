@@ -0,0 +1,22 @@
+package cachecontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	require.NoError(t, Validate(""))
+	require.NoError(t, Validate("default"))
+	require.NoError(t, Validate(" BYPASS "))
+	require.Error(t, Validate("refresh"))
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, ctx, Apply(ctx, ""))
+	require.Equal(t, ctx, Apply(ctx, ModeDefault))
+	require.NotEqual(t, ctx, Apply(ctx, "Bypass"))
+}
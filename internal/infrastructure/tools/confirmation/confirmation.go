@@ -0,0 +1,67 @@
+package confirmation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Outcome is the result of asking the user to confirm a mutating tool call.
+type Outcome int
+
+const (
+	// Unavailable means the client cannot be asked interactively (no elicitation support or
+	// the transport cannot carry server-to-client requests); callers fall back to echo tokens.
+	Unavailable Outcome = iota
+	Accepted
+	Declined
+)
+
+const confirmField = "confirm"
+
+// Token derives a short, deterministic confirmation token from the parts describing a planned
+// change. Including the current entity state in parts makes a token stale as soon as the
+// entity changes, so a confirmation can never be replayed against a different plan.
+func Token(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Elicit asks the user to approve message through MCP elicitation.
+func Elicit(ctx context.Context, req *mcp.CallToolRequest, message string) Outcome {
+	if req == nil || req.Session == nil {
+		return Unavailable
+	}
+	params := req.Session.InitializeParams()
+	if params == nil || params.Capabilities == nil || params.Capabilities.Elicitation == nil {
+		return Unavailable
+	}
+
+	result, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message: message,
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				confirmField: map[string]any{
+					"type":        "boolean",
+					"title":       "Apply these changes",
+					"description": "Set to true to apply the changes to LinkedIn Ads.",
+				},
+			},
+			"required": []string{confirmField},
+		},
+	})
+	if err != nil {
+		return Unavailable
+	}
+	if result.Action != "accept" {
+		return Declined
+	}
+	if approved, _ := result.Content[confirmField].(bool); !approved {
+		return Declined
+	}
+	return Accepted
+}
@@ -0,0 +1,19 @@
+package confirmation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToken_IsDeterministicAndOrderSensitive(t *testing.T) {
+	first := Token("512247261", "PAUSED", "1:ACTIVE")
+	require.Equal(t, first, Token("512247261", "PAUSED", "1:ACTIVE"))
+	require.Len(t, first, 16)
+	require.NotEqual(t, first, Token("512247261", "PAUSED", "1:PAUSED"))
+}
+
+func TestElicit_UnavailableWithoutSession(t *testing.T) {
+	require.Equal(t, Unavailable, Elicit(context.Background(), nil, "apply?"))
+}
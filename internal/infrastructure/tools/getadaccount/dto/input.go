@@ -0,0 +1,6 @@
+package dto
+
+type Input struct {
+	AccountIDs []string `json:"accountIDs" jsonschema:"One or more ad account IDs (e.g. 512345678) or URNs (urn:li:sponsoredAccount:{id}), max 50"`
+	Cache      string   `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
+}
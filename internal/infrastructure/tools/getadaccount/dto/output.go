@@ -0,0 +1,13 @@
+package dto
+
+import "linkedin-mcp/internal/infrastructure/api/adaccounts"
+
+type Output struct {
+	AdAccounts []AdAccount `json:"adAccounts" jsonschema:"Ad accounts found, in request order"`
+	NotFound   []string    `json:"notFound,omitempty" jsonschema:"Requested ad account URNs that do not exist or are not accessible"`
+}
+
+type AdAccount struct {
+	Normalized adaccounts.NormalizedAdAccount `json:"normalized" jsonschema:"Flattened key fields of the ad account"`
+	Entity     map[string]any                 `json:"entity" jsonschema:"Full LinkedIn ad account entity as returned by the API"`
+}
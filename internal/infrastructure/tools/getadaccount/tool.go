@@ -0,0 +1,82 @@
+package getadaccount
+
+import (
+	"context"
+	"fmt"
+
+	"linkedin-mcp/internal/infrastructure/api/adaccounts"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
+	"linkedin-mcp/internal/infrastructure/tools/getadaccount/dto"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxAccountsPerCall = 50
+
+type Tool struct {
+	repository *adaccounts.Repository
+	connectURL string
+}
+
+func NewTool(repository *adaccounts.Repository, connectURL string) *Tool {
+	return &Tool{repository: repository, connectURL: connectURL}
+}
+
+// GetAdAccount fetches one or more ad accounts by ID or URN and returns each full entity next
+// to its normalized fields.
+func (t *Tool) GetAdAccount(ctx context.Context, req *mcp.CallToolRequest, input dto.Input) (*mcp.CallToolResult, dto.Output, error) {
+	result := &mcp.CallToolResult{}
+
+	accountIDs, err := validateInput(input)
+	if err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
+
+	getResult, err := t.repository.GetAdAccounts(ctx, adaccounts.GetInput{AccountIDs: accountIDs})
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("get ad account", err, t.connectURL)
+	}
+
+	output := dto.Output{AdAccounts: make([]dto.AdAccount, 0, len(getResult.Elements))}
+	for _, element := range getResult.Elements {
+		output.AdAccounts = append(output.AdAccounts, dto.AdAccount{
+			Normalized: adaccounts.NormalizeAdAccount(element),
+			Entity:     element,
+		})
+	}
+	for _, id := range getResult.NotFound {
+		output.NotFound = append(output.NotFound, adaccounts.AccountURN(id))
+	}
+
+	return result, output, nil
+}
+
+func validateInput(input dto.Input) ([]string, error) {
+	if len(input.AccountIDs) == 0 {
+		return nil, fmt.Errorf("accountIDs is required and cannot be empty")
+	}
+	if len(input.AccountIDs) > maxAccountsPerCall {
+		return nil, fmt.Errorf("accountIDs cannot exceed %d entries", maxAccountsPerCall)
+	}
+
+	seen := make(map[string]struct{}, len(input.AccountIDs))
+	ids := make([]string, 0, len(input.AccountIDs))
+	for i, reference := range input.AccountIDs {
+		id, err := adaccounts.ParseAccountID(reference)
+		if err != nil {
+			return nil, fmt.Errorf("accountIDs[%d]: %w", i, err)
+		}
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,21 @@
+package getadaccount
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/getadaccount/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInput(t *testing.T) {
+	ids, err := validateInput(dto.Input{AccountIDs: []string{"urn:li:sponsoredAccount:512345678", "512345678", "7"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"512345678", "7"}, ids)
+
+	_, err = validateInput(dto.Input{})
+	require.Error(t, err)
+
+	_, err = validateInput(dto.Input{AccountIDs: []string{"urn:li:organization:1"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,279 @@
+package getanalytics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/pivotlabels"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Ways compare_analytics derives the comparison range from the current one.
+const (
+	comparisonPreviousPeriod     = "previous_period"
+	comparisonSamePeriodLastYear = "same_period_last_year"
+	comparisonCustom             = "custom"
+)
+
+// CompareAnalytics runs the same report for the current range and a comparison range, joins
+// the elements by pivot values and bucket offset, and returns per-metric deltas.
+func (t *Tool) CompareAnalytics(ctx context.Context, req *mcp.CallToolRequest, input dto.CompareInput) (*mcp.CallToolResult, dto.CompareOutput, error) {
+	result := &mcp.CallToolResult{}
+
+	current, derivedFields, err := t.validateAndNormalizeInput(input.Input)
+	if err != nil {
+		return result, dto.CompareOutput{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	comparison, err := comparisonInput(current, input)
+	if err != nil {
+		return result, dto.CompareOutput{}, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	currentResult, currentPagination, err := t.fetch(ctx, current, derivedFields)
+	if err != nil {
+		return result, dto.CompareOutput{}, toolerrors.WrapToolExecutionError("get current period analytics", err, t.connectURL)
+	}
+	comparisonResult, comparisonPagination, err := t.fetch(ctx, comparison, derivedFields)
+	if err != nil {
+		return result, dto.CompareOutput{}, toolerrors.WrapToolExecutionError("get comparison period analytics", err, t.connectURL)
+	}
+
+	output := dto.CompareOutput{
+		Current: dto.ResolvedDateRange{
+			Start:             current.DateRangeStart,
+			End:               current.DateRangeEnd,
+			RelativeDateRange: current.RelativeDateRange,
+			TimeZone:          current.TimeZone,
+		},
+		Comparison: dto.ResolvedDateRange{
+			Start: comparison.DateRangeStart,
+			End:   comparison.DateRangeEnd,
+		},
+		Rows: t.joinPeriods(current, comparison, currentResult, comparisonResult),
+	}
+	if currentPagination != nil && currentPagination.CeilingHit {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("current period truncated at %d rows; rows beyond the ceiling are missing from the comparison", currentPagination.MaxRows))
+	}
+	if comparisonPagination != nil && comparisonPagination.CeilingHit {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("comparison period truncated at %d rows; rows beyond the ceiling are missing from the comparison", comparisonPagination.MaxRows))
+	}
+
+	if current.ResolveNames {
+		t.injectComparisonLabels(ctx, current.AccountID, &output)
+	}
+
+	return result, output, nil
+}
+
+// comparisonInput copies the normalized current input onto the comparison range chosen by
+// input.Comparison.
+func comparisonInput(current dto.Input, input dto.CompareInput) (dto.Input, error) {
+	if current.DateRangeEnd == nil {
+		return dto.Input{}, fmt.Errorf("dateRangeEnd or relativeDateRange is required so both periods have a fixed length")
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(input.Comparison))
+	if mode == "" {
+		mode = comparisonPreviousPeriod
+	}
+	if mode != comparisonCustom && (input.ComparisonDateRangeStart != nil || input.ComparisonDateRangeEnd != nil) {
+		return dto.Input{}, fmt.Errorf("comparisonDateRangeStart and comparisonDateRangeEnd require comparison=custom")
+	}
+
+	start, end := dateToTime(current.DateRangeStart), dateToTime(*current.DateRangeEnd)
+	var comparisonStart, comparisonEnd dto.Date
+	switch mode {
+	case comparisonPreviousPeriod:
+		days := int(end.Sub(start).Hours()/24) + 1
+		comparisonEnd = toDate(start.AddDate(0, 0, -1))
+		comparisonStart = toDate(start.AddDate(0, 0, -days))
+	case comparisonSamePeriodLastYear:
+		comparisonStart = shiftYears(current.DateRangeStart, -1)
+		comparisonEnd = shiftYears(*current.DateRangeEnd, -1)
+	case comparisonCustom:
+		if input.ComparisonDateRangeStart == nil || input.ComparisonDateRangeEnd == nil {
+			return dto.Input{}, fmt.Errorf("comparison=custom requires comparisonDateRangeStart and comparisonDateRangeEnd")
+		}
+		comparisonStart, comparisonEnd = *input.ComparisonDateRangeStart, *input.ComparisonDateRangeEnd
+		if err := validateCalendarDate("comparisonDateRangeStart", comparisonStart); err != nil {
+			return dto.Input{}, err
+		}
+		if err := validateCalendarDate("comparisonDateRangeEnd", comparisonEnd); err != nil {
+			return dto.Input{}, err
+		}
+		if dateBefore(comparisonEnd, comparisonStart) {
+			return dto.Input{}, fmt.Errorf("comparisonDateRangeEnd must be after comparisonDateRangeStart")
+		}
+	default:
+		return dto.Input{}, fmt.Errorf("invalid comparison: %s. Must be one of: %s, %s, %s", input.Comparison, comparisonPreviousPeriod, comparisonSamePeriodLastYear, comparisonCustom)
+	}
+
+	comparison := current
+	comparison.DateRangeStart = comparisonStart
+	comparison.DateRangeEnd = &comparisonEnd
+	comparison.RelativeDateRange = ""
+	return comparison, nil
+}
+
+// joinPeriods pairs elements of both periods that share pivot values and bucket offset. Rows
+// follow the current period's order; rows present only in the comparison period come last.
+func (t *Tool) joinPeriods(current, comparison dto.Input, currentResult, comparisonResult *reporting.AnalyticsResult) []dto.ComparisonRow {
+	pivots := pivotDimensions(current)
+	currentOutput := t.convertOutput(currentResult, pivots)
+	comparisonOutput := t.convertOutput(comparisonResult, pivots)
+
+	rows := make([]dto.ComparisonRow, 0, len(currentOutput.Elements))
+	currentMetrics := make([]map[string]interface{}, 0, len(currentOutput.Elements))
+	comparisonMetrics := make([]map[string]interface{}, 0, len(currentOutput.Elements))
+	index := make(map[string]int, len(currentOutput.Elements))
+
+	for _, element := range currentOutput.Elements {
+		offset := bucketOffset(current.TimeGranularity, current.DateRangeStart, element.DateRange)
+		key := joinKey(element.PivotValues, offset)
+		if _, exists := index[key]; exists {
+			continue
+		}
+		index[key] = len(rows)
+		rows = append(rows, dto.ComparisonRow{
+			PivotValues:      element.PivotValues,
+			BucketOffset:     offset,
+			CurrentDateRange: element.DateRange,
+		})
+		currentMetrics = append(currentMetrics, element.Metrics)
+		comparisonMetrics = append(comparisonMetrics, nil)
+	}
+
+	for _, element := range comparisonOutput.Elements {
+		offset := bucketOffset(comparison.TimeGranularity, comparison.DateRangeStart, element.DateRange)
+		key := joinKey(element.PivotValues, offset)
+		if i, exists := index[key]; exists {
+			if comparisonMetrics[i] == nil {
+				rows[i].ComparisonDateRange = element.DateRange
+				comparisonMetrics[i] = element.Metrics
+			}
+			continue
+		}
+		index[key] = len(rows)
+		rows = append(rows, dto.ComparisonRow{
+			PivotValues:         element.PivotValues,
+			BucketOffset:        offset,
+			ComparisonDateRange: element.DateRange,
+		})
+		currentMetrics = append(currentMetrics, nil)
+		comparisonMetrics = append(comparisonMetrics, element.Metrics)
+	}
+
+	for i := range rows {
+		rows[i].Metrics = metricDeltas(currentMetrics[i], comparisonMetrics[i])
+	}
+	return rows
+}
+
+// metricDeltas computes the delta of every numeric metric present in either period. Derived
+// metrics that were undefined (nil) in one period keep a null value and null changes.
+func metricDeltas(current, comparison map[string]interface{}) map[string]dto.MetricDelta {
+	names := make([]string, 0, len(current)+len(comparison))
+	seen := make(map[string]struct{}, len(current)+len(comparison))
+	for _, metrics := range []map[string]interface{}{current, comparison} {
+		for name, value := range metrics {
+			if _, exists := seen[name]; exists || !isMetricValue(value) {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	deltas := make(map[string]dto.MetricDelta, len(names))
+	for _, name := range names {
+		var delta dto.MetricDelta
+		if value, ok := metricAsFloat(current[name]); ok {
+			delta.Current = &value
+		}
+		if value, ok := metricAsFloat(comparison[name]); ok {
+			delta.Comparison = &value
+		}
+		if delta.Current != nil && delta.Comparison != nil {
+			change := *delta.Current - *delta.Comparison
+			delta.AbsoluteChange = &change
+			if *delta.Comparison != 0 {
+				percent := change / math.Abs(*delta.Comparison) * 100
+				delta.PercentChange = &percent
+			}
+		}
+		deltas[name] = delta
+	}
+	return deltas
+}
+
+// isMetricValue reports whether value is a numeric metric, or nil for an undefined derived
+// metric. Metadata such as pivotValues and dateRange is skipped.
+func isMetricValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	_, ok := metricAsFloat(value)
+	return ok
+}
+
+// bucketOffset is the index of a time bucket counted from the start of its range, so the n-th
+// day (month, year) of one period lines up with the n-th of the other.
+func bucketOffset(granularity string, rangeStart dto.Date, bucket *dto.DateRange) int {
+	if bucket == nil {
+		return 0
+	}
+	switch granularity {
+	case "DAILY":
+		return int(dateToTime(bucket.Start).Sub(dateToTime(rangeStart)).Hours() / 24)
+	case "MONTHLY":
+		return (bucket.Start.Year-rangeStart.Year)*12 + bucket.Start.Month - rangeStart.Month
+	case "YEARLY":
+		return bucket.Start.Year - rangeStart.Year
+	default:
+		return 0
+	}
+}
+
+func joinKey(pivotValues []string, offset int) string {
+	return strings.Join(pivotValues, "\x00") + "\x00" + strconv.Itoa(offset)
+}
+
+// shiftYears moves a date by whole years, clamping February 29 to February 28.
+func shiftYears(date dto.Date, years int) dto.Date {
+	shifted := dto.Date{Year: date.Year + years, Month: date.Month, Day: date.Day}
+	if validateCalendarDate("", shifted) != nil {
+		shifted.Day--
+	}
+	return shifted
+}
+
+// injectComparisonLabels resolves the pivot values of every row once and writes the aligned
+// pivotLabels array.
+func (t *Tool) injectComparisonLabels(ctx context.Context, accountID string, output *dto.CompareOutput) {
+	if t.pivotLabels == nil {
+		return
+	}
+
+	var values []string
+	for _, row := range output.Rows {
+		values = append(values, row.PivotValues...)
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	names, warnings := t.pivotLabels.Resolve(ctx, accountID, values)
+	for i := range output.Rows {
+		output.Rows[i].PivotLabels = pivotlabels.Labels(output.Rows[i].PivotValues, names)
+	}
+	output.Warnings = append(output.Warnings, warnings...)
+}
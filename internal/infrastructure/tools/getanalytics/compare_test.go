@@ -0,0 +1,132 @@
+package getanalytics
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComparisonInput(t *testing.T) {
+	current := dto.Input{
+		AccountID:         "512247261",
+		DateRangeStart:    dto.Date{Year: 2024, Month: 3, Day: 1},
+		DateRangeEnd:      &dto.Date{Year: 2024, Month: 3, Day: 10},
+		RelativeDateRange: "month_to_date",
+		TimeGranularity:   "DAILY",
+	}
+
+	cases := map[string][2]dto.Date{
+		"":                      {{Year: 2024, Month: 2, Day: 20}, {Year: 2024, Month: 2, Day: 29}},
+		"previous_period":       {{Year: 2024, Month: 2, Day: 20}, {Year: 2024, Month: 2, Day: 29}},
+		"same_period_last_year": {{Year: 2023, Month: 3, Day: 1}, {Year: 2023, Month: 3, Day: 10}},
+	}
+	for mode, expected := range cases {
+		comparison, err := comparisonInput(current, dto.CompareInput{Comparison: mode})
+		require.NoError(t, err, mode)
+		require.Equal(t, expected[0], comparison.DateRangeStart, mode)
+		require.Equal(t, expected[1], *comparison.DateRangeEnd, mode)
+		require.Empty(t, comparison.RelativeDateRange, mode)
+		require.Equal(t, current.AccountID, comparison.AccountID, mode)
+	}
+
+	custom, err := comparisonInput(current, dto.CompareInput{
+		Comparison:               "custom",
+		ComparisonDateRangeStart: &dto.Date{Year: 2023, Month: 12, Day: 1},
+		ComparisonDateRangeEnd:   &dto.Date{Year: 2023, Month: 12, Day: 10},
+	})
+	require.NoError(t, err)
+	require.Equal(t, dto.Date{Year: 2023, Month: 12, Day: 1}, custom.DateRangeStart)
+}
+
+func TestComparisonInput_Rejects(t *testing.T) {
+	current := dto.Input{
+		DateRangeStart: dto.Date{Year: 2024, Month: 3, Day: 1},
+		DateRangeEnd:   &dto.Date{Year: 2024, Month: 3, Day: 10},
+	}
+
+	cases := map[string]dto.CompareInput{
+		"unknown mode":         {Comparison: "last_quarter"},
+		"custom without dates": {Comparison: "custom"},
+		"dates without custom": {ComparisonDateRangeStart: &dto.Date{Year: 2024, Month: 1, Day: 1}},
+		"custom end before start": {
+			Comparison:               "custom",
+			ComparisonDateRangeStart: &dto.Date{Year: 2024, Month: 1, Day: 10},
+			ComparisonDateRangeEnd:   &dto.Date{Year: 2024, Month: 1, Day: 1},
+		},
+	}
+	for name, input := range cases {
+		_, err := comparisonInput(current, input)
+		require.Error(t, err, name)
+	}
+
+	_, err := comparisonInput(dto.Input{DateRangeStart: current.DateRangeStart}, dto.CompareInput{})
+	require.Error(t, err, "open-ended current range")
+}
+
+func TestShiftYears_ClampsLeapDay(t *testing.T) {
+	require.Equal(t, dto.Date{Year: 2023, Month: 2, Day: 28}, shiftYears(dto.Date{Year: 2024, Month: 2, Day: 29}, -1))
+	require.Equal(t, dto.Date{Year: 2023, Month: 6, Day: 15}, shiftYears(dto.Date{Year: 2024, Month: 6, Day: 15}, -1))
+}
+
+func TestBucketOffset(t *testing.T) {
+	start := dto.Date{Year: 2024, Month: 11, Day: 30}
+	bucket := &dto.DateRange{Start: dto.Date{Year: 2025, Month: 1, Day: 2}}
+
+	require.Equal(t, 33, bucketOffset("DAILY", start, bucket))
+	require.Equal(t, 2, bucketOffset("MONTHLY", start, bucket))
+	require.Equal(t, 1, bucketOffset("YEARLY", start, bucket))
+	require.Equal(t, 0, bucketOffset("ALL", start, bucket))
+	require.Equal(t, 0, bucketOffset("DAILY", start, nil))
+}
+
+func TestJoinPeriods_MatchesPivotAndBucket(t *testing.T) {
+	tool := &Tool{}
+	current := dto.Input{
+		Pivot:           "CAMPAIGN",
+		TimeGranularity: "DAILY",
+		DateRangeStart:  dto.Date{Year: 2025, Month: 3, Day: 8},
+	}
+	comparison := current
+	comparison.DateRangeStart = dto.Date{Year: 2025, Month: 3, Day: 1}
+
+	day := func(d int) *reporting.DateRange {
+		return &reporting.DateRange{Start: reporting.Date{Year: 2025, Month: 3, Day: d}, End: &reporting.Date{Year: 2025, Month: 3, Day: d}}
+	}
+	currentResult := &reporting.AnalyticsResult{Elements: []reporting.AnalyticsElement{
+		{PivotValues: []string{"urn:li:sponsoredCampaign:1"}, DateRange: day(8), Metrics: map[string]interface{}{"clicks": float64(30), "costInLocalCurrency": "15.0", "costPerClick": 0.5}},
+		{PivotValues: []string{"urn:li:sponsoredCampaign:2"}, DateRange: day(9), Metrics: map[string]interface{}{"clicks": float64(5), "costPerClick": nil}},
+	}}
+	comparisonResult := &reporting.AnalyticsResult{Elements: []reporting.AnalyticsElement{
+		{PivotValues: []string{"urn:li:sponsoredCampaign:1"}, DateRange: day(1), Metrics: map[string]interface{}{"clicks": float64(20), "costInLocalCurrency": "10.0", "costPerClick": 0.5}},
+		{PivotValues: []string{"urn:li:sponsoredCampaign:2"}, DateRange: day(2), Metrics: map[string]interface{}{"clicks": float64(0)}},
+		{PivotValues: []string{"urn:li:sponsoredCampaign:3"}, DateRange: day(1), Metrics: map[string]interface{}{"clicks": float64(7)}},
+	}}
+
+	rows := tool.joinPeriods(current, comparison, currentResult, comparisonResult)
+	require.Len(t, rows, 3)
+
+	first := rows[0]
+	require.Equal(t, 0, first.BucketOffset)
+	require.Equal(t, 8, first.CurrentDateRange.Start.Day)
+	require.Equal(t, 1, first.ComparisonDateRange.Start.Day)
+	require.InDelta(t, 10, *first.Metrics["clicks"].AbsoluteChange, 1e-9)
+	require.InDelta(t, 50, *first.Metrics["clicks"].PercentChange, 1e-9)
+	require.InDelta(t, 5, *first.Metrics["costInLocalCurrency"].AbsoluteChange, 1e-9)
+	require.InDelta(t, 0, *first.Metrics["costPerClick"].PercentChange, 1e-9)
+
+	second := rows[1]
+	require.Equal(t, 1, second.BucketOffset)
+	require.InDelta(t, 5, *second.Metrics["clicks"].AbsoluteChange, 1e-9)
+	require.Nil(t, second.Metrics["clicks"].PercentChange, "zero comparison has no percentage")
+	require.Nil(t, second.Metrics["costPerClick"].Current)
+	require.Nil(t, second.Metrics["costPerClick"].AbsoluteChange)
+
+	onlyComparison := rows[2]
+	require.Equal(t, []string{"urn:li:sponsoredCampaign:3"}, onlyComparison.PivotValues)
+	require.Nil(t, onlyComparison.CurrentDateRange)
+	require.Nil(t, onlyComparison.Metrics["clicks"].Current)
+	require.InDelta(t, 7, *onlyComparison.Metrics["clicks"].Comparison, 1e-9)
+}
@@ -0,0 +1,87 @@
+package getanalytics
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+)
+
+// Named relative date ranges. Every range ends on a full calendar day in the resolved time
+// zone; the last_N_days ranges exclude today because its data is still incomplete.
+const (
+	relativeToday         = "today"
+	relativeYesterday     = "yesterday"
+	relativeLast7Days     = "last_7_days"
+	relativeLast30Days    = "last_30_days"
+	relativeMonthToDate   = "month_to_date"
+	relativeLastMonth     = "last_month"
+	relativeQuarterToDate = "quarter_to_date"
+	relativeYearToDate    = "year_to_date"
+)
+
+// resolveRelativeDateRange turns a named range into absolute start and end dates (both
+// inclusive, as LinkedIn expects) relative to now, which must already be in the target zone.
+func resolveRelativeDateRange(name string, now time.Time) (dto.Date, dto.Date, error) {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	yesterday := today.AddDate(0, 0, -1)
+
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case relativeToday:
+		return toDate(today), toDate(today), nil
+	case relativeYesterday:
+		return toDate(yesterday), toDate(yesterday), nil
+	case relativeLast7Days:
+		return toDate(today.AddDate(0, 0, -7)), toDate(yesterday), nil
+	case relativeLast30Days:
+		return toDate(today.AddDate(0, 0, -30)), toDate(yesterday), nil
+	case relativeMonthToDate:
+		return toDate(time.Date(year, month, 1, 0, 0, 0, 0, now.Location())), toDate(today), nil
+	case relativeLastMonth:
+		firstOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+		return toDate(firstOfMonth.AddDate(0, -1, 0)), toDate(firstOfMonth.AddDate(0, 0, -1)), nil
+	case relativeQuarterToDate:
+		quarterStart := time.Month((int(month)-1)/3*3 + 1)
+		return toDate(time.Date(year, quarterStart, 1, 0, 0, 0, 0, now.Location())), toDate(today), nil
+	case relativeYearToDate:
+		return toDate(time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())), toDate(today), nil
+	default:
+		return dto.Date{}, dto.Date{}, fmt.Errorf("invalid relativeDateRange: %s. Must be one of: %s", name, strings.Join([]string{
+			relativeToday, relativeYesterday, relativeLast7Days, relativeLast30Days,
+			relativeMonthToDate, relativeLastMonth, relativeQuarterToDate, relativeYearToDate,
+		}, ", "))
+	}
+}
+
+// validateCalendarDate rejects dates that do not exist, such as February 30 or month 13.
+func validateCalendarDate(field string, date dto.Date) error {
+	t := time.Date(date.Year, time.Month(date.Month), date.Day, 0, 0, 0, 0, time.UTC)
+	if date.Month < 1 || date.Month > 12 || t.Year() != date.Year || int(t.Month()) != date.Month || t.Day() != date.Day {
+		return fmt.Errorf("%s is not a valid calendar date: %04d-%02d-%02d", field, date.Year, date.Month, date.Day)
+	}
+	return nil
+}
+
+func dateBefore(a, b dto.Date) bool {
+	return dateToTime(a).Before(dateToTime(b))
+}
+
+func dateToTime(date dto.Date) time.Time {
+	return time.Date(date.Year, time.Month(date.Month), date.Day, 0, 0, 0, 0, time.UTC)
+}
+
+func toDate(t time.Time) dto.Date {
+	return dto.Date{Year: t.Year(), Month: int(t.Month()), Day: t.Day()}
+}
+
+// resolvedDateRange echoes the absolute range a normalized input covers.
+func resolvedDateRange(input dto.Input) *dto.ResolvedDateRange {
+	return &dto.ResolvedDateRange{
+		Start:             input.DateRangeStart,
+		End:               input.DateRangeEnd,
+		RelativeDateRange: input.RelativeDateRange,
+		TimeZone:          input.TimeZone,
+	}
+}
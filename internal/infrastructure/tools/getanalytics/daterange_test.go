@@ -0,0 +1,58 @@
+package getanalytics
+
+import (
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveRelativeDateRange(t *testing.T) {
+	// Wednesday 2026-03-04 10:00 local time.
+	now := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+
+	cases := map[string][2]dto.Date{
+		"today":           {{Year: 2026, Month: 3, Day: 4}, {Year: 2026, Month: 3, Day: 4}},
+		"yesterday":       {{Year: 2026, Month: 3, Day: 3}, {Year: 2026, Month: 3, Day: 3}},
+		"last_7_days":     {{Year: 2026, Month: 2, Day: 25}, {Year: 2026, Month: 3, Day: 3}},
+		"last_30_days":    {{Year: 2026, Month: 2, Day: 2}, {Year: 2026, Month: 3, Day: 3}},
+		"month_to_date":   {{Year: 2026, Month: 3, Day: 1}, {Year: 2026, Month: 3, Day: 4}},
+		"last_month":      {{Year: 2026, Month: 2, Day: 1}, {Year: 2026, Month: 2, Day: 28}},
+		"quarter_to_date": {{Year: 2026, Month: 1, Day: 1}, {Year: 2026, Month: 3, Day: 4}},
+		"year_to_date":    {{Year: 2026, Month: 1, Day: 1}, {Year: 2026, Month: 3, Day: 4}},
+		"LAST_MONTH":      {{Year: 2026, Month: 2, Day: 1}, {Year: 2026, Month: 2, Day: 28}},
+	}
+
+	for name, expected := range cases {
+		start, end, err := resolveRelativeDateRange(name, now)
+		require.NoError(t, err, name)
+		require.Equal(t, expected[0], start, name)
+		require.Equal(t, expected[1], end, name)
+	}
+
+	_, _, err := resolveRelativeDateRange("last_fortnight", now)
+	require.Error(t, err)
+}
+
+func TestResolveRelativeDateRange_UsesZoneOfNow(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// 2026-01-01 01:00 in Tokyo is still 2025-12-31 in UTC.
+	now := time.Date(2025, time.December, 31, 16, 0, 0, 0, time.UTC).In(tokyo)
+
+	start, end, err := resolveRelativeDateRange("yesterday", now)
+	require.NoError(t, err)
+	require.Equal(t, dto.Date{Year: 2025, Month: 12, Day: 31}, start)
+	require.Equal(t, start, end)
+}
+
+func TestValidateCalendarDate(t *testing.T) {
+	require.NoError(t, validateCalendarDate("dateRangeStart", dto.Date{Year: 2024, Month: 2, Day: 29}))
+	require.Error(t, validateCalendarDate("dateRangeStart", dto.Date{Year: 2025, Month: 2, Day: 29}))
+	require.Error(t, validateCalendarDate("dateRangeStart", dto.Date{Year: 2025, Month: 2, Day: 30}))
+	require.Error(t, validateCalendarDate("dateRangeEnd", dto.Date{Year: 2025, Month: 13, Day: 1}))
+	require.Error(t, validateCalendarDate("dateRangeEnd", dto.Date{Year: 2025, Month: 0, Day: 1}))
+}
@@ -1,16 +1,30 @@
 package getanalytics
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/expression"
 )
 
+// maxCustomMetrics caps the customMetrics a single call may define.
+const maxCustomMetrics = 20
+
 // derivedMetric defines a metric the MCP server computes client-side from raw
 // LinkedIn fields. These are arithmetic-only definitions so they do not need
 // to be maintained when LinkedIn evolves the AdAnalytics schema.
 type derivedMetric struct {
 	// Canonical name emitted in the response under element.Metrics.
 	Name string
+	// Formula documents the computation in the customMetrics expression
+	// syntax; it is published by the derived metrics resource.
+	Formula string
+	// Unit tells renderers how to format the value: unitCurrency,
+	// unitRate (a fraction shown as a percentage) or unitNumber.
+	Unit string
 	// Raw LinkedIn fields the computation depends on. These are unioned into
 	// the outbound request whenever the caller asks for a derived metric.
 	RequiredFields []string
@@ -20,42 +34,129 @@ type derivedMetric struct {
 	Compute func(metrics map[string]interface{}) (float64, bool)
 }
 
+// Units of derived metric values.
+const (
+	unitCurrency = "currency"
+	unitRate     = "rate"
+	unitNumber   = "number"
+)
+
 // Derived metric definitions. Keys must be canonical lowerCamelCase names.
 // Aliases (UPPER_SNAKE, shorthand) are declared separately in derivedAliases
 // so the canonical set stays small and self-contained.
 var derivedMetrics = map[string]derivedMetric{
 	"costPerClick": {
 		Name:           "costPerClick",
+		Formula:        "costInLocalCurrency / clicks",
+		Unit:           unitCurrency,
 		RequiredFields: []string{"costInLocalCurrency", "clicks"},
 		Compute:        ratio("costInLocalCurrency", "clicks"),
 	},
 	"clickThroughRate": {
 		Name:           "clickThroughRate",
+		Formula:        "clicks / impressions",
+		Unit:           unitRate,
 		RequiredFields: []string{"clicks", "impressions"},
 		Compute:        ratio("clicks", "impressions"),
 	},
 	"costPerLead": {
 		Name:           "costPerLead",
+		Formula:        "costInLocalCurrency / oneClickLeads",
+		Unit:           unitCurrency,
 		RequiredFields: []string{"costInLocalCurrency", "oneClickLeads"},
 		Compute:        ratio("costInLocalCurrency", "oneClickLeads"),
 	},
 	"costPerMille": {
 		Name:           "costPerMille",
+		Formula:        "costInLocalCurrency * 1000 / impressions",
+		Unit:           unitCurrency,
 		RequiredFields: []string{"costInLocalCurrency", "impressions"},
-		Compute: func(metrics map[string]interface{}) (float64, bool) {
-			cost, okCost := metricAsFloat(metrics["costInLocalCurrency"])
-			impressions, okImpr := metricAsFloat(metrics["impressions"])
-			if !okCost || !okImpr || impressions == 0 {
-				return 0, false
-			}
-			return cost * 1000.0 / impressions, true
-		},
+		Compute:        perThousand("costInLocalCurrency", "impressions"),
 	},
 	"videoCompletionRate": {
 		Name:           "videoCompletionRate",
+		Formula:        "videoCompletions / videoViews",
+		Unit:           unitRate,
 		RequiredFields: []string{"videoCompletions", "videoViews"},
 		Compute:        ratio("videoCompletions", "videoViews"),
 	},
+	"conversionRate": {
+		Name:           "conversionRate",
+		Formula:        "externalWebsiteConversions / clicks",
+		Unit:           unitRate,
+		RequiredFields: []string{"externalWebsiteConversions", "clicks"},
+		Compute:        ratio("externalWebsiteConversions", "clicks"),
+	},
+	"costPerConversion": {
+		Name:           "costPerConversion",
+		Formula:        "costInLocalCurrency / externalWebsiteConversions",
+		Unit:           unitCurrency,
+		RequiredFields: []string{"costInLocalCurrency", "externalWebsiteConversions"},
+		Compute:        ratio("costInLocalCurrency", "externalWebsiteConversions"),
+	},
+	"returnOnAdSpend": {
+		Name:           "returnOnAdSpend",
+		Formula:        "conversionValueInLocalCurrency / costInLocalCurrency",
+		Unit:           unitNumber,
+		RequiredFields: []string{"conversionValueInLocalCurrency", "costInLocalCurrency"},
+		Compute:        ratio("conversionValueInLocalCurrency", "costInLocalCurrency"),
+	},
+	"engagementRate": {
+		Name:           "engagementRate",
+		Formula:        "totalEngagements / impressions",
+		Unit:           unitRate,
+		RequiredFields: []string{"totalEngagements", "impressions"},
+		Compute:        ratio("totalEngagements", "impressions"),
+	},
+	"frequency": {
+		Name:           "frequency",
+		Formula:        "impressions / approximateMemberReach",
+		Unit:           unitNumber,
+		RequiredFields: []string{"impressions", "approximateMemberReach"},
+		Compute:        ratio("impressions", "approximateMemberReach"),
+	},
+	"leadFormCompletionRate": {
+		Name:           "leadFormCompletionRate",
+		Formula:        "oneClickLeads / oneClickLeadFormOpens",
+		Unit:           unitRate,
+		RequiredFields: []string{"oneClickLeads", "oneClickLeadFormOpens"},
+		Compute:        ratio("oneClickLeads", "oneClickLeadFormOpens"),
+	},
+	"costPerVideoView": {
+		Name:           "costPerVideoView",
+		Formula:        "costInLocalCurrency / videoViews",
+		Unit:           unitCurrency,
+		RequiredFields: []string{"costInLocalCurrency", "videoViews"},
+		Compute:        ratio("costInLocalCurrency", "videoViews"),
+	},
+	"costPerThousandReach": {
+		Name:           "costPerThousandReach",
+		Formula:        "costInLocalCurrency * 1000 / approximateMemberReach",
+		Unit:           unitCurrency,
+		RequiredFields: []string{"costInLocalCurrency", "approximateMemberReach"},
+		Compute:        perThousand("costInLocalCurrency", "approximateMemberReach"),
+	},
+	"landingPageClickThroughRate": {
+		Name:           "landingPageClickThroughRate",
+		Formula:        "landingPageClicks / impressions",
+		Unit:           unitRate,
+		RequiredFields: []string{"landingPageClicks", "impressions"},
+		Compute:        ratio("landingPageClicks", "impressions"),
+	},
+	"inMailOpenRate": {
+		Name:           "inMailOpenRate",
+		Formula:        "opens / sends",
+		Unit:           unitRate,
+		RequiredFields: []string{"opens", "sends"},
+		Compute:        ratio("opens", "sends"),
+	},
+	"inMailClickRate": {
+		Name:           "inMailClickRate",
+		Formula:        "clicks / opens",
+		Unit:           unitRate,
+		RequiredFields: []string{"clicks", "opens"},
+		Compute:        ratio("clicks", "opens"),
+	},
 }
 
 // derivedAliases maps user-supplied field names (any case/underscore variant)
@@ -63,22 +164,82 @@ var derivedMetrics = map[string]derivedMetric{
 // raw-field passthrough path so LinkedIn remains the source of truth for
 // schema fields.
 var derivedAliases = map[string]string{
-	"COST_PER_CLICK":        "costPerClick",
-	"COSTPERCLICK":          "costPerClick",
-	"CPC":                   "costPerClick",
-	"CLICK_THROUGH_RATE":    "clickThroughRate",
-	"CLICKTHROUGHRATE":      "clickThroughRate",
-	"CLICK_THRU_RATE":       "clickThroughRate",
-	"CTR":                   "clickThroughRate",
-	"COST_PER_LEAD":         "costPerLead",
-	"COSTPERLEAD":           "costPerLead",
-	"CPL":                   "costPerLead",
-	"COST_PER_MILLE":        "costPerMille",
-	"COSTPERMILLE":          "costPerMille",
-	"CPM":                   "costPerMille",
-	"CPA":                   "costPerLead", // common interchangeable shorthand for cost per action/lead
-	"VIDEO_COMPLETION_RATE": "videoCompletionRate",
-	"VIDEOCOMPLETIONRATE":   "videoCompletionRate",
+	"COST_PER_CLICK":                  "costPerClick",
+	"COSTPERCLICK":                    "costPerClick",
+	"CPC":                             "costPerClick",
+	"CLICK_THROUGH_RATE":              "clickThroughRate",
+	"CLICKTHROUGHRATE":                "clickThroughRate",
+	"CLICK_THRU_RATE":                 "clickThroughRate",
+	"CTR":                             "clickThroughRate",
+	"COST_PER_LEAD":                   "costPerLead",
+	"COSTPERLEAD":                     "costPerLead",
+	"CPL":                             "costPerLead",
+	"COST_PER_MILLE":                  "costPerMille",
+	"COSTPERMILLE":                    "costPerMille",
+	"CPM":                             "costPerMille",
+	"CPA":                             "costPerLead", // common interchangeable shorthand for cost per action/lead
+	"VIDEO_COMPLETION_RATE":           "videoCompletionRate",
+	"VIDEOCOMPLETIONRATE":             "videoCompletionRate",
+	"CONVERSION_RATE":                 "conversionRate",
+	"CONVERSIONRATE":                  "conversionRate",
+	"CVR":                             "conversionRate",
+	"COST_PER_CONVERSION":             "costPerConversion",
+	"COSTPERCONVERSION":               "costPerConversion",
+	"RETURN_ON_AD_SPEND":              "returnOnAdSpend",
+	"RETURNONADSPEND":                 "returnOnAdSpend",
+	"ROAS":                            "returnOnAdSpend",
+	"ENGAGEMENT_RATE":                 "engagementRate",
+	"ENGAGEMENTRATE":                  "engagementRate",
+	"FREQUENCY":                       "frequency",
+	"AVERAGE_FREQUENCY":               "frequency",
+	"LEAD_FORM_COMPLETION_RATE":       "leadFormCompletionRate",
+	"LEADFORMCOMPLETIONRATE":          "leadFormCompletionRate",
+	"COST_PER_VIDEO_VIEW":             "costPerVideoView",
+	"COSTPERVIDEOVIEW":                "costPerVideoView",
+	"CPV":                             "costPerVideoView",
+	"COST_PER_THOUSAND_REACH":         "costPerThousandReach",
+	"COSTPERTHOUSANDREACH":            "costPerThousandReach",
+	"COST_PER_1K_REACH":               "costPerThousandReach",
+	"LANDING_PAGE_CLICK_THROUGH_RATE": "landingPageClickThroughRate",
+	"LANDINGPAGECLICKTHROUGHRATE":     "landingPageClickThroughRate",
+	"LANDING_PAGE_CTR":                "landingPageClickThroughRate",
+	"INMAIL_OPEN_RATE":                "inMailOpenRate",
+	"INMAILOPENRATE":                  "inMailOpenRate",
+	"OPEN_RATE":                       "inMailOpenRate",
+	"INMAIL_CLICK_RATE":               "inMailClickRate",
+	"INMAILCLICKRATE":                 "inMailClickRate",
+}
+
+// DerivedMetricInfo describes a derived metric for documentation.
+type DerivedMetricInfo struct {
+	Name           string
+	Aliases        []string
+	Formula        string
+	Unit           string
+	RequiredFields []string
+}
+
+// BuiltinDerivedMetrics lists the built-in derived metrics sorted by name, with their
+// aliases, formula and required raw fields.
+func BuiltinDerivedMetrics() []DerivedMetricInfo {
+	aliases := map[string][]string{}
+	for alias, name := range derivedAliases {
+		aliases[name] = append(aliases[name], alias)
+	}
+
+	infos := make([]DerivedMetricInfo, 0, len(derivedMetrics))
+	for name, metric := range derivedMetrics {
+		sort.Strings(aliases[name])
+		infos = append(infos, DerivedMetricInfo{
+			Name:           metric.Name,
+			Aliases:        aliases[name],
+			Formula:        metric.Formula,
+			Unit:           metric.Unit,
+			RequiredFields: metric.RequiredFields,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
 }
 
 // lookupDerivedMetric resolves a user-supplied field name to a derived metric
@@ -114,6 +275,19 @@ func ratio(numeratorKey, denominatorKey string) func(metrics map[string]interfac
 	}
 }
 
+// perThousand builds a Compute function for cost-per-thousand style metrics:
+// numeratorKey * 1000 / denominatorKey, undefined on a zero denominator.
+func perThousand(numeratorKey, denominatorKey string) func(metrics map[string]interface{}) (float64, bool) {
+	return func(metrics map[string]interface{}) (float64, bool) {
+		numerator, okNum := metricAsFloat(metrics[numeratorKey])
+		denominator, okDen := metricAsFloat(metrics[denominatorKey])
+		if !okNum || !okDen || denominator == 0 {
+			return 0, false
+		}
+		return numerator * 1000.0 / denominator, true
+	}
+}
+
 // metricAsFloat coerces a LinkedIn metric value (number, numeric string) to a
 // float64. LinkedIn returns monetary amounts as JSON strings (e.g. "17.16")
 // and counts as numbers (e.g. 677), so both shapes must be accepted.
@@ -143,3 +317,182 @@ func metricAsFloat(value interface{}) (float64, bool) {
 		return 0, false
 	}
 }
+
+// reservedMetricNames are the adAnalytics metric fields and element keys a derived metric
+// may not be named after.
+// Reference: https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads-reporting/ads-reporting-schema
+var reservedMetricNames = map[string]struct{}{
+	"actionClicks":                        {},
+	"adUnitClicks":                        {},
+	"approximateMemberReach":              {},
+	"approximateUniqueImpressions":        {},
+	"cardClicks":                          {},
+	"cardImpressions":                     {},
+	"clicks":                              {},
+	"commentLikes":                        {},
+	"comments":                            {},
+	"companyPageClicks":                   {},
+	"conversionValueInLocalCurrency":      {},
+	"costInLocalCurrency":                 {},
+	"costInUsd":                           {},
+	"dateRange":                           {},
+	"documentCompletions":                 {},
+	"documentFirstQuartileCompletions":    {},
+	"documentMidpointCompletions":         {},
+	"documentThirdQuartileCompletions":    {},
+	"downloadClicks":                      {},
+	"externalWebsiteConversions":          {},
+	"externalWebsitePostClickConversions": {},
+	"externalWebsitePostViewConversions":  {},
+	"follows":                             {},
+	"fullScreenPlays":                     {},
+	"impressions":                         {},
+	"jobApplications":                     {},
+	"jobApplyClicks":                      {},
+	"landingPageClicks":                   {},
+	"leadGenerationMailContactInfoShares": {},
+	"leadGenerationMailInterestedClicks":  {},
+	"likes":                               {},
+	"oneClickLeadFormOpens":               {},
+	"oneClickLeads":                       {},
+	"opens":                               {},
+	"otherEngagements":                    {},
+	"pivotValues":                         {},
+	"qualifiedLeads":                      {},
+	"reactions":                           {},
+	"registrations":                       {},
+	"sends":                               {},
+	"shares":                              {},
+	"talentLeads":                         {},
+	"textUrlClicks":                       {},
+	"totalEngagements":                    {},
+	"validWorkEmailLeads":                 {},
+	"videoCompletions":                    {},
+	"videoFirstQuartileCompletions":       {},
+	"videoMidpointCompletions":            {},
+	"videoStarts":                         {},
+	"videoThirdQuartileCompletions":       {},
+	"videoViews":                          {},
+	"viralClicks":                         {},
+	"viralImpressions":                    {},
+	"viralTotalEngagements":               {},
+}
+
+// expressionMetric compiles an arithmetic expression over raw LinkedIn fields into a derived
+// metric. The raw fields the expression reads become its RequiredFields, and undefined
+// results (zero divisor, missing field) are emitted as nil like the built-in ratios.
+func expressionMetric(name, source string) (derivedMetric, error) {
+	name = strings.TrimSpace(name)
+	if !expression.IsIdentifier(name) {
+		return derivedMetric{}, fmt.Errorf("invalid metric name %q: use letters, digits and underscores, starting with a letter", name)
+	}
+	if _, builtin := lookupDerivedMetric(name); builtin {
+		return derivedMetric{}, fmt.Errorf("metric name %q is already a built-in derived metric", name)
+	}
+	// Derived values are written into the same metrics map as the raw fields, so a metric
+	// named after one would overwrite the raw column the other metrics and totals read.
+	if _, raw := reservedMetricNames[name]; raw {
+		return derivedMetric{}, fmt.Errorf("metric name %q is a raw LinkedIn field", name)
+	}
+
+	expr, err := expression.Parse(source)
+	if err != nil {
+		return derivedMetric{}, fmt.Errorf("metric %s: %w", name, err)
+	}
+	fields := expr.Fields()
+	if len(fields) == 0 {
+		return derivedMetric{}, fmt.Errorf("metric %s: expression must reference at least one raw field", name)
+	}
+	for _, field := range fields {
+		if _, derived := lookupDerivedMetric(field); derived {
+			return derivedMetric{}, fmt.Errorf("metric %s: expressions may only reference raw LinkedIn fields, %s is a derived metric", name, field)
+		}
+		if field == name {
+			return derivedMetric{}, fmt.Errorf("metric %s: expression cannot reference the metric itself", name)
+		}
+	}
+
+	return derivedMetric{
+		Name:           name,
+		Formula:        expr.String(),
+		Unit:           unitNumber,
+		RequiredFields: fields,
+		Compute: func(metrics map[string]interface{}) (float64, bool) {
+			return expr.Evaluate(func(field string) (float64, bool) {
+				return metricAsFloat(metrics[field])
+			})
+		},
+	}, nil
+}
+
+// ConfiguredDerivedMetrics describes operator-defined derived metrics (name to expression)
+// sorted by name.
+func ConfiguredDerivedMetrics(definitions map[string]string) ([]DerivedMetricInfo, error) {
+	infos := make([]DerivedMetricInfo, 0, len(definitions))
+	for name, source := range definitions {
+		metric, err := expressionMetric(name, source)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, DerivedMetricInfo{
+			Name:           metric.Name,
+			Formula:        metric.Formula,
+			Unit:           metric.Unit,
+			RequiredFields: metric.RequiredFields,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// ValidateDerivedMetrics checks operator-defined derived metrics (name to expression) so a
+// bad definition fails at startup rather than on every get_analytics call.
+func ValidateDerivedMetrics(definitions map[string]string) error {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := expressionMetric(name, definitions[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// derivedCatalog returns the derived metrics a call may use beyond the built-ins, keyed by
+// name: the operator's configured expressions and then the caller's customMetrics, which
+// may not redefine either.
+func (t *Tool) derivedCatalog(customMetrics []dto.CustomMetric) (map[string]derivedMetric, error) {
+	if len(customMetrics) > maxCustomMetrics {
+		return nil, fmt.Errorf("customMetrics cannot exceed %d entries", maxCustomMetrics)
+	}
+
+	catalog := make(map[string]derivedMetric, len(t.settings.DerivedMetrics)+len(customMetrics))
+	for name, source := range t.settings.DerivedMetrics {
+		metric, err := expressionMetric(name, source)
+		if err != nil {
+			return nil, fmt.Errorf("server derived metric: %w", err)
+		}
+		catalog[metric.Name] = metric
+	}
+	for i, custom := range customMetrics {
+		metric, err := expressionMetric(custom.Name, custom.Expression)
+		if err != nil {
+			return nil, fmt.Errorf("customMetrics[%d]: %w", i, err)
+		}
+		if _, exists := catalog[metric.Name]; exists {
+			return nil, fmt.Errorf("customMetrics[%d]: metric name %q is already defined", i, metric.Name)
+		}
+		catalog[metric.Name] = metric
+	}
+	for _, metric := range catalog {
+		for _, field := range metric.RequiredFields {
+			if _, derived := catalog[field]; derived {
+				return nil, fmt.Errorf("metric %s: expressions may only reference raw LinkedIn fields, %s is a derived metric", metric.Name, field)
+			}
+		}
+	}
+	return catalog, nil
+}
@@ -0,0 +1,104 @@
+package getanalytics
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/expression"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Every built-in formula must describe exactly what Compute does, since the derived metrics
+// resource publishes it.
+func TestDerivedMetrics_FormulasMatchCompute(t *testing.T) {
+	sample := map[string]interface{}{}
+	values := map[string]float64{}
+	for _, metric := range derivedMetrics {
+		for j, field := range metric.RequiredFields {
+			if _, exists := values[field]; !exists {
+				values[field] = float64(len(values)*7 + j + 3)
+				sample[field] = values[field]
+			}
+		}
+	}
+
+	for name, metric := range derivedMetrics {
+		require.Equal(t, name, metric.Name)
+		require.Contains(t, []string{unitCurrency, unitRate, unitNumber}, metric.Unit, name)
+
+		expr, err := expression.Parse(metric.Formula)
+		require.NoError(t, err, name)
+		require.ElementsMatch(t, metric.RequiredFields, expr.Fields(), name)
+
+		want, ok := expr.Evaluate(func(field string) (float64, bool) {
+			value, exists := values[field]
+			return value, exists
+		})
+		require.True(t, ok, name)
+		got, ok := metric.Compute(sample)
+		require.True(t, ok, name)
+		require.InDelta(t, want, got, 1e-9, name)
+	}
+}
+
+func TestDerivedAliases_PointAtKnownMetrics(t *testing.T) {
+	for alias, name := range derivedAliases {
+		_, ok := derivedMetrics[name]
+		require.True(t, ok, alias)
+	}
+}
+
+func TestLookupDerivedMetric_NewCatalogueEntries(t *testing.T) {
+	cases := map[string]string{
+		"ROAS":                      "returnOnAdSpend",
+		"frequency":                 "frequency",
+		"landing_page_ctr":          "landingPageClickThroughRate",
+		"inMailOpenRate":            "inMailOpenRate",
+		"COST_PER_1K_REACH":         "costPerThousandReach",
+		"LEAD_FORM_COMPLETION_RATE": "leadFormCompletionRate",
+	}
+	for input, expected := range cases {
+		metric, ok := lookupDerivedMetric(input)
+		require.True(t, ok, input)
+		require.Equal(t, expected, metric.Name, input)
+	}
+}
+
+func TestBuiltinDerivedMetrics(t *testing.T) {
+	infos := BuiltinDerivedMetrics()
+	require.Len(t, infos, len(derivedMetrics))
+	for i := 1; i < len(infos); i++ {
+		require.Less(t, infos[i-1].Name, infos[i].Name)
+	}
+	for _, info := range infos {
+		if info.Name == "returnOnAdSpend" {
+			require.Equal(t, []string{"RETURNONADSPEND", "RETURN_ON_AD_SPEND", "ROAS"}, info.Aliases)
+			require.Equal(t, "conversionValueInLocalCurrency / costInLocalCurrency", info.Formula)
+		}
+	}
+}
+
+func TestExpressionMetric_RejectsRawFieldAndSelfReferencingNames(t *testing.T) {
+	for _, tc := range []struct {
+		name, source, err string
+	}{
+		{name: "clicks", source: "clicks * 2", err: "is a raw LinkedIn field"},
+		{name: "impressions", source: "landingPageClicks / 2", err: "is a raw LinkedIn field"},
+		{name: "pivotValues", source: "clicks", err: "is a raw LinkedIn field"},
+		{name: "boostedLeads", source: "boostedLeads * 2", err: "cannot reference the metric itself"},
+	} {
+		_, err := expressionMetric(tc.name, tc.source)
+		require.ErrorContains(t, err, tc.err, tc.name)
+	}
+
+	_, err := expressionMetric("doubleClicks", "clicks * 2")
+	require.NoError(t, err)
+}
+
+func TestDerivedCatalog_RejectsMetricsReadingOtherCustomMetrics(t *testing.T) {
+	tool := &Tool{settings: Settings{DerivedMetrics: map[string]string{"leadCost": "costInLocalCurrency / oneClickLeads"}}}
+
+	_, err := tool.derivedCatalog([]dto.CustomMetric{{Name: "leadCostDoubled", Expression: "leadCost * 2"}})
+	require.ErrorContains(t, err, "leadCost is a derived metric")
+}
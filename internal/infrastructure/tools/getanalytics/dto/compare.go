@@ -0,0 +1,31 @@
+package dto
+
+type CompareInput struct {
+	Input
+	Comparison               string `json:"comparison,omitempty" jsonschema:"How the comparison range is chosen: previous_period (default; the same number of days immediately before the current range), same_period_last_year, or custom (requires comparisonDateRangeStart and comparisonDateRangeEnd)"`
+	ComparisonDateRangeStart *Date  `json:"comparisonDateRangeStart,omitempty" jsonschema:"Start of the comparison range when comparison is custom"`
+	ComparisonDateRangeEnd   *Date  `json:"comparisonDateRangeEnd,omitempty" jsonschema:"End of the comparison range (inclusive) when comparison is custom"`
+}
+
+type CompareOutput struct {
+	Current    ResolvedDateRange `json:"current" jsonschema:"Date range of the current period"`
+	Comparison ResolvedDateRange `json:"comparison" jsonschema:"Date range of the comparison period"`
+	Rows       []ComparisonRow   `json:"rows" jsonschema:"One row per pivot value and bucket offset present in either period"`
+	Warnings   []string          `json:"warnings,omitempty" jsonschema:"Non-fatal problems, e.g. pivot names that could not be resolved or truncated pages"`
+}
+
+type ComparisonRow struct {
+	PivotValues         []string               `json:"pivotValues,omitempty" jsonschema:"Pivot values shared by both periods (URNs)"`
+	PivotLabels         []string               `json:"pivotLabels,omitempty" jsonschema:"Display names aligned with pivotValues when resolveNames is set"`
+	BucketOffset        int                    `json:"bucketOffset" jsonschema:"Index of the time bucket from the start of each range (0 for timeGranularity ALL); rows are joined on it"`
+	CurrentDateRange    *DateRange             `json:"currentDateRange,omitempty" jsonschema:"Bucket covered in the current period"`
+	ComparisonDateRange *DateRange             `json:"comparisonDateRange,omitempty" jsonschema:"Bucket covered in the comparison period"`
+	Metrics             map[string]MetricDelta `json:"metrics" jsonschema:"Per-metric values and deltas, keyed by metric name"`
+}
+
+type MetricDelta struct {
+	Current        *float64 `json:"current" jsonschema:"Value in the current period; null when missing or undefined"`
+	Comparison     *float64 `json:"comparison" jsonschema:"Value in the comparison period; null when missing or undefined"`
+	AbsoluteChange *float64 `json:"absoluteChange" jsonschema:"current - comparison; null unless both values are present"`
+	PercentChange  *float64 `json:"percentChange" jsonschema:"(current - comparison) / |comparison| * 100; null when comparison is zero or missing"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+type ExportInput struct {
+	Input
+	Format     string `json:"format,omitempty" jsonschema:"Output format: csv (default), tsv or markdown"`
+	AsResource bool   `json:"asResource,omitempty" jsonschema:"When true, always publish the export as a linkedin://exports/{id} resource instead of returning it inline"`
+}
+
+type ExportOutput struct {
+	Format            string             `json:"format" jsonschema:"Rendered format"`
+	MIMEType          string             `json:"mimeType" jsonschema:"Media type of the rendered export"`
+	Columns           []string           `json:"columns" jsonschema:"Column headers in order: date bucket, one column per pivot (plus a _LABEL column when resolveNames is set), then each metric in requested field order"`
+	RowCount          int                `json:"rowCount" jsonschema:"Number of data rows"`
+	Content           string             `json:"content,omitempty" jsonschema:"Rendered export when it is small enough to return inline"`
+	ResourceURI       string             `json:"resourceURI,omitempty" jsonschema:"Resource to read for the rendered export when it is too large to return inline"`
+	ExpiresAt         string             `json:"expiresAt,omitempty" jsonschema:"RFC3339 time after which resourceURI can no longer be read"`
+	ResolvedDateRange *ResolvedDateRange `json:"resolvedDateRange,omitempty" jsonschema:"Absolute date range the export covers"`
+	Pagination        *Pagination        `json:"pagination,omitempty" jsonschema:"Auto-pagination summary, present when autoPaginate is set"`
+	Warnings          []string           `json:"warnings,omitempty" jsonschema:"Non-fatal problems, e.g. pivot names that could not be resolved"`
+}
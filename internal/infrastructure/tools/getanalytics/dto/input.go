@@ -1,20 +1,28 @@
 package dto
 
 type Input struct {
-	AccountID       string   `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric value, e.g., 512345678) - used as accounts facet in query"`
-	Pivot           string   `json:"pivot,omitempty" jsonschema:"Pivot of results: COMPANY, ACCOUNT, SHARE, CAMPAIGN, CREATIVE, CAMPAIGN_GROUP, CONVERSION, CONVERSATION_NODE, CONVERSATION_NODE_OPTION_INDEX, SERVING_LOCATION, CARD_INDEX, MEMBER_COMPANY_SIZE, MEMBER_INDUSTRY, MEMBER_SENIORITY, MEMBER_JOB_TITLE, MEMBER_JOB_FUNCTION, MEMBER_COUNTRY_V2, MEMBER_REGION_V2, MEMBER_COMPANY, PLACEMENT_NAME, IMPRESSION_DEVICE_TYPE, EVENT_STAGE. Note: when combined with a non-ALL timeGranularity, LinkedIn may return one aggregated element per pivot value instead of one per time bucket — if you need time-series results, prefer calling once per bucket or omitting pivot."`
-	DateRangeStart  Date     `json:"dateRangeStart" jsonschema:"Start date for analytics (required)"`
-	DateRangeEnd    *Date    `json:"dateRangeEnd,omitempty" jsonschema:"End date for analytics (optional)"`
-	TimeGranularity string   `json:"timeGranularity" jsonschema:"Time granularity: ALL (single aggregate across the full range), DAILY, MONTHLY, YEARLY. For bucketed granularities (DAILY/MONTHLY/YEARLY) the server automatically projects dateRange on each element so the caller can identify which bucket each row covers. When combined with a pivot, LinkedIn may collapse buckets into a single per-pivot aggregate (required)."`
-	CampaignType    string   `json:"campaignType,omitempty" jsonschema:"Campaign type: TEXT_AD, SPONSORED_UPDATES, SPONSORED_INMAILS, DYNAMIC"`
-	Shares          []string `json:"shares,omitempty" jsonschema:"Array of Share URNs"`
-	Campaigns       []string `json:"campaigns,omitempty" jsonschema:"Array of Campaign URNs (urn:li:sponsoredCampaign:{id})"`
-	CampaignGroups  []string `json:"campaignGroups,omitempty" jsonschema:"Array of Campaign Group URNs (urn:li:sponsoredCampaignGroup:{id})"`
-	Accounts        []string `json:"accounts,omitempty" jsonschema:"Array of Account URNs (urn:li:sponsoredAccount:{id})"`
-	Companies       []string `json:"companies,omitempty" jsonschema:"Array of Organization URNs (urn:li:organization:{id})"`
-	SortByField     string   `json:"sortByField,omitempty" jsonschema:"Field to sort by: COST_IN_LOCAL_CURRENCY, IMPRESSIONS, CLICKS, ONE_CLICK_LEADS, OPENS, SENDS, EXTERNAL_WEBSITE_CONVERSIONS"`
-	SortByOrder     string   `json:"sortByOrder,omitempty" jsonschema:"Sort order: ASCENDING, DESCENDING"`
-	Fields          []string `json:"fields" jsonschema:"List of metric field names to fetch (required). Pass any metric from the LinkedIn Ad Analytics schema (https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads-reporting/ads-reporting-schema) — e.g. impressions, clicks, costInLocalCurrency, videoViews, videoCompletions, oneClickLeads, externalWebsiteConversions, totalEngagements, likes, shares, follows, approximateMemberReach. Derived ratio metrics are computed server-side and may be requested by name: costPerClick (CPC), clickThroughRate (CTR), costPerLead (CPL), costPerMille (CPM), videoCompletionRate. Metadata fields (dateRange for bucketed timeGranularity, pivotValues when a pivot is set) are injected automatically — listing them explicitly is harmless but unnecessary. Unknown fields are forwarded to LinkedIn and will surface LinkedIn's schema error so the caller can retry with a valid name."`
+	AccountID         string         `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric value, e.g., 512345678) - used as accounts facet in query"`
+	Pivot             string         `json:"pivot,omitempty" jsonschema:"Pivot of results: COMPANY, ACCOUNT, SHARE, CAMPAIGN, CREATIVE, CAMPAIGN_GROUP, CONVERSION, CONVERSATION_NODE, CONVERSATION_NODE_OPTION_INDEX, SERVING_LOCATION, CARD_INDEX, MEMBER_COMPANY_SIZE, MEMBER_INDUSTRY, MEMBER_SENIORITY, MEMBER_JOB_TITLE, MEMBER_JOB_FUNCTION, MEMBER_COUNTRY_V2, MEMBER_REGION_V2, MEMBER_COMPANY, PLACEMENT_NAME, IMPRESSION_DEVICE_TYPE, EVENT_STAGE. Note: when combined with a non-ALL timeGranularity, LinkedIn may return one aggregated element per pivot value instead of one per time bucket — if you need time-series results, prefer calling once per bucket or omitting pivot."`
+	Pivots            []string       `json:"pivots,omitempty" jsonschema:"Up to three pivots for a multi-dimensional breakdown (e.g. [CAMPAIGN, CREATIVE] or [CAMPAIGN, MEMBER_SENIORITY]). Uses LinkedIn's statistics finder; each element then carries one pivotValues entry per pivot, in this order. Accepts the same values as pivot and cannot be combined with it."`
+	DateRangeStart    Date           `json:"dateRangeStart,omitempty" jsonschema:"Start date for analytics (required unless relativeDateRange is set)"`
+	DateRangeEnd      *Date          `json:"dateRangeEnd,omitempty" jsonschema:"End date for analytics (optional, inclusive)"`
+	RelativeDateRange string         `json:"relativeDateRange,omitempty" jsonschema:"Named range resolved by the server instead of dateRangeStart/dateRangeEnd: today, yesterday, last_7_days, last_30_days (both end yesterday), month_to_date, last_month, quarter_to_date, year_to_date. Prefer this over computing dates yourself."`
+	TimeZone          string         `json:"timeZone,omitempty" jsonschema:"IANA time zone (e.g. America/New_York) used to resolve relativeDateRange. Defaults to the account's configured zone, then the server default."`
+	TimeGranularity   string         `json:"timeGranularity" jsonschema:"Time granularity: ALL (single aggregate across the full range), DAILY, MONTHLY, YEARLY. For bucketed granularities (DAILY/MONTHLY/YEARLY) the server automatically projects dateRange on each element so the caller can identify which bucket each row covers. When combined with a pivot, LinkedIn may collapse buckets into a single per-pivot aggregate (required)."`
+	CampaignType      string         `json:"campaignType,omitempty" jsonschema:"Campaign type: TEXT_AD, SPONSORED_UPDATES, SPONSORED_INMAILS, DYNAMIC"`
+	Shares            []string       `json:"shares,omitempty" jsonschema:"Array of Share URNs"`
+	Campaigns         []string       `json:"campaigns,omitempty" jsonschema:"Array of Campaign URNs (urn:li:sponsoredCampaign:{id})"`
+	CampaignGroups    []string       `json:"campaignGroups,omitempty" jsonschema:"Array of Campaign Group URNs (urn:li:sponsoredCampaignGroup:{id})"`
+	Accounts          []string       `json:"accounts,omitempty" jsonschema:"Array of Account URNs (urn:li:sponsoredAccount:{id})"`
+	Companies         []string       `json:"companies,omitempty" jsonschema:"Array of Organization URNs (urn:li:organization:{id})"`
+	SortByField       string         `json:"sortByField,omitempty" jsonschema:"Field to sort by: COST_IN_LOCAL_CURRENCY, IMPRESSIONS, CLICKS, ONE_CLICK_LEADS, OPENS, SENDS, EXTERNAL_WEBSITE_CONVERSIONS"`
+	SortByOrder       string         `json:"sortByOrder,omitempty" jsonschema:"Sort order: ASCENDING, DESCENDING"`
+	AutoPaginate      bool           `json:"autoPaginate,omitempty" jsonschema:"When true, follow start/count paging until LinkedIn returns no more rows and merge all pages. Use for large pivots such as MEMBER_COMPANY. Results are capped at maxRows (and the server ceiling); see pagination in the output."`
+	MaxRows           *int           `json:"maxRows,omitempty" jsonschema:"Maximum rows merged by autoPaginate. Defaults to and cannot exceed the server ceiling."`
+	ResolveNames      bool           `json:"resolveNames,omitempty" jsonschema:"When true, look up display names for pivot URNs (campaigns, campaign groups, creatives, accounts, organizations, and member industry/seniority/job function/title/country/region facets) and return them in pivotLabels. Costs extra LinkedIn calls; use it for pivoted reports shown to people."`
+	Cache             string         `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical LinkedIn read; ranges that include the last few days are cached only briefly) or bypass (always fetch fresh analytics from LinkedIn)"`
+	CustomMetrics     []CustomMetric `json:"customMetrics,omitempty" jsonschema:"Caller-defined derived metrics computed server-side from raw fields, e.g. {name: costPerQualifiedLead, expression: costInLocalCurrency / (externalWebsiteConversions + oneClickLeads)}. Every entry is returned under its name; its raw fields are requested automatically."`
+	Fields            []string       `json:"fields" jsonschema:"List of metric field names to fetch (required). Pass any metric from the LinkedIn Ad Analytics schema (https://learn.microsoft.com/en-us/linkedin/marketing/integrations/ads-reporting/ads-reporting-schema) — e.g. impressions, clicks, costInLocalCurrency, videoViews, videoCompletions, oneClickLeads, externalWebsiteConversions, totalEngagements, likes, shares, follows, approximateMemberReach. Derived ratio metrics are computed server-side and may be requested by name or alias: costPerClick (CPC), clickThroughRate (CTR), costPerLead (CPL), costPerMille (CPM), videoCompletionRate, conversionRate, costPerConversion, returnOnAdSpend (ROAS), engagementRate, frequency, leadFormCompletionRate, costPerVideoView, costPerThousandReach, landingPageClickThroughRate, inMailOpenRate, inMailClickRate — see linkedin://analytics/derived-metrics for formulas — plus any derived metrics the server operator configured; define your own with customMetrics. Metadata fields (dateRange for bucketed timeGranularity, pivotValues when a pivot is set) are injected automatically — listing them explicitly is harmless but unnecessary. Unknown fields are forwarded to LinkedIn and will surface LinkedIn's schema error so the caller can retry with a valid name."`
 }
 
 type Date struct {
@@ -22,3 +30,8 @@ type Date struct {
 	Month int `json:"month" jsonschema:"Month (1-12)"`
 	Day   int `json:"day" jsonschema:"Day (1-31)"`
 }
+
+type CustomMetric struct {
+	Name       string `json:"name" jsonschema:"Metric name returned in element metrics (letters, digits, underscores; must not clash with a built-in derived metric or a raw LinkedIn field)"`
+	Expression string `json:"expression" jsonschema:"Arithmetic over raw LinkedIn metric fields using numbers, + - * /, parentheses and the functions abs, min, max, coalesce. Division by zero or a missing field yields null."`
+}
@@ -1,13 +1,18 @@
 package dto
 
 type Output struct {
-	Elements []AnalyticsElement `json:"elements" jsonschema:"Analytics results"`
-	Paging   Paging             `json:"paging" jsonschema:"Pagination information"`
+	Elements          []AnalyticsElement `json:"elements" jsonschema:"Analytics results"`
+	Paging            Paging             `json:"paging" jsonschema:"Pagination information"`
+	ResolvedDateRange *ResolvedDateRange `json:"resolvedDateRange,omitempty" jsonschema:"Absolute date range the report covers"`
+	Pagination        *Pagination        `json:"pagination,omitempty" jsonschema:"Auto-pagination summary, present when autoPaginate is set"`
+	Warnings          []string           `json:"warnings,omitempty" jsonschema:"Non-fatal problems, e.g. pivot names that could not be resolved"`
 }
 
 type AnalyticsElement struct {
 	DateRange   *DateRange             `json:"dateRange,omitempty" jsonschema:"Date range for this data point"`
 	PivotValues []string               `json:"pivotValues,omitempty" jsonschema:"Pivot values for this data point (URNs)"`
+	PivotLabels []string               `json:"pivotLabels,omitempty" jsonschema:"Display names aligned with pivotValues when resolveNames is set; unresolved values are repeated as-is"`
+	Dimensions  []PivotDimension       `json:"dimensions,omitempty" jsonschema:"One entry per pivotValues item naming its pivot, with a label when resolveNames is set"`
 	CreativeID  string                 `json:"creativeID,omitempty" jsonschema:"Creative ID extracted from pivotValues URN when pivot=CREATIVE"`
 	Metrics     map[string]interface{} `json:"metrics,omitempty" jsonschema:"Metric values (dynamic based on requested fields)"`
 }
@@ -22,3 +27,23 @@ type Paging struct {
 	Start int                 `json:"start" jsonschema:"Starting index"`
 	Links []map[string]string `json:"links" jsonschema:"Pagination links"`
 }
+
+type Pagination struct {
+	PagesFetched int  `json:"pagesFetched" jsonschema:"Number of LinkedIn pages requested"`
+	RowsFetched  int  `json:"rowsFetched" jsonschema:"Number of elements returned after merging"`
+	MaxRows      int  `json:"maxRows" jsonschema:"Row ceiling applied to this call"`
+	CeilingHit   bool `json:"ceilingHit" jsonschema:"True when more rows may exist beyond maxRows; the result is truncated"`
+}
+
+type PivotDimension struct {
+	Pivot string `json:"pivot" jsonschema:"Pivot this value belongs to (e.g. CAMPAIGN)"`
+	Value string `json:"value" jsonschema:"Pivot value (URN)"`
+	Label string `json:"label,omitempty" jsonschema:"Display name of the value when resolveNames is set"`
+}
+
+type ResolvedDateRange struct {
+	Start             Date   `json:"start" jsonschema:"First day covered (inclusive)"`
+	End               *Date  `json:"end,omitempty" jsonschema:"Last day covered (inclusive); open-ended when omitted"`
+	RelativeDateRange string `json:"relativeDateRange,omitempty" jsonschema:"Named range this was resolved from"`
+	TimeZone          string `json:"timeZone,omitempty" jsonschema:"Time zone the named range was resolved in"`
+}
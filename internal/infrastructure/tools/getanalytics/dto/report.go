@@ -0,0 +1,27 @@
+package dto
+
+type ReportInput struct {
+	Title  string        `json:"title,omitempty" jsonschema:"Report title used for the file name and the summary sheet"`
+	Sheets []ReportSheet `json:"sheets" jsonschema:"One analytics query per sheet, e.g. a campaign summary (pivot CAMPAIGN, timeGranularity ALL), a daily trend (timeGranularity DAILY) and a demographic breakdown (pivot MEMBER_SENIORITY). Up to 10 sheets."`
+}
+
+type ReportSheet struct {
+	Name string `json:"name" jsonschema:"Sheet name (Excel allows 31 characters; longer or duplicate names are adjusted)"`
+	Input
+}
+
+type ReportOutput struct {
+	ResourceURI string               `json:"resourceURI" jsonschema:"Resource to read for the .xlsx workbook (binary blob contents)"`
+	FileName    string               `json:"fileName" jsonschema:"Suggested file name"`
+	MIMEType    string               `json:"mimeType" jsonschema:"Media type of the workbook"`
+	ExpiresAt   string               `json:"expiresAt" jsonschema:"RFC3339 time after which resourceURI can no longer be read"`
+	Sheets      []ReportSheetSummary `json:"sheets" jsonschema:"One entry per query sheet, after the Summary sheet"`
+	Warnings    []string             `json:"warnings,omitempty" jsonschema:"Non-fatal problems, prefixed with the sheet name"`
+}
+
+type ReportSheetSummary struct {
+	Name              string             `json:"name" jsonschema:"Sheet name as requested"`
+	RowCount          int                `json:"rowCount" jsonschema:"Data rows on the sheet, excluding the header and totals rows"`
+	ResolvedDateRange *ResolvedDateRange `json:"resolvedDateRange" jsonschema:"Absolute date range the sheet covers"`
+	Pagination        *Pagination        `json:"pagination,omitempty" jsonschema:"Auto-pagination summary, present when autoPaginate is set"`
+}
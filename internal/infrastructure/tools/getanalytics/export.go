@@ -0,0 +1,189 @@
+package getanalytics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/middleware"
+	"linkedin-mcp/internal/infrastructure/resources/exports"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/table"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxInlineExportBytes is the largest rendered export returned in the tool result; anything
+// bigger is published as a linkedin://exports/{id} resource.
+const maxInlineExportBytes = 16 * 1024
+
+// ExportAnalytics runs a get_analytics query and renders the elements as a flat CSV, TSV or
+// Markdown table.
+func (t *Tool) ExportAnalytics(ctx context.Context, req *mcp.CallToolRequest, input dto.ExportInput) (*mcp.CallToolResult, dto.ExportOutput, error) {
+	result := &mcp.CallToolResult{}
+
+	format := strings.ToLower(strings.TrimSpace(input.Format))
+	if format == "" {
+		format = table.FormatCSV
+	}
+	if format != table.FormatCSV && format != table.FormatTSV && format != table.FormatMarkdown {
+		return result, dto.ExportOutput{}, fmt.Errorf("input validation failed: invalid format: %s. Must be one of: csv, tsv, markdown", input.Format)
+	}
+
+	normalizedInput, derivedFields, err := t.validateAndNormalizeInput(input.Input)
+	if err != nil {
+		return result, dto.ExportOutput{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	metricColumns, err := t.requestedMetrics(input.Input)
+	if err != nil {
+		return result, dto.ExportOutput{}, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	analyticsResult, pagination, err := t.fetch(ctx, normalizedInput, derivedFields)
+	if err != nil {
+		return result, dto.ExportOutput{}, toolerrors.WrapToolExecutionError("export analytics", err, t.connectURL)
+	}
+
+	output := t.convertOutput(analyticsResult, pivotDimensions(normalizedInput))
+	if normalizedInput.ResolveNames {
+		t.injectPivotLabels(ctx, normalizedInput.AccountID, &output)
+	}
+
+	flat := flattenOutput(output, normalizedInput, metricColumns)
+	content, err := table.Render(flat, format)
+	if err != nil {
+		return result, dto.ExportOutput{}, toolerrors.WrapToolExecutionError("render analytics export", err, t.connectURL)
+	}
+
+	exportOutput := dto.ExportOutput{
+		Format:            format,
+		MIMEType:          table.MIMEType(format),
+		Columns:           flat.Columns,
+		RowCount:          len(flat.Rows),
+		ResolvedDateRange: resolvedDateRange(normalizedInput),
+		Pagination:        pagination,
+		Warnings:          output.Warnings,
+	}
+
+	if t.exports == nil || (!input.AsResource && len(content) <= maxInlineExportBytes) {
+		exportOutput.Content = content
+		return result, exportOutput, nil
+	}
+
+	userID, _ := middleware.UserIDFromContext(ctx)
+	uri, expiresAt, err := t.exports.Put(userID, exports.Export{
+		Name:     exportFileName(normalizedInput, format),
+		MIMEType: exportOutput.MIMEType,
+		Text:     content,
+	})
+	if err != nil {
+		return result, dto.ExportOutput{}, toolerrors.WrapToolExecutionError("publish analytics export", err, t.connectURL)
+	}
+	exportOutput.ResourceURI = uri
+	exportOutput.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	return result, exportOutput, nil
+}
+
+// requestedMetrics lists the metric columns in the order the caller asked for them: raw
+// fields under their normalized name, derived metrics under their canonical name, followed by
+// customMetrics. Raw fields pulled in only as derived-metric dependencies are left out.
+func (t *Tool) requestedMetrics(input dto.Input) ([]string, error) {
+	catalog, err := t.derivedCatalog(input.CustomMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	var columns []string
+	add := func(name string) {
+		if name == "" || name == "dateRange" || name == "pivotValues" {
+			return
+		}
+		if _, exists := seen[name]; exists {
+			return
+		}
+		seen[name] = struct{}{}
+		columns = append(columns, name)
+	}
+
+	for _, field := range input.Fields {
+		trimmed := strings.TrimSpace(field)
+		if metric, ok := lookupDerivedMetric(trimmed); ok {
+			add(metric.Name)
+			continue
+		}
+		if metric, ok := catalog[trimmed]; ok {
+			add(metric.Name)
+			continue
+		}
+		add(t.normalizeFieldName(trimmed))
+	}
+	for _, custom := range input.CustomMetrics {
+		add(strings.TrimSpace(custom.Name))
+	}
+	return columns, nil
+}
+
+// flattenOutput turns elements into table rows: the date bucket when timeGranularity is not
+// ALL, each pivot value (and label when resolveNames is set), then the metric columns.
+func flattenOutput(output dto.Output, input dto.Input, metricColumns []string) table.Table {
+	bucketed := input.TimeGranularity != "" && input.TimeGranularity != "ALL"
+	pivots := pivotDimensions(input)
+
+	var columns []string
+	if bucketed {
+		columns = append(columns, "dateStart", "dateEnd")
+	}
+	for _, pivot := range pivots {
+		columns = append(columns, pivot)
+		if input.ResolveNames {
+			columns = append(columns, pivot+"_LABEL")
+		}
+	}
+	columns = append(columns, metricColumns...)
+
+	rows := make([][]any, 0, len(output.Elements))
+	for _, element := range output.Elements {
+		row := make([]any, 0, len(columns))
+		if bucketed {
+			var start, end any
+			if element.DateRange != nil {
+				start = formatDate(element.DateRange.Start)
+				if element.DateRange.End != nil {
+					end = formatDate(*element.DateRange.End)
+				}
+			}
+			row = append(row, start, end)
+		}
+		for i := range pivots {
+			var value, label any
+			if i < len(element.PivotValues) {
+				value = element.PivotValues[i]
+			}
+			if i < len(element.PivotLabels) {
+				label = element.PivotLabels[i]
+			}
+			row = append(row, value)
+			if input.ResolveNames {
+				row = append(row, label)
+			}
+		}
+		for _, metric := range metricColumns {
+			row = append(row, element.Metrics[metric])
+		}
+		rows = append(rows, row)
+	}
+
+	return table.Table{Columns: columns, Rows: rows}
+}
+
+func formatDate(date dto.Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", date.Year, date.Month, date.Day)
+}
+
+func exportFileName(input dto.Input, format string) string {
+	extension := map[string]string{table.FormatCSV: "csv", table.FormatTSV: "tsv", table.FormatMarkdown: "md"}[format]
+	return fmt.Sprintf("linkedin-analytics-%s-%s.%s", input.AccountID, formatDate(input.DateRangeStart), extension)
+}
@@ -0,0 +1,55 @@
+package getanalytics
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/table"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestedMetrics_KeepsCallerOrder(t *testing.T) {
+	tool := &Tool{}
+
+	columns, err := tool.requestedMetrics(dto.Input{
+		Fields: []string{"CTR", "SPEND", "impressions", "clickThroughRate", "pivotValues"},
+		CustomMetrics: []dto.CustomMetric{
+			{Name: "costPerQualifiedLead", Expression: "costInLocalCurrency / (externalWebsiteConversions + oneClickLeads)"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"clickThroughRate", "costInLocalCurrency", "impressions", "costPerQualifiedLead"}, columns)
+}
+
+func TestFlattenOutput(t *testing.T) {
+	end := dto.Date{Year: 2025, Month: 3, Day: 1}
+	output := dto.Output{Elements: []dto.AnalyticsElement{
+		{
+			DateRange:   &dto.DateRange{Start: dto.Date{Year: 2025, Month: 3, Day: 1}, End: &end},
+			PivotValues: []string{"urn:li:sponsoredCampaign:1"},
+			PivotLabels: []string{"Spring launch"},
+			Metrics:     map[string]interface{}{"clicks": float64(12), "costInLocalCurrency": "30.5", "costPerClick": nil},
+		},
+	}}
+	input := dto.Input{TimeGranularity: "DAILY", Pivot: "CAMPAIGN", ResolveNames: true}
+
+	flat := flattenOutput(output, input, []string{"clicks", "costPerClick"})
+	require.Equal(t, []string{"dateStart", "dateEnd", "CAMPAIGN", "CAMPAIGN_LABEL", "clicks", "costPerClick"}, flat.Columns)
+	require.Equal(t, [][]any{{"2025-03-01", "2025-03-01", "urn:li:sponsoredCampaign:1", "Spring launch", float64(12), nil}}, flat.Rows)
+
+	rendered, err := table.Render(flat, table.FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, "dateStart,dateEnd,CAMPAIGN,CAMPAIGN_LABEL,clicks,costPerClick\n2025-03-01,2025-03-01,urn:li:sponsoredCampaign:1,Spring launch,12,\n", rendered)
+}
+
+func TestFlattenOutput_AllGranularityHasNoDateColumns(t *testing.T) {
+	output := dto.Output{Elements: []dto.AnalyticsElement{
+		{PivotValues: []string{"urn:li:sponsoredCampaign:1", "urn:li:seniority:3"}, Metrics: map[string]interface{}{"impressions": float64(900)}},
+	}}
+	input := dto.Input{TimeGranularity: "ALL", Pivots: []string{"CAMPAIGN", "MEMBER_SENIORITY"}}
+
+	flat := flattenOutput(output, input, []string{"impressions"})
+	require.Equal(t, []string{"CAMPAIGN", "MEMBER_SENIORITY", "impressions"}, flat.Columns)
+	require.Equal(t, [][]any{{"urn:li:sponsoredCampaign:1", "urn:li:seniority:3", float64(900)}}, flat.Rows)
+}
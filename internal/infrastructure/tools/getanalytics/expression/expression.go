@@ -0,0 +1,230 @@
+// Package expression parses and evaluates the arithmetic used to define derived analytics
+// metrics, e.g. "costInLocalCurrency / (externalWebsiteConversions + oneClickLeads)".
+//
+// The grammar is deliberately small: numbers, raw metric field names, + - * /, unary minus,
+// parentheses and the functions listed in functions. There are no variables, loops or
+// side effects, so expressions from callers can be evaluated safely.
+package expression
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// maxLength and maxDepth bound the work done for a single expression.
+	maxLength = 1000
+	maxDepth  = 32
+)
+
+// functions lists every function an expression may call with its minimum and maximum
+// argument count (0 meaning unbounded).
+var functions = map[string]struct{ minArgs, maxArgs int }{
+	"abs":      {1, 1},
+	"min":      {2, 0},
+	"max":      {2, 0},
+	"coalesce": {2, 0},
+}
+
+// Expression is a parsed, validated arithmetic expression.
+type Expression struct {
+	source string
+	root   node
+	fields []string
+}
+
+// Parse validates source and returns the parsed expression.
+func Parse(source string) (*Expression, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return nil, fmt.Errorf("expression is empty")
+	}
+	if len(source) > maxLength {
+		return nil, fmt.Errorf("expression exceeds %d characters", maxLength)
+	}
+
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, seen: map[string]struct{}{}}
+	root, err := p.parseSum(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+
+	return &Expression{source: source, root: root, fields: p.fields}, nil
+}
+
+// Fields returns the raw metric fields the expression reads, in order of first use.
+func (e *Expression) Fields() []string {
+	return append([]string(nil), e.fields...)
+}
+
+// String returns the expression source.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Evaluate computes the expression. lookup returns a field's value and whether it is
+// present. The result is undefined (false) when a field is missing, a divisor is zero or
+// the result is not a finite number; coalesce is the only way to recover from a missing value.
+func (e *Expression) Evaluate(lookup func(field string) (float64, bool)) (float64, bool) {
+	value, ok := e.root.eval(lookup)
+	if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// IsIdentifier reports whether name is a valid field or metric name: a letter followed by
+// letters, digits or underscores.
+func IsIdentifier(name string) bool {
+	if name == "" || !isLetter(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isLetter(name[i]) && !isDigit(name[i]) && name[i] != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields []string
+	seen   map[string]struct{}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseSum handles + and -, the lowest precedence level.
+func (p *parser) parseSum(depth int) (node, error) {
+	left, err := p.parseProduct(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && (p.peek().text == "+" || p.peek().text == "-") {
+		op := p.next().text
+		right, err := p.parseProduct(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseProduct(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && (p.peek().text == "*" || p.peek().text == "/") {
+		op := p.next().text
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("expression nests deeper than %d levels", maxDepth)
+	}
+	if tok := p.peek(); tok.kind == tokenOperator && (tok.text == "-" || tok.text == "+") {
+		p.next()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tok.text == "-" {
+			return negateNode{operand: operand}, nil
+		}
+		return operand, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *parser) parsePrimary(depth int) (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return numberNode(tok.value), nil
+	case tokenIdentifier:
+		if p.peek().kind == tokenOpen {
+			return p.parseCall(tok, depth)
+		}
+		if _, exists := p.seen[tok.text]; !exists {
+			p.seen[tok.text] = struct{}{}
+			p.fields = append(p.fields, tok.text)
+		}
+		return fieldNode(tok.text), nil
+	case tokenOpen:
+		inner, err := p.parseSum(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenClose {
+			return nil, fmt.Errorf("missing ) for ( at position %d", tok.pos+1)
+		}
+		return inner, nil
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+}
+
+func (p *parser) parseCall(name token, depth int) (node, error) {
+	arity, ok := functions[strings.ToLower(name.text)]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d; supported functions are abs, min, max, coalesce", name.text, name.pos+1)
+	}
+	p.next() // (
+
+	var args []node
+	if p.peek().kind != tokenClose {
+		for {
+			arg, err := p.parseSum(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if p.next().kind != tokenClose {
+		return nil, fmt.Errorf("missing ) for %s( at position %d", name.text, name.pos+1)
+	}
+
+	if len(args) < arity.minArgs || (arity.maxArgs > 0 && len(args) > arity.maxArgs) {
+		if arity.minArgs == arity.maxArgs {
+			return nil, fmt.Errorf("%s takes %d argument(s), got %d", name.text, arity.minArgs, len(args))
+		}
+		return nil, fmt.Errorf("%s takes at least %d arguments, got %d", name.text, arity.minArgs, len(args))
+	}
+	return callNode{name: strings.ToLower(name.text), args: args}, nil
+}
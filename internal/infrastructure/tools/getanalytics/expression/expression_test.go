@@ -0,0 +1,96 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func lookupFrom(values map[string]float64) func(string) (float64, bool) {
+	return func(field string) (float64, bool) {
+		value, ok := values[field]
+		return value, ok
+	}
+}
+
+func TestParse_ExtractsFieldsInOrder(t *testing.T) {
+	expr, err := Parse("costInLocalCurrency / (externalWebsiteConversions + oneClickLeads + costInLocalCurrency * 0)")
+	require.NoError(t, err)
+	require.Equal(t, []string{"costInLocalCurrency", "externalWebsiteConversions", "oneClickLeads"}, expr.Fields())
+}
+
+func TestEvaluate(t *testing.T) {
+	values := lookupFrom(map[string]float64{"cost": 120, "conversions": 4, "leads": 2, "zero": 0})
+
+	cases := map[string]float64{
+		"cost / (conversions + leads)": 20,
+		"1 + 2 * 3":                    7,
+		"(1 + 2) * 3":                  9,
+		"10 - 4 - 3":                   3,
+		"-cost / -conversions":         30,
+		"abs(leads - conversions)":     2,
+		"min(cost, leads, 3)":          2,
+		"MAX(conversions, leads)":      4,
+		"coalesce(missing, leads)":     2,
+		"cost * 1000 / 2.5":            48000,
+	}
+	for source, expected := range cases {
+		expr, err := Parse(source)
+		require.NoError(t, err, source)
+		value, ok := expr.Evaluate(values)
+		require.True(t, ok, source)
+		require.InDelta(t, expected, value, 1e-9, source)
+	}
+}
+
+func TestEvaluate_UndefinedValues(t *testing.T) {
+	values := lookupFrom(map[string]float64{"cost": 120, "zero": 0})
+
+	for _, source := range []string{"cost / zero", "cost / missing", "missing + 1", "abs(cost / zero)", "coalesce(missing, cost / zero)"} {
+		expr, err := Parse(source)
+		require.NoError(t, err, source)
+		_, ok := expr.Evaluate(values)
+		require.False(t, ok, source)
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	cases := []string{
+		"",
+		"cost /",
+		"(cost + 1",
+		"cost + 1)",
+		"cost ^ 2",
+		"exp(cost)",
+		"abs(cost, clicks)",
+		"min(cost)",
+		"1.2.3",
+		"cost clicks",
+		"os.Exit(1)",
+	}
+	for _, source := range cases {
+		_, err := Parse(source)
+		require.Error(t, err, source)
+	}
+}
+
+func TestParse_LimitsNesting(t *testing.T) {
+	source := ""
+	for i := 0; i < maxDepth+2; i++ {
+		source += "("
+	}
+	source += "1"
+	for i := 0; i < maxDepth+2; i++ {
+		source += ")"
+	}
+	_, err := Parse(source)
+	require.Error(t, err)
+}
+
+func TestIsIdentifier(t *testing.T) {
+	require.True(t, IsIdentifier("costPerQualifiedLead"))
+	require.True(t, IsIdentifier("cpql_v2"))
+	require.False(t, IsIdentifier("2cpql"))
+	require.False(t, IsIdentifier("cost per lead"))
+	require.False(t, IsIdentifier(""))
+}
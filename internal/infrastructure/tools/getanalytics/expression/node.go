@@ -0,0 +1,106 @@
+package expression
+
+import "math"
+
+type lookupFunc func(field string) (float64, bool)
+
+type node interface {
+	eval(lookup lookupFunc) (float64, bool)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(lookupFunc) (float64, bool) {
+	return float64(n), true
+}
+
+type fieldNode string
+
+func (n fieldNode) eval(lookup lookupFunc) (float64, bool) {
+	return lookup(string(n))
+}
+
+type negateNode struct {
+	operand node
+}
+
+func (n negateNode) eval(lookup lookupFunc) (float64, bool) {
+	value, ok := n.operand.eval(lookup)
+	return -value, ok
+}
+
+type binaryNode struct {
+	op          byte
+	left, right node
+}
+
+func (n binaryNode) eval(lookup lookupFunc) (float64, bool) {
+	left, ok := n.left.eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	right, ok := n.right.eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	switch n.op {
+	case '+':
+		return left + right, true
+	case '-':
+		return left - right, true
+	case '*':
+		return left * right, true
+	case '/':
+		// Same contract as the built-in ratio metrics: a zero divisor leaves the value undefined.
+		if right == 0 {
+			return 0, false
+		}
+		return left / right, true
+	default:
+		return 0, false
+	}
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+func (n callNode) eval(lookup lookupFunc) (float64, bool) {
+	if n.name == "coalesce" {
+		for _, arg := range n.args {
+			if value, ok := arg.eval(lookup); ok {
+				return value, true
+			}
+		}
+		return 0, false
+	}
+
+	values := make([]float64, len(n.args))
+	for i, arg := range n.args {
+		value, ok := arg.eval(lookup)
+		if !ok {
+			return 0, false
+		}
+		values[i] = value
+	}
+
+	switch n.name {
+	case "abs":
+		return math.Abs(values[0]), true
+	case "min":
+		result := values[0]
+		for _, value := range values[1:] {
+			result = math.Min(result, value)
+		}
+		return result, true
+	case "max":
+		result := values[0]
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+		return result, true
+	default:
+		return 0, false
+	}
+}
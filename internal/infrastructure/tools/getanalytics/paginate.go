@@ -0,0 +1,46 @@
+package getanalytics
+
+import (
+	"context"
+
+	"linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+)
+
+// analyticsPageSize is the count requested per page when autoPaginate is set.
+const analyticsPageSize = 1000
+
+type fetchAnalyticsFunc func(ctx context.Context, input reporting.AnalyticsInput) (*reporting.AnalyticsResult, error)
+
+// paginate requests consecutive start/count pages until LinkedIn returns a short page or
+// maxRows elements have been merged. A full last page at the ceiling counts as a hit
+// because more rows may exist beyond it.
+func paginate(ctx context.Context, input reporting.AnalyticsInput, maxRows int, fetch fetchAnalyticsFunc) (*reporting.AnalyticsResult, *dto.Pagination, error) {
+	merged := &reporting.AnalyticsResult{}
+	summary := &dto.Pagination{MaxRows: maxRows}
+
+	for start := 0; ; start += analyticsPageSize {
+		input.Start = start
+		input.Count = analyticsPageSize
+
+		page, err := fetch(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		summary.PagesFetched++
+		merged.Elements = append(merged.Elements, page.Elements...)
+
+		if len(merged.Elements) >= maxRows {
+			summary.CeilingHit = len(merged.Elements) > maxRows || len(page.Elements) == analyticsPageSize
+			merged.Elements = merged.Elements[:maxRows]
+			break
+		}
+		if len(page.Elements) < analyticsPageSize {
+			break
+		}
+	}
+
+	summary.RowsFetched = len(merged.Elements)
+	merged.Paging = reporting.Paging{Count: len(merged.Elements)}
+	return merged, summary, nil
+}
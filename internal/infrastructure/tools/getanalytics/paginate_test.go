@@ -0,0 +1,79 @@
+package getanalytics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api/reporting"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pagedFetcher serves total elements in start/count pages and records the requests.
+func pagedFetcher(total int, requests *[]reporting.AnalyticsInput) fetchAnalyticsFunc {
+	return func(_ context.Context, input reporting.AnalyticsInput) (*reporting.AnalyticsResult, error) {
+		*requests = append(*requests, input)
+		page := &reporting.AnalyticsResult{}
+		for i := input.Start; i < total && i < input.Start+input.Count; i++ {
+			page.Elements = append(page.Elements, reporting.AnalyticsElement{})
+		}
+		return page, nil
+	}
+}
+
+func TestPaginate_FollowsPagesUntilShortPage(t *testing.T) {
+	var requests []reporting.AnalyticsInput
+
+	result, summary, err := paginate(context.Background(), reporting.AnalyticsInput{}, 10000, pagedFetcher(2500, &requests))
+
+	require.NoError(t, err)
+	require.Len(t, result.Elements, 2500)
+	require.Equal(t, 3, summary.PagesFetched)
+	require.Equal(t, 2500, summary.RowsFetched)
+	require.False(t, summary.CeilingHit)
+	require.Equal(t, []int{0, 1000, 2000}, []int{requests[0].Start, requests[1].Start, requests[2].Start})
+	require.Equal(t, analyticsPageSize, requests[0].Count)
+}
+
+func TestPaginate_StopsAtCeiling(t *testing.T) {
+	var requests []reporting.AnalyticsInput
+
+	result, summary, err := paginate(context.Background(), reporting.AnalyticsInput{}, 1500, pagedFetcher(5000, &requests))
+
+	require.NoError(t, err)
+	require.Len(t, result.Elements, 1500)
+	require.Equal(t, 2, summary.PagesFetched)
+	require.True(t, summary.CeilingHit)
+	require.Equal(t, 1500, result.Paging.Count)
+}
+
+func TestPaginate_ExactPageBoundaryWithoutMoreRows(t *testing.T) {
+	var requests []reporting.AnalyticsInput
+
+	_, summary, err := paginate(context.Background(), reporting.AnalyticsInput{}, 10000, pagedFetcher(1000, &requests))
+
+	require.NoError(t, err)
+	require.Equal(t, 2, summary.PagesFetched)
+	require.Equal(t, 1000, summary.RowsFetched)
+	require.False(t, summary.CeilingHit)
+}
+
+func TestPaginate_PropagatesErrors(t *testing.T) {
+	fetch := func(context.Context, reporting.AnalyticsInput) (*reporting.AnalyticsResult, error) {
+		return nil, errors.New("boom")
+	}
+
+	_, _, err := paginate(context.Background(), reporting.AnalyticsInput{}, 10, fetch)
+
+	require.EqualError(t, err, "boom")
+}
+
+func TestRowCeiling(t *testing.T) {
+	tool := &Tool{settings: Settings{MaxRows: 5000}}
+	lower, higher := 100, 9000
+
+	require.Equal(t, 5000, tool.rowCeiling(nil))
+	require.Equal(t, 100, tool.rowCeiling(&lower))
+	require.Equal(t, 5000, tool.rowCeiling(&higher))
+}
@@ -0,0 +1,287 @@
+package getanalytics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/middleware"
+	"linkedin-mcp/internal/infrastructure/resources/exports"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/table"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/workbook"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxReportSheets caps the analytics queries rendered into one workbook.
+const maxReportSheets = 10
+
+// reportTotalLabel marks the totals row at the bottom of each query sheet.
+const reportTotalLabel = "Total"
+
+var fileNameUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// reportSheet is one rendered query of a report.
+type reportSheet struct {
+	request dto.ReportSheet
+	input   dto.Input
+	sheet   workbook.Sheet
+	metrics []string
+	totals  map[string]interface{}
+	rows    int
+}
+
+// ExportReport runs one analytics query per sheet and publishes a single .xlsx workbook with
+// a Summary sheet followed by the query sheets.
+func (t *Tool) ExportReport(ctx context.Context, req *mcp.CallToolRequest, input dto.ReportInput) (*mcp.CallToolResult, dto.ReportOutput, error) {
+	result := &mcp.CallToolResult{}
+
+	if len(input.Sheets) == 0 {
+		return result, dto.ReportOutput{}, fmt.Errorf("input validation failed: sheets is required and cannot be empty")
+	}
+	if len(input.Sheets) > maxReportSheets {
+		return result, dto.ReportOutput{}, fmt.Errorf("input validation failed: sheets cannot exceed %d entries", maxReportSheets)
+	}
+	if t.exports == nil {
+		return result, dto.ReportOutput{}, toolerrors.WrapToolExecutionError("export report", fmt.Errorf("export storage is not configured"), t.connectURL)
+	}
+
+	type preparedSheet struct {
+		input         dto.Input
+		derivedFields []string
+		metrics       []string
+	}
+	prepared := make([]preparedSheet, len(input.Sheets))
+	for i, sheet := range input.Sheets {
+		normalizedInput, derivedFields, err := t.validateAndNormalizeInput(sheet.Input)
+		if err != nil {
+			return result, dto.ReportOutput{}, fmt.Errorf("input validation failed: sheets[%d]: %w", i, err)
+		}
+		metricColumns, err := t.requestedMetrics(sheet.Input)
+		if err != nil {
+			return result, dto.ReportOutput{}, fmt.Errorf("input validation failed: sheets[%d]: %w", i, err)
+		}
+		prepared[i] = preparedSheet{input: normalizedInput, derivedFields: derivedFields, metrics: metricColumns}
+	}
+
+	output := dto.ReportOutput{MIMEType: workbook.MIMEType}
+	sheets := make([]reportSheet, 0, len(input.Sheets))
+	for i, sheet := range input.Sheets {
+		p := prepared[i]
+		analyticsResult, pagination, err := t.fetch(ctx, p.input, p.derivedFields)
+		if err != nil {
+			return result, dto.ReportOutput{}, toolerrors.WrapToolExecutionError(fmt.Sprintf("get analytics for sheet %q", sheet.Name), err, t.connectURL)
+		}
+
+		analyticsOutput := t.convertOutput(analyticsResult, pivotDimensions(p.input))
+		if p.input.ResolveNames {
+			t.injectPivotLabels(ctx, p.input.AccountID, &analyticsOutput)
+		}
+		for _, warning := range analyticsOutput.Warnings {
+			output.Warnings = append(output.Warnings, fmt.Sprintf("%s: %s", sheet.Name, warning))
+		}
+		if pagination != nil && pagination.CeilingHit {
+			output.Warnings = append(output.Warnings, fmt.Sprintf("%s: truncated at %d rows", sheet.Name, pagination.MaxRows))
+		}
+
+		catalog, err := t.derivedCatalog(p.input.CustomMetrics)
+		if err != nil {
+			return result, dto.ReportOutput{}, fmt.Errorf("input validation failed: sheets[%d]: %w", i, err)
+		}
+		flat := flattenOutput(analyticsOutput, p.input, p.metrics)
+		totals := reportTotals(analyticsResult, p.input.Fields, p.derivedFields, catalog)
+
+		sheets = append(sheets, reportSheet{
+			request: sheet,
+			input:   p.input,
+			sheet:   querySheet(sheet.Name, flat, p.metrics, totals, catalog),
+			metrics: p.metrics,
+			totals:  totals,
+			rows:    len(flat.Rows),
+		})
+		output.Sheets = append(output.Sheets, dto.ReportSheetSummary{
+			Name:              sheet.Name,
+			RowCount:          len(flat.Rows),
+			ResolvedDateRange: resolvedDateRange(p.input),
+			Pagination:        pagination,
+		})
+	}
+
+	workbookSheets := []workbook.Sheet{summarySheet(sheets)}
+	for _, sheet := range sheets {
+		workbookSheets = append(workbookSheets, sheet.sheet)
+	}
+	data, err := workbook.Render(workbookSheets)
+	if err != nil {
+		return result, dto.ReportOutput{}, toolerrors.WrapToolExecutionError("render report workbook", err, t.connectURL)
+	}
+
+	output.FileName = reportFileName(input.Title, t.currentTime())
+	userID, _ := middleware.UserIDFromContext(ctx)
+	uri, expiresAt, err := t.exports.Put(userID, exports.Export{
+		Name:     output.FileName,
+		MIMEType: workbook.MIMEType,
+		Blob:     data,
+	})
+	if err != nil {
+		return result, dto.ReportOutput{}, toolerrors.WrapToolExecutionError("publish report workbook", err, t.connectURL)
+	}
+	output.ResourceURI = uri
+	output.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+
+	return result, output, nil
+}
+
+// reportTotals sums the raw fields across elements and recomputes derived metrics from the
+// sums, so a total ratio is the ratio of totals (as get_analytics reports with
+// timeGranularity ALL) rather than an average of per-row ratios. Reach-style fields cannot be
+// summed across rows and are left empty, along with any metric derived from them.
+func reportTotals(result *reporting.AnalyticsResult, rawFields, derivedFields []string, catalog map[string]derivedMetric) map[string]interface{} {
+	totals := map[string]interface{}{}
+	for _, field := range rawFields {
+		if !isAdditiveField(field) {
+			continue
+		}
+		sum, found := 0.0, false
+		for _, element := range result.Elements {
+			if value, ok := metricAsFloat(element.Metrics[field]); ok {
+				sum += value
+				found = true
+			}
+		}
+		if found {
+			totals[field] = sum
+		}
+	}
+
+	aggregate := &reporting.AnalyticsResult{Elements: []reporting.AnalyticsElement{{Metrics: totals}}}
+	injectDerivedMetrics(aggregate, derivedFields, catalog)
+	return aggregate.Elements[0].Metrics
+}
+
+// isAdditiveField reports whether a raw field can be summed across rows. Reach and average
+// fields are de-duplicated or averaged by LinkedIn.
+func isAdditiveField(field string) bool {
+	return !strings.HasPrefix(field, "approximate") &&
+		!strings.HasPrefix(field, "average") &&
+		field != "audiencePenetration"
+}
+
+// querySheet types the flattened columns and, when there is more than one row, appends a
+// totals row.
+func querySheet(name string, flat table.Table, metricColumns []string, totals map[string]interface{}, catalog map[string]derivedMetric) workbook.Sheet {
+	dimensionCount := len(flat.Columns) - len(metricColumns)
+	columns := make([]workbook.Column, len(flat.Columns))
+	for i, column := range flat.Columns {
+		columns[i] = workbook.Column{Name: column, Kind: workbook.Text}
+		if i >= dimensionCount {
+			columns[i].Kind = metricKind(column, catalog, flat.Rows, i)
+		}
+	}
+
+	rows := flat.Rows
+	if len(rows) > 1 {
+		total := make([]any, len(flat.Columns))
+		if dimensionCount > 0 {
+			total[0] = reportTotalLabel
+		}
+		for i, metric := range metricColumns {
+			total[dimensionCount+i] = totals[metric]
+		}
+		rows = append(rows[:len(rows):len(rows)], total)
+	}
+
+	return workbook.Sheet{Name: name, Columns: columns, Rows: rows}
+}
+
+// summarySheet lists every query sheet with its range, dimensions, row count and totals. Each
+// metric gets one column, shared by the sheets that requested it.
+func summarySheet(sheets []reportSheet) workbook.Sheet {
+	columns := []workbook.Column{
+		{Name: "Sheet", Kind: workbook.Text},
+		{Name: "Account", Kind: workbook.Text},
+		{Name: "Start", Kind: workbook.Text},
+		{Name: "End", Kind: workbook.Text},
+		{Name: "Granularity", Kind: workbook.Text},
+		{Name: "Pivots", Kind: workbook.Text},
+		{Name: "Rows", Kind: workbook.Integer},
+	}
+
+	metricIndex := map[string]int{}
+	for _, sheet := range sheets {
+		metricColumns := sheet.sheet.Columns[len(sheet.sheet.Columns)-len(sheet.metrics):]
+		for _, column := range metricColumns {
+			if _, exists := metricIndex[column.Name]; !exists {
+				metricIndex[column.Name] = len(columns)
+				columns = append(columns, column)
+			}
+		}
+	}
+
+	rows := make([][]any, 0, len(sheets))
+	for _, sheet := range sheets {
+		row := make([]any, len(columns))
+		row[0] = sheet.request.Name
+		row[1] = sheet.input.AccountID
+		row[2] = formatDate(sheet.input.DateRangeStart)
+		if sheet.input.DateRangeEnd != nil {
+			row[3] = formatDate(*sheet.input.DateRangeEnd)
+		}
+		row[4] = sheet.input.TimeGranularity
+		row[5] = strings.Join(pivotDimensions(sheet.input), ", ")
+		row[6] = sheet.rows
+		for _, metric := range sheet.metrics {
+			row[metricIndex[metric]] = sheet.totals[metric]
+		}
+		rows = append(rows, row)
+	}
+
+	return workbook.Sheet{Name: "Summary", Columns: columns, Rows: rows}
+}
+
+// metricKind picks the cell type of a metric column: the unit of a derived metric, currency
+// for *InLocalCurrency and *InUsd fields, otherwise Integer when every value in the column is
+// whole (counts) and Number when not.
+func metricKind(name string, catalog map[string]derivedMetric, rows [][]any, column int) workbook.Kind {
+	metric, derived := derivedMetrics[name]
+	if !derived {
+		metric, derived = catalog[name]
+	}
+	if derived {
+		switch metric.Unit {
+		case unitCurrency:
+			return workbook.Currency
+		case unitRate:
+			return workbook.Percent
+		default:
+			return workbook.Number
+		}
+	}
+
+	if strings.HasSuffix(name, "InLocalCurrency") || strings.HasSuffix(name, "InUsd") {
+		return workbook.Currency
+	}
+	for _, row := range rows {
+		if column >= len(row) {
+			continue
+		}
+		if value, ok := metricAsFloat(row[column]); ok && value != math.Trunc(value) {
+			return workbook.Number
+		}
+	}
+	return workbook.Integer
+}
+
+func reportFileName(title string, now time.Time) string {
+	name := strings.Trim(fileNameUnsafe.ReplaceAllString(strings.TrimSpace(title), "-"), "-.")
+	if name == "" {
+		name = "linkedin-analytics-report"
+	}
+	return fmt.Sprintf("%s-%s.xlsx", name, now.UTC().Format("2006-01-02"))
+}
@@ -0,0 +1,100 @@
+package getanalytics
+
+import (
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/table"
+	"linkedin-mcp/internal/infrastructure/tools/getanalytics/workbook"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportTotals_RecomputesRatiosFromSums(t *testing.T) {
+	result := &reporting.AnalyticsResult{Elements: []reporting.AnalyticsElement{
+		{Metrics: map[string]interface{}{"clicks": float64(10), "impressions": float64(100), "costInLocalCurrency": "20.00", "approximateMemberReach": float64(80)}},
+		{Metrics: map[string]interface{}{"clicks": float64(30), "impressions": float64(900), "costInLocalCurrency": "40.00", "approximateMemberReach": float64(500)}},
+	}}
+
+	totals := reportTotals(result,
+		[]string{"clicks", "impressions", "costInLocalCurrency", "approximateMemberReach"},
+		[]string{"clickThroughRate", "costPerClick", "frequency"}, nil)
+
+	require.Equal(t, 40.0, totals["clicks"])
+	require.Equal(t, 60.0, totals["costInLocalCurrency"])
+	require.NotContains(t, totals, "approximateMemberReach", "reach cannot be summed across rows")
+	require.InDelta(t, 0.04, totals["clickThroughRate"], 1e-9, "ratio of totals, not the mean of row ratios")
+	require.InDelta(t, 1.5, totals["costPerClick"], 1e-9)
+	require.Nil(t, totals["frequency"])
+}
+
+func TestMetricKind(t *testing.T) {
+	catalog := map[string]derivedMetric{"costPerQualifiedLead": {Name: "costPerQualifiedLead", Unit: unitNumber}}
+	rows := [][]any{{float64(12), 0.5}, {float64(3), float64(2)}}
+
+	require.Equal(t, workbook.Percent, metricKind("clickThroughRate", nil, nil, 0))
+	require.Equal(t, workbook.Currency, metricKind("costPerClick", nil, nil, 0))
+	require.Equal(t, workbook.Number, metricKind("returnOnAdSpend", nil, nil, 0))
+	require.Equal(t, workbook.Number, metricKind("costPerQualifiedLead", catalog, nil, 0))
+	require.Equal(t, workbook.Currency, metricKind("costInLocalCurrency", nil, nil, 0))
+	require.Equal(t, workbook.Integer, metricKind("clicks", nil, rows, 0))
+	require.Equal(t, workbook.Number, metricKind("averageDwellTime", nil, rows, 1))
+}
+
+func TestQuerySheet_AppendsTotalsRow(t *testing.T) {
+	flat := table.Table{
+		Columns: []string{"CAMPAIGN", "clicks", "clickThroughRate"},
+		Rows: [][]any{
+			{"urn:li:sponsoredCampaign:1", float64(10), 0.1},
+			{"urn:li:sponsoredCampaign:2", float64(30), 0.03},
+		},
+	}
+
+	sheet := querySheet("Campaigns", flat, []string{"clicks", "clickThroughRate"}, map[string]interface{}{"clicks": 40.0, "clickThroughRate": 0.04}, nil)
+
+	require.Equal(t, []workbook.Column{
+		{Name: "CAMPAIGN", Kind: workbook.Text},
+		{Name: "clicks", Kind: workbook.Integer},
+		{Name: "clickThroughRate", Kind: workbook.Percent},
+	}, sheet.Columns)
+	require.Len(t, sheet.Rows, 3)
+	require.Equal(t, []any{"Total", 40.0, 0.04}, sheet.Rows[2])
+	require.Len(t, flat.Rows, 2, "the flattened table is not modified")
+}
+
+func TestSummarySheet_SharesMetricColumns(t *testing.T) {
+	end := dto.Date{Year: 2025, Month: 3, Day: 31}
+	sheets := []reportSheet{
+		{
+			request: dto.ReportSheet{Name: "Campaigns"},
+			input:   dto.Input{AccountID: "512247261", DateRangeStart: dto.Date{Year: 2025, Month: 3, Day: 1}, DateRangeEnd: &end, TimeGranularity: "ALL", Pivot: "CAMPAIGN"},
+			sheet:   workbook.Sheet{Columns: []workbook.Column{{Name: "CAMPAIGN"}, {Name: "clicks", Kind: workbook.Integer}}},
+			metrics: []string{"clicks"},
+			totals:  map[string]interface{}{"clicks": 40.0},
+			rows:    2,
+		},
+		{
+			request: dto.ReportSheet{Name: "Trend"},
+			input:   dto.Input{AccountID: "512247261", DateRangeStart: dto.Date{Year: 2025, Month: 3, Day: 1}, TimeGranularity: "DAILY"},
+			sheet:   workbook.Sheet{Columns: []workbook.Column{{Name: "dateStart"}, {Name: "dateEnd"}, {Name: "costPerClick", Kind: workbook.Currency}, {Name: "clicks", Kind: workbook.Integer}}},
+			metrics: []string{"costPerClick", "clicks"},
+			totals:  map[string]interface{}{"clicks": 41.0, "costPerClick": 1.5},
+			rows:    31,
+		},
+	}
+
+	summary := summarySheet(sheets)
+	require.Equal(t, "Summary", summary.Name)
+	require.Equal(t, "clicks", summary.Columns[7].Name)
+	require.Equal(t, workbook.Currency, summary.Columns[8].Kind)
+	require.Equal(t, []any{"Campaigns", "512247261", "2025-03-01", "2025-03-31", "ALL", "CAMPAIGN", 2, 40.0, nil}, summary.Rows[0])
+	require.Equal(t, []any{"Trend", "512247261", "2025-03-01", nil, "DAILY", "", 31, 41.0, 1.5}, summary.Rows[1])
+}
+
+func TestReportFileName(t *testing.T) {
+	now := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, "Acme-Q1-review-2026-03-04.xlsx", reportFileName("Acme Q1 review", now))
+	require.Equal(t, "linkedin-analytics-report-2026-03-04.xlsx", reportFileName(" ../ ", now))
+}
@@ -0,0 +1,183 @@
+// Package table renders flattened analytics rows as CSV, TSV and Markdown.
+package table
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Supported text formats.
+const (
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatMarkdown = "markdown"
+)
+
+// Table is a header row plus data rows. Cells hold strings, numbers or nil for empty cells.
+type Table struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// MIMEType returns the media type of a rendered format.
+func MIMEType(format string) string {
+	switch format {
+	case FormatTSV:
+		return "text/tab-separated-values"
+	case FormatMarkdown:
+		return "text/markdown"
+	default:
+		return "text/csv"
+	}
+}
+
+// Render renders t in format: csv, tsv or markdown.
+func Render(t Table, format string) (string, error) {
+	switch format {
+	case FormatCSV:
+		return renderCSV(t)
+	case FormatTSV:
+		return renderTSV(t), nil
+	case FormatMarkdown:
+		return renderMarkdown(t), nil
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// FormatCell renders a cell as text. Numbers are printed without exponent or trailing zeros.
+func FormatCell(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(typed), 'f', -1, 32)
+	case int:
+		return strconv.Itoa(typed)
+	case int64:
+		return strconv.FormatInt(typed, 10)
+	case bool:
+		return strconv.FormatBool(typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func renderCSV(t Table) (string, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(t.Columns); err != nil {
+		return "", err
+	}
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i := range record {
+			record[i] = spreadsheetCellAt(row, i)
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderTSV writes tab-separated lines. TSV has no quoting, so tabs and line breaks inside
+// cells are replaced with spaces after formula neutralization.
+func renderTSV(t Table) string {
+	sanitize := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+
+	var b strings.Builder
+	writeLine := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(sanitize.Replace(cell))
+		}
+		b.WriteByte('\n')
+	}
+
+	writeLine(t.Columns)
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i := range record {
+			record[i] = spreadsheetCellAt(row, i)
+		}
+		writeLine(record)
+	}
+	return b.String()
+}
+
+func renderMarkdown(t Table) string {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+	var b strings.Builder
+	writeLine := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" ")
+			b.WriteString(escape.Replace(cell))
+			b.WriteString(" |")
+		}
+		b.WriteByte('\n')
+	}
+
+	writeLine(t.Columns)
+	b.WriteString("|")
+	for range t.Columns {
+		b.WriteString(" --- |")
+	}
+	b.WriteByte('\n')
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i := range record {
+			record[i] = cellAt(row, i)
+		}
+		writeLine(record)
+	}
+	return b.String()
+}
+
+func cellAt(row []any, i int) string {
+	if i >= len(row) {
+		return ""
+	}
+	return FormatCell(row[i])
+}
+
+// spreadsheetCellAt renders a cell for CSV and TSV, which are usually opened in a
+// spreadsheet. Text starting with =, +, -, @, a tab or a carriage return would be evaluated
+// as a formula there, so it is prefixed with a single quote. Numbers, including numeric
+// strings such as LinkedIn's "-12.50" amounts, are left unchanged.
+// Reference: https://owasp.org/www-community/attacks/CSV_Injection
+func spreadsheetCellAt(row []any, i int) string {
+	if i >= len(row) {
+		return ""
+	}
+	text, ok := row[i].(string)
+	if !ok {
+		return FormatCell(row[i])
+	}
+	return neutralizeFormula(text)
+}
+
+func neutralizeFormula(cell string) string {
+	if cell == "" || !strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return cell
+	}
+	if _, err := strconv.ParseFloat(cell, 64); err == nil {
+		return cell
+	}
+	return "'" + cell
+}
@@ -0,0 +1,67 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var sample = Table{
+	Columns: []string{"campaign", "clicks", "clickThroughRate"},
+	Rows: [][]any{
+		{"Spring, \"launch\"", float64(120), 0.0125},
+		{"Tab\there | pipe", 7, nil},
+	},
+}
+
+func TestRender_CSV(t *testing.T) {
+	out, err := Render(sample, FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, "campaign,clicks,clickThroughRate\n\"Spring, \"\"launch\"\"\",120,0.0125\nTab\there | pipe,7,\n", out)
+}
+
+func TestRender_TSV(t *testing.T) {
+	out, err := Render(sample, FormatTSV)
+	require.NoError(t, err)
+	require.Equal(t, "campaign\tclicks\tclickThroughRate\nSpring, \"launch\"\t120\t0.0125\nTab here | pipe\t7\t\n", out)
+}
+
+func TestRender_Markdown(t *testing.T) {
+	out, err := Render(sample, FormatMarkdown)
+	require.NoError(t, err)
+	require.Equal(t, "| campaign | clicks | clickThroughRate |\n| --- | --- | --- |\n| Spring, \"launch\" | 120 | 0.0125 |\n| Tab\there \\| pipe | 7 |  |\n", out)
+}
+
+func TestRender_RejectsUnknownFormat(t *testing.T) {
+	_, err := Render(sample, "xml")
+	require.Error(t, err)
+}
+
+func TestFormatCell(t *testing.T) {
+	require.Equal(t, "1234567.5", FormatCell(1234567.5))
+	require.Equal(t, "", FormatCell(nil))
+	require.Equal(t, "17.16", FormatCell("17.16"))
+}
+
+func TestRender_NeutralizesFormulaCells(t *testing.T) {
+	formulas := Table{
+		Columns: []string{"campaign", "cost", "clicks"},
+		Rows: [][]any{
+			{"=HYPERLINK(\"https://evil.example\")", "-12.50", float64(-3)},
+			{"@SUM(A1)", "+5", 4},
+			{"\tcmd", "-", nil},
+		},
+	}
+
+	out, err := Render(formulas, FormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, "campaign,cost,clicks\n\"'=HYPERLINK(\"\"https://evil.example\"\")\",-12.50,-3\n'@SUM(A1),+5,4\n'\tcmd,'-,\n", out)
+
+	out, err = Render(formulas, FormatTSV)
+	require.NoError(t, err)
+	require.Equal(t, "campaign\tcost\tclicks\n'=HYPERLINK(\"https://evil.example\")\t-12.50\t-3\n'@SUM(A1)\t+5\t4\n' cmd\t'-\t\n", out)
+
+	out, err = Render(formulas, FormatMarkdown)
+	require.NoError(t, err)
+	require.Contains(t, out, "| =HYPERLINK(")
+}
@@ -4,24 +4,78 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 	"unicode"
 
 	"linkedin-mcp/internal/infrastructure/api/reporting"
+	"linkedin-mcp/internal/infrastructure/resources/exports"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
 	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+	"linkedin-mcp/internal/infrastructure/tools/pivotlabels"
 	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// validPivots lists the pivots accepted by both the analytics and statistics finders.
+var validPivots = map[string]bool{
+	"COMPANY":                        true,
+	"ACCOUNT":                        true,
+	"SHARE":                          true,
+	"CAMPAIGN":                       true,
+	"CREATIVE":                       true,
+	"CAMPAIGN_GROUP":                 true,
+	"CONVERSION":                     true,
+	"CONVERSATION_NODE":              true,
+	"CONVERSATION_NODE_OPTION_INDEX": true,
+	"SERVING_LOCATION":               true,
+	"CARD_INDEX":                     true,
+	"MEMBER_COMPANY_SIZE":            true,
+	"MEMBER_INDUSTRY":                true,
+	"MEMBER_SENIORITY":               true,
+	"MEMBER_JOB_TITLE":               true,
+	"MEMBER_JOB_FUNCTION":            true,
+	"MEMBER_COUNTRY_V2":              true,
+	"MEMBER_REGION_V2":               true,
+	"MEMBER_COMPANY":                 true,
+	"PLACEMENT_NAME":                 true,
+	"IMPRESSION_DEVICE_TYPE":         true,
+	"EVENT_STAGE":                    true,
+}
+
+// maxStatisticsPivots is the number of pivots LinkedIn's statistics finder accepts.
+const maxStatisticsPivots = 3
+
+// Settings holds the server-side limits and defaults of get_analytics.
+type Settings struct {
+	// MaxRows is the ceiling on elements merged by autoPaginate.
+	MaxRows int
+	// DefaultTimeZone and AccountTimeZones (keyed by numeric account ID) are IANA zone names
+	// used to resolve relative date ranges when the caller does not pass timeZone.
+	DefaultTimeZone  string
+	AccountTimeZones map[string]string
+	// DerivedMetrics maps operator-defined metric names to arithmetic expressions over raw
+	// fields; callers request them in fields like the built-in derived metrics.
+	DerivedMetrics map[string]string
+}
+
 type Tool struct {
-	repository *reporting.Repository
-	connectURL string
+	repository  *reporting.Repository
+	pivotLabels *pivotlabels.Resolver
+	exports     *exports.Store
+	settings    Settings
+	connectURL  string
+	now         func() time.Time
 }
 
-func NewTool(repository *reporting.Repository, connectURL string) *Tool {
+func NewTool(repository *reporting.Repository, pivotLabels *pivotlabels.Resolver, exports *exports.Store, settings Settings, connectURL string) *Tool {
 	return &Tool{
-		repository: repository,
-		connectURL: connectURL,
+		repository:  repository,
+		pivotLabels: pivotLabels,
+		exports:     exports,
+		settings:    settings,
+		connectURL:  connectURL,
+		now:         time.Now,
 	}
 }
 
@@ -33,21 +87,147 @@ func (t *Tool) GetAnalytics(ctx context.Context, req *mcp.CallToolRequest, input
 		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
 	}
 
-	analyticsInput := t.convertInput(normalizedInput)
-
-	analyticsResult, err := t.repository.GetAnalytics(ctx, analyticsInput)
+	analyticsResult, pagination, err := t.fetch(ctx, normalizedInput, derivedFields)
 	if err != nil {
 		return result, dto.Output{}, toolerrors.WrapToolExecutionError("get analytics", err, t.connectURL)
 	}
 
+	output := t.convertOutput(analyticsResult, pivotDimensions(normalizedInput))
+	output.Pagination = pagination
+	output.ResolvedDateRange = resolvedDateRange(normalizedInput)
+
+	if normalizedInput.ResolveNames {
+		t.injectPivotLabels(ctx, normalizedInput.AccountID, &output)
+	}
+
+	return result, output, nil
+}
+
+// fetch runs a normalized input against LinkedIn, following pages when autoPaginate is set,
+// and computes the requested derived metrics on every element.
+func (t *Tool) fetch(ctx context.Context, input dto.Input, derivedFields []string) (*reporting.AnalyticsResult, *dto.Pagination, error) {
+	catalog, err := t.derivedCatalog(input.CustomMetrics)
+	if err != nil {
+		return nil, nil, err
+	}
+	analyticsInput := t.convertInput(input)
+	ctx = cachecontrol.Apply(ctx, input.Cache)
+
+	var pagination *dto.Pagination
+	var analyticsResult *reporting.AnalyticsResult
+	if input.AutoPaginate {
+		analyticsResult, pagination, err = paginate(ctx, analyticsInput, t.rowCeiling(input.MaxRows), t.repository.GetAnalytics)
+	} else {
+		analyticsResult, err = t.repository.GetAnalytics(ctx, analyticsInput)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// LinkedIn does not expose ratio metrics (CPC, CTR, CPL, CPM, video
 	// completion rate) in its AdAnalytics schema, so we compute them from the
 	// raw fields we requested on the caller's behalf.
-	injectDerivedMetrics(analyticsResult, derivedFields)
+	injectDerivedMetrics(analyticsResult, derivedFields, catalog)
+	return analyticsResult, pagination, nil
+}
 
-	output := t.convertOutput(analyticsResult)
+// validatePivots checks the statistics finder pivots: at most three distinct valid pivots,
+// and not combined with the single pivot parameter of the analytics finder.
+func validatePivots(pivot string, pivots []string) error {
+	if len(pivots) == 0 {
+		return nil
+	}
+	if pivot != "" {
+		return fmt.Errorf("pivot and pivots cannot be combined; list every dimension in pivots")
+	}
+	if len(pivots) > maxStatisticsPivots {
+		return fmt.Errorf("pivots cannot exceed %d entries", maxStatisticsPivots)
+	}
+	seen := make(map[string]struct{}, len(pivots))
+	for _, p := range pivots {
+		if !validPivots[p] {
+			return fmt.Errorf("invalid pivot in pivots: %s", p)
+		}
+		if _, exists := seen[p]; exists {
+			return fmt.Errorf("duplicate pivot in pivots: %s", p)
+		}
+		seen[p] = struct{}{}
+	}
+	return nil
+}
 
-	return result, output, nil
+// pivotDimensions returns the pivot each pivotValues entry corresponds to, in order.
+func pivotDimensions(input dto.Input) []string {
+	if len(input.Pivots) > 0 {
+		return input.Pivots
+	}
+	if input.Pivot != "" {
+		return []string{input.Pivot}
+	}
+	return nil
+}
+
+// location picks the zone relative date ranges are resolved in: the caller's timeZone, else
+// the account's configured zone, else the server default, else UTC.
+func (t *Tool) location(accountID, requested string) (*time.Location, error) {
+	name := strings.TrimSpace(requested)
+	if name == "" {
+		name = t.settings.AccountTimeZones[accountID]
+	}
+	if name == "" {
+		name = t.settings.DefaultTimeZone
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeZone %q: must be an IANA time zone such as America/New_York", name)
+	}
+	return location, nil
+}
+
+func (t *Tool) currentTime() time.Time {
+	if t.now == nil {
+		return time.Now()
+	}
+	return t.now()
+}
+
+// rowCeiling returns the autoPaginate row limit: the caller's maxRows when set, never above
+// the server-wide ceiling.
+func (t *Tool) rowCeiling(requested *int) int {
+	ceiling := t.settings.MaxRows
+	if requested != nil && *requested < ceiling {
+		ceiling = *requested
+	}
+	return ceiling
+}
+
+// injectPivotLabels resolves every distinct pivot value in the output once and writes the
+// aligned pivotLabels array on each element.
+func (t *Tool) injectPivotLabels(ctx context.Context, accountID string, output *dto.Output) {
+	if t.pivotLabels == nil {
+		return
+	}
+
+	var values []string
+	for _, element := range output.Elements {
+		values = append(values, element.PivotValues...)
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	names, warnings := t.pivotLabels.Resolve(ctx, accountID, values)
+	for i := range output.Elements {
+		element := &output.Elements[i]
+		element.PivotLabels = pivotlabels.Labels(element.PivotValues, names)
+		for j := range element.Dimensions {
+			element.Dimensions[j].Label = element.PivotLabels[j]
+		}
+	}
+	output.Warnings = append(output.Warnings, warnings...)
 }
 
 // validateAndNormalizeInput validates the raw tool input, splits the requested
@@ -64,15 +244,37 @@ func (t *Tool) validateAndNormalizeInput(input dto.Input) (dto.Input, []string,
 	if input.AccountID == "" {
 		return dto.Input{}, nil, fmt.Errorf("accountID cannot be empty or whitespace only")
 	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return dto.Input{}, nil, err
+	}
+
+	// Resolve a named relative range into absolute dates before the required-field checks.
+	if input.RelativeDateRange != "" {
+		if input.DateRangeStart != (dto.Date{}) || input.DateRangeEnd != nil {
+			return dto.Input{}, nil, fmt.Errorf("relativeDateRange cannot be combined with dateRangeStart or dateRangeEnd")
+		}
+		location, err := t.location(input.AccountID, input.TimeZone)
+		if err != nil {
+			return dto.Input{}, nil, err
+		}
+		start, end, err := resolveRelativeDateRange(input.RelativeDateRange, t.currentTime().In(location))
+		if err != nil {
+			return dto.Input{}, nil, err
+		}
+		input.RelativeDateRange = strings.ToLower(strings.TrimSpace(input.RelativeDateRange))
+		input.TimeZone = location.String()
+		input.DateRangeStart = start
+		input.DateRangeEnd = &end
+	}
 
 	// Validate required fields
 	if input.DateRangeStart.Year == 0 {
-		return dto.Input{}, nil, fmt.Errorf("dateRangeStart is required")
+		return dto.Input{}, nil, fmt.Errorf("dateRangeStart or relativeDateRange is required")
 	}
 	if input.TimeGranularity == "" {
 		return dto.Input{}, nil, fmt.Errorf("timeGranularity is required")
 	}
-	if len(input.Fields) == 0 {
+	if len(input.Fields) == 0 && len(input.CustomMetrics) == 0 {
 		return dto.Input{}, nil, fmt.Errorf("fields is required and cannot be empty")
 	}
 
@@ -88,33 +290,12 @@ func (t *Tool) validateAndNormalizeInput(input dto.Input) (dto.Input, []string,
 	}
 
 	// Validate pivot values
-	validPivots := map[string]bool{
-		"COMPANY":                        true,
-		"ACCOUNT":                        true,
-		"SHARE":                          true,
-		"CAMPAIGN":                       true,
-		"CREATIVE":                       true,
-		"CAMPAIGN_GROUP":                 true,
-		"CONVERSION":                     true,
-		"CONVERSATION_NODE":              true,
-		"CONVERSATION_NODE_OPTION_INDEX": true,
-		"SERVING_LOCATION":               true,
-		"CARD_INDEX":                     true,
-		"MEMBER_COMPANY_SIZE":            true,
-		"MEMBER_INDUSTRY":                true,
-		"MEMBER_SENIORITY":               true,
-		"MEMBER_JOB_TITLE":               true,
-		"MEMBER_JOB_FUNCTION":            true,
-		"MEMBER_COUNTRY_V2":              true,
-		"MEMBER_REGION_V2":               true,
-		"MEMBER_COMPANY":                 true,
-		"PLACEMENT_NAME":                 true,
-		"IMPRESSION_DEVICE_TYPE":         true,
-		"EVENT_STAGE":                    true,
-	}
 	if input.Pivot != "" && !validPivots[input.Pivot] {
 		return dto.Input{}, nil, fmt.Errorf("invalid pivot: %s", input.Pivot)
 	}
+	if err := validatePivots(input.Pivot, input.Pivots); err != nil {
+		return dto.Input{}, nil, err
+	}
 
 	// Validate campaign type
 	validCampaignTypes := map[string]bool{
@@ -153,11 +334,19 @@ func (t *Tool) validateAndNormalizeInput(input dto.Input) (dto.Input, []string,
 		return dto.Input{}, nil, fmt.Errorf("sortByField and sortByOrder must be provided together; supply both or omit both")
 	}
 
+	if input.MaxRows != nil && *input.MaxRows < 1 {
+		return dto.Input{}, nil, fmt.Errorf("maxRows must be at least 1")
+	}
+
 	// Validate date range
+	if err := validateCalendarDate("dateRangeStart", input.DateRangeStart); err != nil {
+		return dto.Input{}, nil, err
+	}
 	if input.DateRangeEnd != nil {
-		if input.DateRangeEnd.Year < input.DateRangeStart.Year ||
-			(input.DateRangeEnd.Year == input.DateRangeStart.Year && input.DateRangeEnd.Month < input.DateRangeStart.Month) ||
-			(input.DateRangeEnd.Year == input.DateRangeStart.Year && input.DateRangeEnd.Month == input.DateRangeStart.Month && input.DateRangeEnd.Day < input.DateRangeStart.Day) {
+		if err := validateCalendarDate("dateRangeEnd", *input.DateRangeEnd); err != nil {
+			return dto.Input{}, nil, err
+		}
+		if dateBefore(*input.DateRangeEnd, input.DateRangeStart) {
 			return dto.Input{}, nil, fmt.Errorf("dateRangeEnd must be after dateRangeStart")
 		}
 	}
@@ -185,10 +374,16 @@ func (t *Tool) validateAndNormalizeInput(input dto.Input) (dto.Input, []string,
 	//   - rawFields: LinkedIn schema fields (passed through untouched so LinkedIn
 	//     remains the schema source of truth — no allowlist).
 	//   - derivedFields: ratio/computed metrics (CPC, CTR, CPL, CPM, video
-	//     completion rate) we calculate from raw fields after the response.
+	//     completion rate, plus operator- and caller-defined expressions) we
+	//     calculate from raw fields after the response.
 	// For each derived field we union its required raw dependencies into the
 	// outbound request, deduplicated against the raw fields the caller already
 	// asked for.
+	catalog, err := t.derivedCatalog(input.CustomMetrics)
+	if err != nil {
+		return dto.Input{}, nil, err
+	}
+
 	rawFieldSeen := map[string]struct{}{}
 	derivedSeen := map[string]struct{}{}
 	rawFields := make([]string, 0, len(input.Fields))
@@ -205,23 +400,35 @@ func (t *Tool) validateAndNormalizeInput(input dto.Input) (dto.Input, []string,
 		rawFields = append(rawFields, field)
 	}
 
+	addDerivedField := func(metric derivedMetric) {
+		if _, exists := derivedSeen[metric.Name]; !exists {
+			derivedSeen[metric.Name] = struct{}{}
+			derivedFields = append(derivedFields, metric.Name)
+		}
+		for _, required := range metric.RequiredFields {
+			addRawField(required)
+		}
+	}
+
 	for _, field := range input.Fields {
 		trimmed := strings.TrimSpace(field)
 		if trimmed == "" {
 			continue
 		}
 		if metric, ok := lookupDerivedMetric(trimmed); ok {
-			if _, exists := derivedSeen[metric.Name]; !exists {
-				derivedSeen[metric.Name] = struct{}{}
-				derivedFields = append(derivedFields, metric.Name)
-			}
-			for _, required := range metric.RequiredFields {
-				addRawField(required)
-			}
+			addDerivedField(metric)
+			continue
+		}
+		if metric, ok := catalog[trimmed]; ok {
+			addDerivedField(metric)
 			continue
 		}
 		addRawField(t.normalizeFieldName(trimmed))
 	}
+	// Metrics the caller defined in this request are always returned.
+	for _, custom := range input.CustomMetrics {
+		addDerivedField(catalog[strings.TrimSpace(custom.Name)])
+	}
 
 	if len(rawFields) == 0 && len(derivedFields) == 0 {
 		return dto.Input{}, nil, fmt.Errorf("no valid fields after normalization")
@@ -254,6 +461,7 @@ func (t *Tool) convertInput(input dto.Input) reporting.AnalyticsInput {
 	return reporting.AnalyticsInput{
 		AccountID:       input.AccountID,
 		Pivot:           input.Pivot,
+		Pivots:          input.Pivots,
 		DateRange:       dateRange,
 		TimeGranularity: input.TimeGranularity,
 		CampaignType:    input.CampaignType,
@@ -270,12 +478,13 @@ func (t *Tool) convertInput(input dto.Input) reporting.AnalyticsInput {
 	}
 }
 
-func (t *Tool) convertOutput(result *reporting.AnalyticsResult) dto.Output {
+func (t *Tool) convertOutput(result *reporting.AnalyticsResult, pivots []string) dto.Output {
 	elements := make([]dto.AnalyticsElement, len(result.Elements))
 	for i, element := range result.Elements {
 		elements[i] = dto.AnalyticsElement{
 			DateRange:   t.convertDateRange(element.DateRange),
 			PivotValues: element.PivotValues,
+			Dimensions:  convertDimensions(pivots, element.PivotValues),
 			CreativeID:  element.CreativeID,
 			Metrics:     element.Metrics,
 		}
@@ -291,6 +500,22 @@ func (t *Tool) convertOutput(result *reporting.AnalyticsResult) dto.Output {
 	}
 }
 
+// convertDimensions pairs every pivot value with the pivot it belongs to. LinkedIn returns
+// pivotValues in the order the pivots were requested.
+func convertDimensions(pivots, values []string) []dto.PivotDimension {
+	if len(pivots) == 0 || len(values) == 0 {
+		return nil
+	}
+	dimensions := make([]dto.PivotDimension, len(values))
+	for i, value := range values {
+		dimensions[i] = dto.PivotDimension{Value: value}
+		if i < len(pivots) {
+			dimensions[i].Pivot = pivots[i]
+		}
+	}
+	return dimensions
+}
+
 // normalizeFieldName converts field names from various formats (UPPER_CASE, UPPERCASE) to camelCase
 // which is the format expected by LinkedIn API.
 // Also handles common aliases and variations.
@@ -384,8 +609,9 @@ func (t *Tool) convertDateRange(dateRange *reporting.DateRange) *dto.DateRange {
 // element in the analytics result and writes them into element.Metrics under
 // their canonical lowerCamelCase name. Values are emitted as nil when the
 // computation is undefined (missing dependency or zero denominator), so the
-// MCP client can distinguish "unavailable" from "zero".
-func injectDerivedMetrics(result *reporting.AnalyticsResult, derivedFields []string) {
+// MCP client can distinguish "unavailable" from "zero". Names missing from the
+// built-in derivedMetrics are looked up in catalog (operator and caller expressions).
+func injectDerivedMetrics(result *reporting.AnalyticsResult, derivedFields []string, catalog map[string]derivedMetric) {
 	if result == nil || len(derivedFields) == 0 {
 		return
 	}
@@ -398,6 +624,9 @@ func injectDerivedMetrics(result *reporting.AnalyticsResult, derivedFields []str
 
 		for _, name := range derivedFields {
 			metric, ok := derivedMetrics[name]
+			if !ok {
+				metric, ok = catalog[name]
+			}
 			if !ok {
 				continue
 			}
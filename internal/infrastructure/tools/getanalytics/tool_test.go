@@ -3,9 +3,12 @@ package getanalytics
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"linkedin-mcp/internal/infrastructure/api/reporting"
 	"linkedin-mcp/internal/infrastructure/tools/getanalytics/dto"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestNormalizeFieldName_MapsV8Aliases(t *testing.T) {
@@ -176,7 +179,7 @@ func TestInjectDerivedMetrics_ComputesRatios(t *testing.T) {
 		},
 	}
 
-	injectDerivedMetrics(result, []string{"clickThroughRate", "costPerClick"})
+	injectDerivedMetrics(result, []string{"clickThroughRate", "costPerClick"}, nil)
 
 	metrics := result.Elements[0].Metrics
 	ctr, ok := metrics["clickThroughRate"].(float64)
@@ -201,7 +204,7 @@ func TestInjectDerivedMetrics_NilOnZeroDenominator(t *testing.T) {
 		},
 	}
 
-	injectDerivedMetrics(result, []string{"clickThroughRate"})
+	injectDerivedMetrics(result, []string{"clickThroughRate"}, nil)
 
 	if _, present := result.Elements[0].Metrics["clickThroughRate"]; !present {
 		t.Fatalf("expected clickThroughRate key to be present")
@@ -223,9 +226,176 @@ func TestInjectDerivedMetrics_NilWhenDependencyMissing(t *testing.T) {
 		},
 	}
 
-	injectDerivedMetrics(result, []string{"clickThroughRate"})
+	injectDerivedMetrics(result, []string{"clickThroughRate"}, nil)
 
 	if result.Elements[0].Metrics["clickThroughRate"] != nil {
 		t.Fatalf("expected clickThroughRate nil when clicks missing, got %v", result.Elements[0].Metrics["clickThroughRate"])
 	}
 }
+
+func TestValidatePivots(t *testing.T) {
+	if err := validatePivots("", []string{"CAMPAIGN", "CREATIVE", "MEMBER_SENIORITY"}); err != nil {
+		t.Fatalf("expected three valid pivots to pass, got %v", err)
+	}
+
+	cases := map[string]struct {
+		pivot  string
+		pivots []string
+	}{
+		"combined with pivot": {pivot: "CAMPAIGN", pivots: []string{"CREATIVE"}},
+		"too many":            {pivots: []string{"CAMPAIGN", "CREATIVE", "ACCOUNT", "COMPANY"}},
+		"unknown":             {pivots: []string{"CAMPAIGN", "COLOR"}},
+		"duplicate":           {pivots: []string{"CAMPAIGN", "CAMPAIGN"}},
+	}
+	for name, tc := range cases {
+		if err := validatePivots(tc.pivot, tc.pivots); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestConvertOutput_PairsPivotValuesWithDimensions(t *testing.T) {
+	tool := &Tool{}
+	result := &reporting.AnalyticsResult{
+		Elements: []reporting.AnalyticsElement{
+			{PivotValues: []string{"urn:li:sponsoredCampaign:1", "urn:li:seniority:5"}},
+			{},
+		},
+	}
+
+	output := tool.convertOutput(result, []string{"CAMPAIGN", "MEMBER_SENIORITY"})
+
+	dimensions := output.Elements[0].Dimensions
+	if len(dimensions) != 2 {
+		t.Fatalf("expected 2 dimensions, got %d", len(dimensions))
+	}
+	if dimensions[0].Pivot != "CAMPAIGN" || dimensions[0].Value != "urn:li:sponsoredCampaign:1" {
+		t.Fatalf("unexpected first dimension: %+v", dimensions[0])
+	}
+	if dimensions[1].Pivot != "MEMBER_SENIORITY" || dimensions[1].Value != "urn:li:seniority:5" {
+		t.Fatalf("unexpected second dimension: %+v", dimensions[1])
+	}
+	if output.Elements[1].Dimensions != nil {
+		t.Fatalf("expected no dimensions without pivot values, got %+v", output.Elements[1].Dimensions)
+	}
+}
+
+func TestValidateAndNormalizeInput_ResolvesRelativeDateRangeInAccountZone(t *testing.T) {
+	tool := &Tool{
+		settings: Settings{
+			DefaultTimeZone:  "UTC",
+			AccountTimeZones: map[string]string{"512247261": "America/Los_Angeles"},
+		},
+		// 2026-03-01 03:00 UTC is still February 28 in Los Angeles.
+		now: func() time.Time { return time.Date(2026, time.March, 1, 3, 0, 0, 0, time.UTC) },
+	}
+
+	normalized, _, err := tool.validateAndNormalizeInput(dto.Input{
+		AccountID:         "512247261",
+		RelativeDateRange: "month_to_date",
+		TimeGranularity:   "DAILY",
+		Fields:            []string{"impressions"},
+	})
+	if err != nil {
+		t.Fatalf("expected no validation error, got %v", err)
+	}
+
+	if normalized.DateRangeStart != (dto.Date{Year: 2026, Month: 2, Day: 1}) {
+		t.Fatalf("unexpected start: %+v", normalized.DateRangeStart)
+	}
+	if normalized.DateRangeEnd == nil || *normalized.DateRangeEnd != (dto.Date{Year: 2026, Month: 2, Day: 28}) {
+		t.Fatalf("unexpected end: %+v", normalized.DateRangeEnd)
+	}
+	if normalized.TimeZone != "America/Los_Angeles" {
+		t.Fatalf("expected account time zone, got %q", normalized.TimeZone)
+	}
+}
+
+func TestValidateAndNormalizeInput_RejectsInvalidDates(t *testing.T) {
+	tool := &Tool{}
+
+	cases := map[string]dto.Input{
+		"february 30": {
+			DateRangeStart: dto.Date{Year: 2025, Month: 2, Day: 30},
+		},
+		"end before start": {
+			DateRangeStart: dto.Date{Year: 2025, Month: 3, Day: 2},
+			DateRangeEnd:   &dto.Date{Year: 2025, Month: 3, Day: 1},
+		},
+		"relative with explicit dates": {
+			DateRangeStart:    dto.Date{Year: 2025, Month: 3, Day: 1},
+			RelativeDateRange: "last_month",
+		},
+		"unknown time zone": {
+			RelativeDateRange: "last_month",
+			TimeZone:          "Mars/Olympus_Mons",
+		},
+	}
+
+	for name, input := range cases {
+		input.AccountID = "512247261"
+		input.TimeGranularity = "ALL"
+		input.Fields = []string{"impressions"}
+		if _, _, err := tool.validateAndNormalizeInput(input); err == nil {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestValidateAndNormalizeInput_CustomAndServerMetrics(t *testing.T) {
+	tool := &Tool{settings: Settings{DerivedMetrics: map[string]string{
+		"costPerEngagement": "costInLocalCurrency / totalEngagements",
+	}}}
+
+	input := dto.Input{
+		AccountID:       "512247261",
+		DateRangeStart:  dto.Date{Year: 2025, Month: 1, Day: 1},
+		TimeGranularity: "ALL",
+		Fields:          []string{"impressions", "costPerEngagement"},
+		CustomMetrics: []dto.CustomMetric{
+			{Name: "costPerQualifiedLead", Expression: "costInLocalCurrency / (externalWebsiteConversions + oneClickLeads)"},
+		},
+	}
+
+	normalized, derived, err := tool.validateAndNormalizeInput(input)
+	require.NoError(t, err)
+	require.Equal(t, []string{"impressions", "costInLocalCurrency", "totalEngagements", "externalWebsiteConversions", "oneClickLeads"}, normalized.Fields)
+	require.Equal(t, []string{"costPerEngagement", "costPerQualifiedLead"}, derived)
+
+	catalog, err := tool.derivedCatalog(normalized.CustomMetrics)
+	require.NoError(t, err)
+	result := &reporting.AnalyticsResult{Elements: []reporting.AnalyticsElement{
+		{Metrics: map[string]interface{}{"costInLocalCurrency": "90", "totalEngagements": float64(30), "externalWebsiteConversions": float64(2), "oneClickLeads": float64(1)}},
+		{Metrics: map[string]interface{}{"costInLocalCurrency": "90", "totalEngagements": float64(0), "externalWebsiteConversions": float64(0), "oneClickLeads": float64(0)}},
+	}}
+	injectDerivedMetrics(result, derived, catalog)
+
+	require.Equal(t, 3.0, result.Elements[0].Metrics["costPerEngagement"])
+	require.Equal(t, 30.0, result.Elements[0].Metrics["costPerQualifiedLead"])
+	require.Nil(t, result.Elements[1].Metrics["costPerEngagement"])
+	require.Nil(t, result.Elements[1].Metrics["costPerQualifiedLead"])
+}
+
+func TestValidateAndNormalizeInput_RejectsInvalidCustomMetrics(t *testing.T) {
+	tool := &Tool{settings: Settings{DerivedMetrics: map[string]string{"costPerEngagement": "costInLocalCurrency / totalEngagements"}}}
+
+	cases := map[string]dto.CustomMetric{
+		"unknown function":    {Name: "cpql", Expression: "exp(clicks)"},
+		"syntax error":        {Name: "cpql", Expression: "clicks / "},
+		"built-in name":       {Name: "CPC", Expression: "clicks / impressions"},
+		"server name":         {Name: "costPerEngagement", Expression: "clicks / impressions"},
+		"invalid name":        {Name: "cost per lead", Expression: "clicks / impressions"},
+		"derived reference":   {Name: "cpql", Expression: "costPerClick * 2"},
+		"constant expression": {Name: "cpql", Expression: "1 + 2"},
+	}
+	for name, custom := range cases {
+		_, _, err := tool.validateAndNormalizeInput(dto.Input{
+			AccountID:       "512247261",
+			DateRangeStart:  dto.Date{Year: 2025, Month: 1, Day: 1},
+			TimeGranularity: "ALL",
+			Fields:          []string{"clicks"},
+			CustomMetrics:   []dto.CustomMetric{custom},
+		})
+		require.Error(t, err, name)
+	}
+}
@@ -0,0 +1,278 @@
+// Package workbook writes minimal Office Open XML (.xlsx) workbooks: one or more sheets of
+// typed cells with number formats for counts, decimals, currency amounts and percentages.
+// It covers what analytics reports need without an external spreadsheet dependency.
+package workbook
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MIMEType is the media type of an .xlsx file.
+const MIMEType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// maxSheetNameLength is Excel's limit on sheet names.
+const maxSheetNameLength = 31
+
+// Kind selects how a column's cells are stored and formatted.
+type Kind int
+
+const (
+	// Text cells are stored as inline strings.
+	Text Kind = iota
+	// Integer cells are whole-number counts such as impressions, formatted #,##0.
+	Integer
+	// Number cells are plain decimals formatted #,##0.00.
+	Number
+	// Currency cells are money amounts formatted #,##0.00; the currency is the ad account's.
+	Currency
+	// Percent cells hold fractions (0.05) displayed as percentages (5.00%).
+	Percent
+)
+
+// styleIndex maps a Kind to its cellXfs entry in styles.xml.
+var styleIndex = map[Kind]int{
+	Text:     0,
+	Integer:  1,
+	Number:   2,
+	Currency: 3,
+	Percent:  4,
+}
+
+type Column struct {
+	Name string
+	Kind Kind
+}
+
+// Sheet is one worksheet. Row cells hold strings, numbers or nil for empty cells; numeric
+// strings in non-Text columns are stored as numbers.
+type Sheet struct {
+	Name    string
+	Columns []Column
+	Rows    [][]any
+}
+
+// Render returns the workbook as .xlsx bytes. Sheet names are made valid and unique.
+func Render(sheets []Sheet) ([]byte, error) {
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("workbook needs at least one sheet")
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	names := sheetNames(sheets)
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", workbookXML(names)},
+		{"xl/_rels/workbook.xml.rels", workbookRels(len(sheets))},
+		{"xl/styles.xml", stylesXML},
+	}
+	for _, file := range files {
+		if err := writeFile(archive, file.name, file.content); err != nil {
+			return nil, err
+		}
+	}
+	for i, sheet := range sheets {
+		writer, err := archive.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return nil, err
+		}
+		if err := writeSheet(writer, sheet); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeFile(archive *zip.Writer, name, content string) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(writer, content)
+	return err
+}
+
+func writeSheet(w io.Writer, sheet Sheet) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	header := make([]any, len(sheet.Columns))
+	headerColumns := make([]Column, len(sheet.Columns))
+	for i, column := range sheet.Columns {
+		header[i] = column.Name
+		headerColumns[i] = Column{Name: column.Name, Kind: Text}
+	}
+	writeRow(&b, 1, headerColumns, header)
+	for i, row := range sheet.Rows {
+		writeRow(&b, i+2, sheet.Columns, row)
+	}
+
+	b.WriteString(`</sheetData></worksheet>`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeRow(b *strings.Builder, index int, columns []Column, cells []any) {
+	fmt.Fprintf(b, `<row r="%d">`, index)
+	for i, value := range cells {
+		if value == nil {
+			continue
+		}
+		kind := Text
+		if i < len(columns) {
+			kind = columns[i].Kind
+		}
+		ref := columnName(i) + strconv.Itoa(index)
+		if number, ok := numericValue(value); ok && kind != Text {
+			if math.IsNaN(number) || math.IsInf(number, 0) {
+				// SpreadsheetML has no NaN or infinity; Excel reports such a <v> as corruption.
+				continue
+			}
+			fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleIndex[kind], strconv.FormatFloat(number, 'g', -1, 64))
+			continue
+		}
+		fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(fmt.Sprint(value)))
+	}
+	b.WriteString(`</row>`)
+}
+
+func numericValue(value any) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case float32:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		return number, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// columnName converts a zero-based column index to its letters: 0 → A, 26 → AA.
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// sheetNames strips characters Excel forbids, truncates to 31 characters and de-duplicates
+// case-insensitively by appending " (2)", " (3)" and so on.
+func sheetNames(sheets []Sheet) []string {
+	invalid := strings.NewReplacer(":", " ", "\\", " ", "/", " ", "?", " ", "*", " ", "[", " ", "]", " ")
+	used := map[string]bool{}
+	names := make([]string, len(sheets))
+	for i, sheet := range sheets {
+		base := strings.Trim(strings.TrimSpace(invalid.Replace(sheet.Name)), "'")
+		if base == "" {
+			base = fmt.Sprintf("Sheet%d", i+1)
+		}
+		name := truncate(base, maxSheetNameLength)
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncate(base, maxSheetNameLength-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	// xml.EscapeText only fails on writer errors, which strings.Builder never returns.
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func contentTypes(sheetCount int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func workbookXML(names []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func workbookRels(sheetCount int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheetCount; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheetCount+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// stylesXML declares the cell formats referenced by styleIndex, in order: general text,
+// #,##0 (built-in 3), #,##0.00 (built-in 4), #,##0.00 for currency and 0.00% (built-in 10).
+const stylesXML = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
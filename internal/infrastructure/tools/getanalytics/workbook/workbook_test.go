@@ -0,0 +1,121 @@
+package workbook
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		// Every part must be well-formed XML.
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else {
+				require.NoError(t, err, file.Name)
+			}
+		}
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+func TestRender_WritesTypedCells(t *testing.T) {
+	data, err := Render([]Sheet{
+		{Name: "Summary", Columns: []Column{{Name: "Sheet", Kind: Text}}, Rows: [][]any{{"Campaigns"}}},
+		{
+			Name: "Campaigns",
+			Columns: []Column{
+				{Name: "campaign", Kind: Text},
+				{Name: "impressions", Kind: Integer},
+				{Name: "costInLocalCurrency", Kind: Currency},
+				{Name: "clickThroughRate", Kind: Percent},
+			},
+			Rows: [][]any{
+				{"Spring <launch> & co", float64(1200), "17.16", 0.0125},
+				{"Empty", nil, nil, nil},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	parts := readParts(t, data)
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		require.Contains(t, parts, name)
+	}
+
+	sheet := parts["xl/worksheets/sheet2.xml"]
+	require.Contains(t, sheet, `<c r="A2" t="inlineStr"><is><t xml:space="preserve">Spring &lt;launch&gt; &amp; co</t></is></c>`)
+	require.Contains(t, sheet, `<c r="B2" s="1"><v>1200</v></c>`)
+	require.Contains(t, sheet, `<c r="C2" s="3"><v>17.16</v></c>`)
+	require.Contains(t, sheet, `<c r="D2" s="4"><v>0.0125</v></c>`)
+	require.Contains(t, sheet, `<row r="3"><c r="A3" t="inlineStr"><is><t xml:space="preserve">Empty</t></is></c></row>`)
+	require.Contains(t, parts["xl/workbook.xml"], `<sheet name="Campaigns" sheetId="2" r:id="rId2"/>`)
+}
+
+func TestRender_LeavesNonFiniteNumbersEmpty(t *testing.T) {
+	data, err := Render([]Sheet{{
+		Name: "Campaigns",
+		Columns: []Column{
+			{Name: "campaign", Kind: Text},
+			{Name: "costPerLead", Kind: Currency},
+			{Name: "clickThroughRate", Kind: Percent},
+			{Name: "impressions", Kind: Integer},
+		},
+		Rows: [][]any{{"NaN", math.NaN(), "+Inf", math.Inf(-1)}},
+	}})
+	require.NoError(t, err)
+
+	sheet := readParts(t, data)["xl/worksheets/sheet1.xml"]
+	require.Contains(t, sheet, `<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">NaN</t></is></c></row>`)
+	require.NotContains(t, sheet, "<v>NaN</v>")
+	require.NotContains(t, sheet, "Inf</v>")
+}
+
+func TestRender_RequiresSheets(t *testing.T) {
+	_, err := Render(nil)
+	require.Error(t, err)
+}
+
+func TestSheetNames(t *testing.T) {
+	names := sheetNames([]Sheet{
+		{Name: "Daily trend"},
+		{Name: "daily TREND"},
+		{Name: "Q1/Q2 [draft]: spend?"},
+		{Name: strings.Repeat("x", 40)},
+		{Name: ""},
+	})
+	require.Equal(t, "Daily trend", names[0])
+	require.Equal(t, "daily TREND (2)", names[1])
+	require.NotContains(t, names[2], "/")
+	require.NotContains(t, names[2], "[")
+	require.Len(t, names[3], maxSheetNameLength)
+	require.Equal(t, "Sheet5", names[4])
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", columnName(0))
+	require.Equal(t, "Z", columnName(25))
+	require.Equal(t, "AA", columnName(26))
+	require.Equal(t, "AZ", columnName(51))
+	require.Equal(t, "BA", columnName(52))
+}
@@ -0,0 +1,7 @@
+package dto
+
+type Input struct {
+	AccountID   string   `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric value, e.g., 512345678)"`
+	CampaignIDs []string `json:"campaignIDs" jsonschema:"One or more campaign IDs (e.g. 394073893) or URNs (urn:li:sponsoredCampaign:{id}), max 50"`
+	Cache       string   `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "linkedin-mcp/internal/infrastructure/api/campaigns"
+
+type Output struct {
+	Campaigns []Campaign `json:"campaigns" jsonschema:"Campaigns found, in request order"`
+	NotFound  []string   `json:"notFound,omitempty" jsonschema:"Requested campaign URNs that do not exist in this ad account"`
+}
+
+type Campaign struct {
+	Normalized campaigns.NormalizedCampaign `json:"normalized" jsonschema:"Flattened key fields of the campaign"`
+	Entity     map[string]any               `json:"entity" jsonschema:"Full LinkedIn campaign entity as returned by the API"`
+}
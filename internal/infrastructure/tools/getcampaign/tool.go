@@ -0,0 +1,96 @@
+package getcampaign
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/campaigns"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
+	"linkedin-mcp/internal/infrastructure/tools/getcampaign/dto"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxCampaignsPerCall = 50
+
+type Tool struct {
+	repository *campaigns.Repository
+	connectURL string
+}
+
+func NewTool(repository *campaigns.Repository, connectURL string) *Tool {
+	return &Tool{repository: repository, connectURL: connectURL}
+}
+
+// GetCampaign fetches one or more campaigns by ID or URN and returns each full entity next to
+// its normalized fields.
+func (t *Tool) GetCampaign(ctx context.Context, req *mcp.CallToolRequest, input dto.Input) (*mcp.CallToolResult, dto.Output, error) {
+	result := &mcp.CallToolResult{}
+
+	campaignIDs, err := validateInput(&input)
+	if err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
+
+	getResult, err := t.repository.GetCampaigns(ctx, campaigns.GetInput{
+		AccountID:   input.AccountID,
+		CampaignIDs: campaignIDs,
+	})
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("get campaign", err, t.connectURL)
+	}
+
+	output := dto.Output{Campaigns: make([]dto.Campaign, 0, len(getResult.Elements))}
+	for _, element := range getResult.Elements {
+		output.Campaigns = append(output.Campaigns, dto.Campaign{
+			Normalized: campaigns.NormalizeCampaign(element),
+			Entity:     element,
+		})
+	}
+	for _, id := range getResult.NotFound {
+		output.NotFound = append(output.NotFound, campaigns.CampaignURN(id))
+	}
+
+	return result, output, nil
+}
+
+func validateInput(input *dto.Input) ([]string, error) {
+	input.AccountID = strings.TrimSpace(input.AccountID)
+	if input.AccountID == "" {
+		return nil, fmt.Errorf("accountID is required")
+	}
+	for _, r := range input.AccountID {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("accountID must contain only digits")
+		}
+	}
+
+	if len(input.CampaignIDs) == 0 {
+		return nil, fmt.Errorf("campaignIDs is required and cannot be empty")
+	}
+	if len(input.CampaignIDs) > maxCampaignsPerCall {
+		return nil, fmt.Errorf("campaignIDs cannot exceed %d entries", maxCampaignsPerCall)
+	}
+
+	seen := make(map[string]struct{}, len(input.CampaignIDs))
+	ids := make([]string, 0, len(input.CampaignIDs))
+	for i, reference := range input.CampaignIDs {
+		id, err := campaigns.ParseCampaignID(reference)
+		if err != nil {
+			return nil, fmt.Errorf("campaignIDs[%d]: %w", i, err)
+		}
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
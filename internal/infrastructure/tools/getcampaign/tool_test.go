@@ -0,0 +1,44 @@
+package getcampaign
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/getcampaign/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInput_AcceptsIDsAndURNs(t *testing.T) {
+	input := dto.Input{
+		AccountID:   " 512345678 ",
+		CampaignIDs: []string{"394073893", "urn:li:sponsoredCampaign:394073893", "urn:li:sponsoredCampaign:1"},
+	}
+
+	ids, err := validateInput(&input)
+
+	require.NoError(t, err)
+	require.Equal(t, "512345678", input.AccountID)
+	require.Equal(t, []string{"394073893", "1"}, ids)
+}
+
+func TestValidateInput_RejectsInvalidInput(t *testing.T) {
+	tooMany := make([]string, maxCampaignsPerCall+1)
+	for i := range tooMany {
+		tooMany[i] = "1"
+	}
+
+	cases := map[string]dto.Input{
+		"missing account": {CampaignIDs: []string{"1"}},
+		"non numeric":     {AccountID: "abc", CampaignIDs: []string{"1"}},
+		"no campaigns":    {AccountID: "1"},
+		"too many":        {AccountID: "1", CampaignIDs: tooMany},
+		"wrong urn":       {AccountID: "1", CampaignIDs: []string{"urn:li:sponsoredCreative:1"}},
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := validateInput(&input)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package dto
+
+type Input struct {
+	AccountID   string   `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric, e.g. 512247261)"`
+	CreativeIDs []string `json:"creativeIDs" jsonschema:"One or more creative IDs (e.g. 123456789) or URNs (urn:li:sponsoredCreative:{id}), max 50"`
+	Cache       string   `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "linkedin-mcp/internal/infrastructure/api/creatives"
+
+type Output struct {
+	Creatives []Creative `json:"creatives" jsonschema:"Creatives found, in request order"`
+	NotFound  []string   `json:"notFound,omitempty" jsonschema:"Requested creative URNs that do not exist in this ad account"`
+}
+
+type Creative struct {
+	Normalized creatives.NormalizedCreative `json:"normalized" jsonschema:"Normalized creative metadata"`
+	Entity     map[string]any               `json:"entity" jsonschema:"Full LinkedIn creative entity as returned by the API"`
+}
@@ -0,0 +1,96 @@
+package getcreative
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/creatives"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
+	"linkedin-mcp/internal/infrastructure/tools/getcreative/dto"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxCreativesPerCall = 50
+
+type Tool struct {
+	repository *creatives.Repository
+	connectURL string
+}
+
+func NewTool(repository *creatives.Repository, connectURL string) *Tool {
+	return &Tool{repository: repository, connectURL: connectURL}
+}
+
+// GetCreative fetches one or more creatives by ID or URN and returns each full entity next to
+// its normalized fields.
+func (t *Tool) GetCreative(ctx context.Context, req *mcp.CallToolRequest, input dto.Input) (*mcp.CallToolResult, dto.Output, error) {
+	result := &mcp.CallToolResult{}
+
+	creativeURNs, err := validateInput(&input)
+	if err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
+
+	getResult, err := t.repository.GetCreatives(ctx, creatives.GetInput{
+		AccountID:    input.AccountID,
+		CreativeURNs: creativeURNs,
+	})
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("get creative", err, t.connectURL)
+	}
+
+	output := dto.Output{
+		Creatives: make([]dto.Creative, 0, len(getResult.Elements)),
+		NotFound:  getResult.NotFound,
+	}
+	for _, element := range getResult.Elements {
+		output.Creatives = append(output.Creatives, dto.Creative{
+			Normalized: creatives.NormalizeCreative(element),
+			Entity:     element,
+		})
+	}
+
+	return result, output, nil
+}
+
+func validateInput(input *dto.Input) ([]string, error) {
+	input.AccountID = strings.TrimSpace(input.AccountID)
+	if input.AccountID == "" {
+		return nil, fmt.Errorf("accountID is required")
+	}
+	for _, r := range input.AccountID {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("accountID must contain only digits")
+		}
+	}
+
+	if len(input.CreativeIDs) == 0 {
+		return nil, fmt.Errorf("creativeIDs is required and cannot be empty")
+	}
+	if len(input.CreativeIDs) > maxCreativesPerCall {
+		return nil, fmt.Errorf("creativeIDs cannot exceed %d entries", maxCreativesPerCall)
+	}
+
+	seen := make(map[string]struct{}, len(input.CreativeIDs))
+	urns := make([]string, 0, len(input.CreativeIDs))
+	for i, reference := range input.CreativeIDs {
+		id, err := creatives.ParseCreativeID(reference)
+		if err != nil {
+			return nil, fmt.Errorf("creativeIDs[%d]: %w", i, err)
+		}
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		urns = append(urns, creatives.CreativeURN(id))
+	}
+
+	return urns, nil
+}
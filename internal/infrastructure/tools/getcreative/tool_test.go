@@ -0,0 +1,32 @@
+package getcreative
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/getcreative/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInput_NormalizesToURNs(t *testing.T) {
+	input := dto.Input{
+		AccountID:   "512247261",
+		CreativeIDs: []string{"123", " urn:li:sponsoredCreative:123 ", "456"},
+	}
+
+	urns, err := validateInput(&input)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"urn:li:sponsoredCreative:123", "urn:li:sponsoredCreative:456"}, urns)
+}
+
+func TestValidateInput_RejectsInvalidReferences(t *testing.T) {
+	_, err := validateInput(&dto.Input{AccountID: "1", CreativeIDs: []string{"urn:li:sponsoredCampaign:1"}})
+	require.Error(t, err)
+
+	_, err = validateInput(&dto.Input{CreativeIDs: []string{"1"}})
+	require.Error(t, err)
+
+	_, err = validateInput(&dto.Input{AccountID: "1"})
+	require.Error(t, err)
+}
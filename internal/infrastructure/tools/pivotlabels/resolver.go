@@ -0,0 +1,225 @@
+// Package pivotlabels resolves the URNs LinkedIn returns in adAnalytics pivotValues to
+// human-readable names.
+package pivotlabels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/adaccounts"
+	"linkedin-mcp/internal/infrastructure/api/campaigngroups"
+	"linkedin-mcp/internal/infrastructure/api/campaigns"
+	"linkedin-mcp/internal/infrastructure/api/creatives"
+	"linkedin-mcp/internal/infrastructure/api/lookup"
+)
+
+// maxIDsPerLookup bounds every BATCH_GET / id-filtered search so large demographic reports
+// (e.g. thousands of job titles) are resolved in several calls instead of one oversized URL.
+const maxIDsPerLookup = 100
+
+const (
+	urnTypeCampaign      = "sponsoredCampaign"
+	urnTypeCampaignGroup = "sponsoredCampaignGroup"
+	urnTypeCreative      = "sponsoredCreative"
+	urnTypeAccount       = "sponsoredAccount"
+)
+
+type Resolver struct {
+	campaigns      *campaigns.Repository
+	campaignGroups *campaigngroups.Repository
+	creatives      *creatives.Repository
+	adAccounts     *adaccounts.Repository
+	lookups        *lookup.Repository
+}
+
+func NewResolver(
+	campaignsRepository *campaigns.Repository,
+	campaignGroupsRepository *campaigngroups.Repository,
+	creativesRepository *creatives.Repository,
+	adAccountsRepository *adaccounts.Repository,
+	lookupRepository *lookup.Repository,
+) *Resolver {
+	return &Resolver{
+		campaigns:      campaignsRepository,
+		campaignGroups: campaignGroupsRepository,
+		creatives:      creativesRepository,
+		adAccounts:     adAccountsRepository,
+		lookups:        lookupRepository,
+	}
+}
+
+// Resolve looks up display names for the given pivot values, batching one lookup per URN type.
+// Campaigns, campaign groups and creatives are looked up inside accountID. Resolution is best
+// effort: a failed lookup leaves its URNs unresolved and is reported as a warning so the
+// analytics data itself is still returned.
+func (r *Resolver) Resolve(ctx context.Context, accountID string, values []string) (map[string]string, []string) {
+	names := map[string]string{}
+	var warnings []string
+
+	groups := groupByURNType(values)
+	for _, urnType := range sortedKeys(groups) {
+		ids := groups[urnType]
+		for start := 0; start < len(ids); start += maxIDsPerLookup {
+			end := min(start+maxIDsPerLookup, len(ids))
+			resolved, err := r.resolveChunk(ctx, accountID, urnType, ids[start:end])
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("could not resolve %s names: %v", urnType, err))
+				break
+			}
+			for id, name := range resolved {
+				names[urn(urnType, id)] = name
+			}
+		}
+	}
+
+	return names, warnings
+}
+
+func (r *Resolver) resolveChunk(ctx context.Context, accountID, urnType string, ids []string) (map[string]string, error) {
+	switch urnType {
+	case urnTypeCampaign:
+		result, err := r.campaigns.GetCampaigns(ctx, campaigns.GetInput{AccountID: accountID, CampaignIDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		names := map[string]string{}
+		for _, element := range result.Elements {
+			normalized := campaigns.NormalizeCampaign(element)
+			names[normalized.CampaignID] = normalized.Name
+		}
+		return names, nil
+
+	case urnTypeCampaignGroup:
+		urns := make([]string, len(ids))
+		for i, id := range ids {
+			urns[i] = campaigngroups.CampaignGroupURN(id)
+		}
+		result, err := r.campaignGroups.SearchCampaignGroups(ctx, campaigngroups.SearchInput{
+			AccountID:         accountID,
+			CampaignGroupURNs: urns,
+			PageSize:          len(urns),
+		})
+		if err != nil {
+			return nil, err
+		}
+		names := map[string]string{}
+		for _, element := range result.Elements {
+			id := campaigngroups.ElementID(element)
+			if name, ok := element["name"].(string); ok && id != "" {
+				names[id] = name
+			}
+		}
+		return names, nil
+
+	case urnTypeCreative:
+		urns := make([]string, len(ids))
+		for i, id := range ids {
+			urns[i] = creatives.CreativeURN(id)
+		}
+		result, err := r.creatives.GetCreatives(ctx, creatives.GetInput{AccountID: accountID, CreativeURNs: urns})
+		if err != nil {
+			return nil, err
+		}
+		names := map[string]string{}
+		for _, element := range result.Elements {
+			normalized := creatives.NormalizeCreative(element)
+			name, _ := element["name"].(string)
+			if strings.TrimSpace(name) == "" {
+				name = normalized.Headline
+			}
+			names[normalized.CreativeID] = strings.TrimSpace(name)
+		}
+		return names, nil
+
+	case urnTypeAccount:
+		result, err := r.adAccounts.GetAdAccounts(ctx, adaccounts.GetInput{AccountIDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		names := map[string]string{}
+		for _, element := range result.Elements {
+			normalized := adaccounts.NormalizeAdAccount(element)
+			names[normalized.AccountID] = normalized.Name
+		}
+		return names, nil
+
+	default:
+		kind, ok := lookup.KindForURNType(urnType)
+		if !ok {
+			return nil, nil
+		}
+		return r.lookups.GetLabels(ctx, kind, ids)
+	}
+}
+
+// Labels returns one label per pivot value, in order. Values without a resolved name keep
+// the original value so the array always lines up with pivotValues.
+func Labels(values []string, names map[string]string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	labels := make([]string, len(values))
+	for i, value := range values {
+		if name := strings.TrimSpace(names[value]); name != "" {
+			labels[i] = name
+			continue
+		}
+		labels[i] = value
+	}
+	return labels
+}
+
+// groupByURNType collects the distinct IDs of resolvable URNs (urn:li:{type}:{id}) per type.
+// Values that are not URNs, such as MEMBER_COMPANY_SIZE enums, are skipped.
+func groupByURNType(values []string) map[string][]string {
+	groups := map[string][]string{}
+	seen := map[string]struct{}{}
+	for _, value := range values {
+		urnType, id, ok := parseURN(value)
+		if !ok || !isResolvable(urnType) {
+			continue
+		}
+		if _, exists := seen[value]; exists {
+			continue
+		}
+		seen[value] = struct{}{}
+		groups[urnType] = append(groups[urnType], id)
+	}
+	return groups
+}
+
+func isResolvable(urnType string) bool {
+	switch urnType {
+	case urnTypeCampaign, urnTypeCampaignGroup, urnTypeCreative, urnTypeAccount:
+		return true
+	}
+	_, ok := lookup.KindForURNType(urnType)
+	return ok
+}
+
+func parseURN(value string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(value), "urn:li:")
+	if !ok {
+		return "", "", false
+	}
+	urnType, id, ok := strings.Cut(rest, ":")
+	if !ok || urnType == "" || id == "" {
+		return "", "", false
+	}
+	return urnType, id, true
+}
+
+func urn(urnType, id string) string {
+	return "urn:li:" + urnType + ":" + id
+}
+
+func sortedKeys(groups map[string][]string) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,196 @@
+package pivotlabels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/api"
+	"linkedin-mcp/internal/infrastructure/api/adaccounts"
+	"linkedin-mcp/internal/infrastructure/api/campaigngroups"
+	"linkedin-mcp/internal/infrastructure/api/campaigns"
+	"linkedin-mcp/internal/infrastructure/api/creatives"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/api/lookup"
+	"linkedin-mcp/internal/infrastructure/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupByURNType(t *testing.T) {
+	groups := groupByURNType([]string{
+		"urn:li:sponsoredCampaign:1",
+		"urn:li:sponsoredCampaign:2",
+		"urn:li:sponsoredCampaign:1",
+		"urn:li:seniority:5",
+		"urn:li:geo:103644278",
+		"urn:li:share:7",
+		"SIZE_11_TO_50",
+	})
+
+	require.Equal(t, map[string][]string{
+		"sponsoredCampaign": {"1", "2"},
+		"seniority":         {"5"},
+		"geo":               {"103644278"},
+	}, groups)
+}
+
+func TestLabels_FallsBackToPivotValue(t *testing.T) {
+	labels := Labels(
+		[]string{"urn:li:sponsoredCampaign:1", "urn:li:seniority:5"},
+		map[string]string{"urn:li:sponsoredCampaign:1": "Q3 Demand Gen"},
+	)
+
+	require.Equal(t, []string{"Q3 Demand Gen", "urn:li:seniority:5"}, labels)
+	require.Nil(t, Labels(nil, nil))
+}
+
+func TestResolve_ChunksLookupsIntoBatchGetsOf100(t *testing.T) {
+	linkedIn := &fakeLinkedIn{respond: func(request gateway.ProxyRequest) (int, any) {
+		results := map[string]any{}
+		for _, id := range batchKeys(request) {
+			results[id] = map[string]any{"name": map[string]any{"localized": map[string]any{"en_US": "Title " + id}}}
+		}
+		return http.StatusOK, batchGetBody(results)
+	}}
+	values := make([]string, 150)
+	for i := range values {
+		values[i] = "urn:li:title:" + strconv.Itoa(i+1)
+	}
+
+	names, warnings := newTestResolver(linkedIn).Resolve(testContext(), "512345678", values)
+
+	require.Empty(t, warnings)
+	require.Len(t, linkedIn.requests, 2)
+	for _, request := range linkedIn.requests {
+		require.Equal(t, "titles", request.ResourcePath)
+		require.Equal(t, gateway.RestLiMethodBatchGet, request.Headers[gateway.HeaderRestLiMethod])
+	}
+	require.Len(t, batchKeys(linkedIn.requests[0]), 100)
+	require.Len(t, batchKeys(linkedIn.requests[1]), 50)
+	require.Len(t, names, 150)
+	require.Equal(t, "Title 150", names["urn:li:title:150"])
+}
+
+func TestResolve_FailedLookupBecomesWarning(t *testing.T) {
+	linkedIn := &fakeLinkedIn{respond: func(request gateway.ProxyRequest) (int, any) {
+		if request.ResourcePath == "adAccounts/512345678/adCampaigns" {
+			return http.StatusOK, batchGetBody(map[string]any{
+				"1": map[string]any{"id": float64(1), "name": "Q3 Demand Gen"},
+				"2": map[string]any{"id": float64(2), "name": "Retargeting"},
+			})
+		}
+		return http.StatusForbidden, map[string]any{"status": 403, "message": "Not enough permissions to access: creatives"}
+	}}
+	values := []string{
+		"urn:li:sponsoredCampaign:1",
+		"urn:li:sponsoredCampaign:2",
+		"urn:li:sponsoredCreative:10",
+		"urn:li:sponsoredCreative:11",
+	}
+
+	names, warnings := newTestResolver(linkedIn).Resolve(testContext(), "512345678", values)
+
+	require.Equal(t, map[string]string{
+		"urn:li:sponsoredCampaign:1": "Q3 Demand Gen",
+		"urn:li:sponsoredCampaign:2": "Retargeting",
+	}, names)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "could not resolve sponsoredCreative names")
+	require.Equal(t, []string{"Q3 Demand Gen", "urn:li:sponsoredCreative:10"}, Labels(
+		[]string{"urn:li:sponsoredCampaign:1", "urn:li:sponsoredCreative:10"}, names))
+}
+
+func TestResolve_StopsTypeAfterFailedChunk(t *testing.T) {
+	linkedIn := &fakeLinkedIn{respond: func(request gateway.ProxyRequest) (int, any) {
+		return http.StatusForbidden, map[string]any{"status": 403, "message": "Not enough permissions"}
+	}}
+	values := make([]string, 250)
+	for i := range values {
+		values[i] = "urn:li:geo:" + strconv.Itoa(i+1)
+	}
+
+	names, warnings := newTestResolver(linkedIn).Resolve(testContext(), "512345678", values)
+
+	require.Empty(t, names)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "could not resolve geo names")
+	require.Len(t, linkedIn.requests, 1)
+}
+
+func TestResolve_CampaignGroupsUseSearchFinder(t *testing.T) {
+	linkedIn := &fakeLinkedIn{respond: func(request gateway.ProxyRequest) (int, any) {
+		return http.StatusOK, map[string]any{
+			"elements": []any{
+				map[string]any{"id": float64(612345678), "name": "Brand"},
+				map[string]any{"id": "urn:li:sponsoredCampaignGroup:612345679", "name": "Pipeline"},
+			},
+			"paging": map[string]any{},
+		}
+	}}
+
+	names, warnings := newTestResolver(linkedIn).Resolve(testContext(), "512345678", []string{
+		"urn:li:sponsoredCampaignGroup:612345678",
+		"urn:li:sponsoredCampaignGroup:612345679",
+	})
+
+	require.Empty(t, warnings)
+	require.Equal(t, map[string]string{
+		"urn:li:sponsoredCampaignGroup:612345678": "Brand",
+		"urn:li:sponsoredCampaignGroup:612345679": "Pipeline",
+	}, names)
+	require.Len(t, linkedIn.requests, 1)
+	request := linkedIn.requests[0]
+	require.Equal(t, "adAccounts/512345678/adCampaignGroups", request.ResourcePath)
+	require.Equal(t, "search", request.Query["q"])
+	require.Equal(t, "(id:(values:List(urn:li:sponsoredCampaignGroup:612345678,urn:li:sponsoredCampaignGroup:612345679)))", request.Query["search"])
+	require.Equal(t, "2", request.Query["pageSize"])
+}
+
+// fakeLinkedIn is a connected [gateway.Provider] answering every proxied call with respond.
+type fakeLinkedIn struct {
+	respond  func(request gateway.ProxyRequest) (int, any)
+	requests []gateway.ProxyRequest
+}
+
+func (f *fakeLinkedIn) GetLinkedInConnection(context.Context, string) (*api.Response, error) {
+	return &api.Response{StatusCode: http.StatusOK, Body: []byte(`{"connected":true}`)}, nil
+}
+
+func (f *fakeLinkedIn) ProxyLinkedInRequestOrRefresh(_ context.Context, _ string, request gateway.ProxyRequest) (*api.Response, error) {
+	f.requests = append(f.requests, request)
+	status, body := f.respond(request)
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &api.Response{StatusCode: status, Body: encoded}, nil
+}
+
+func newTestResolver(provider gateway.Provider) *Resolver {
+	const baseURL = "https://api.linkedin.com/rest"
+	executor := gateway.NewExecutor(provider, nil)
+	return NewResolver(
+		campaigns.NewRepository(executor, campaigns.NewQueryBuilder(baseURL)),
+		campaigngroups.NewRepository(executor, campaigngroups.NewQueryBuilder(baseURL)),
+		creatives.NewRepository(executor, creatives.NewQueryBuilder(baseURL)),
+		adaccounts.NewRepository(executor, adaccounts.NewQueryBuilder(baseURL)),
+		lookup.NewRepository(executor, lookup.NewQueryBuilder(baseURL)),
+	)
+}
+
+func testContext() context.Context {
+	return middleware.ContextWithUserID(context.Background(), "user_1")
+}
+
+func batchKeys(request gateway.ProxyRequest) []string {
+	list := strings.TrimSuffix(strings.TrimPrefix(request.Query["ids"], "List("), ")")
+	return strings.Split(list, ",")
+}
+
+func batchGetBody(results map[string]any) map[string]any {
+	return map[string]any{"results": results, "statuses": map[string]any{}, "errors": map[string]any{}}
+}
@@ -9,4 +9,5 @@ type Input struct {
 	Names      []string `json:"names" jsonschema:"Filter by ad account names (exact match)"`
 	Start      *int     `json:"start,omitempty" jsonschema:"Pagination start offset (>=0)"`
 	Count      *int     `json:"count,omitempty" jsonschema:"Results per page (1-1000). Default 100"`
+	Cache      string   `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
 }
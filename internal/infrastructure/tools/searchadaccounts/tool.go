@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api/adaccounts"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
 	"linkedin-mcp/internal/infrastructure/tools/searchadaccounts/dto"
 	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
 
@@ -27,6 +28,10 @@ func (t *Tool) SearchAdAccounts(ctx context.Context, req *mcp.CallToolRequest, i
 	if err := t.validateInput(input); err != nil {
 		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
 	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
 
 	searchInput := t.convertInput(input)
 
@@ -0,0 +1,13 @@
+package dto
+
+type Input struct {
+	AccountID        string   `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric value, e.g., 512345678)"`
+	CampaignGroupIDs []string `json:"campaignGroupIDs,omitempty" jsonschema:"Filter by campaign group IDs or URNs (urn:li:sponsoredCampaignGroup:{id})"`
+	Status           []string `json:"status,omitempty" jsonschema:"Filter by status: ACTIVE, PAUSED, ARCHIVED, CANCELED, DRAFT, PENDING_DELETION, REMOVED"`
+	Name             []string `json:"name,omitempty" jsonschema:"Filter by name (exact match)"`
+	Test             *bool    `json:"test,omitempty" jsonschema:"Filter by test campaign groups: true or false (both if omitted)"`
+	SortOrder        string   `json:"sortOrder,omitempty" jsonschema:"Sort by campaign group ID: ASCENDING or DESCENDING (default ASCENDING)"`
+	PageSize         int      `json:"pageSize,omitempty" jsonschema:"Results per page (1-1000). Default 100"`
+	PageToken        *string  `json:"pageToken,omitempty" jsonschema:"Opaque cursor for pagination"`
+	Cache            string   `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
+}
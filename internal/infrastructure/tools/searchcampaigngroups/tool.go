@@ -0,0 +1,115 @@
+package searchcampaigngroups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/campaigngroups"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
+	"linkedin-mcp/internal/infrastructure/tools/searchcampaigngroups/dto"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxPageSize = 1000
+
+type Tool struct {
+	repository *campaigngroups.Repository
+	connectURL string
+}
+
+func NewTool(repository *campaigngroups.Repository, connectURL string) *Tool {
+	return &Tool{
+		repository: repository,
+		connectURL: connectURL,
+	}
+}
+
+func (t *Tool) SearchCampaignGroups(ctx context.Context, req *mcp.CallToolRequest, input dto.Input) (*mcp.CallToolResult, dto.Output, error) {
+	result := &mcp.CallToolResult{}
+
+	searchInput, err := convertInput(input)
+	if err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
+
+	searchResult, err := t.repository.SearchCampaignGroups(ctx, searchInput)
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("search campaign groups", err, t.connectURL)
+	}
+
+	return result, dto.Output{
+		Elements: searchResult.Elements,
+		Metadata: dto.Metadata{
+			NextPageToken: searchResult.Metadata.NextPageToken,
+		},
+	}, nil
+}
+
+// convertInput validates the tool input and maps it to the repository filters. Campaign
+// group references may be numeric IDs or URNs; the search finder only accepts URNs.
+func convertInput(input dto.Input) (campaigngroups.SearchInput, error) {
+	accountID := strings.TrimSpace(input.AccountID)
+	if accountID == "" {
+		return campaigngroups.SearchInput{}, fmt.Errorf("accountID is required")
+	}
+
+	if input.PageSize < 0 {
+		return campaigngroups.SearchInput{}, fmt.Errorf("pageSize must be non-negative")
+	}
+	if input.PageSize > maxPageSize {
+		return campaigngroups.SearchInput{}, fmt.Errorf("pageSize cannot exceed %d", maxPageSize)
+	}
+
+	sortOrder := strings.ToUpper(strings.TrimSpace(input.SortOrder))
+	if sortOrder != "" && sortOrder != "ASCENDING" && sortOrder != "DESCENDING" {
+		return campaigngroups.SearchInput{}, fmt.Errorf("sortOrder must be either ASCENDING or DESCENDING")
+	}
+
+	statuses := make([]string, 0, len(input.Status))
+	for _, status := range input.Status {
+		status = strings.ToUpper(strings.TrimSpace(status))
+		if !campaigngroups.IsValidStatus(status) {
+			return campaigngroups.SearchInput{}, fmt.Errorf("invalid status: %s", status)
+		}
+		statuses = append(statuses, status)
+	}
+
+	urns := make([]string, 0, len(input.CampaignGroupIDs))
+	for i, reference := range input.CampaignGroupIDs {
+		id, err := campaigngroups.ParseCampaignGroupID(reference)
+		if err != nil {
+			return campaigngroups.SearchInput{}, fmt.Errorf("campaignGroupIDs[%d]: %w", i, err)
+		}
+		urns = append(urns, campaigngroups.CampaignGroupURN(id))
+	}
+
+	names := make([]string, 0, len(input.Name))
+	for _, name := range input.Name {
+		if trimmed := strings.TrimSpace(name); trimmed != "" {
+			names = append(names, trimmed)
+		}
+	}
+
+	var pageToken string
+	if input.PageToken != nil {
+		pageToken = strings.TrimSpace(*input.PageToken)
+	}
+
+	return campaigngroups.SearchInput{
+		AccountID:         accountID,
+		CampaignGroupURNs: urns,
+		Status:            statuses,
+		Name:              names,
+		Test:              input.Test,
+		SortOrder:         sortOrder,
+		PageSize:          input.PageSize,
+		PageToken:         pageToken,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package searchcampaigngroups
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/searchcampaigngroups/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertInput_NormalizesFilters(t *testing.T) {
+	token := " next "
+	searchInput, err := convertInput(dto.Input{
+		AccountID:        " 512345678 ",
+		CampaignGroupIDs: []string{"123", "urn:li:sponsoredCampaignGroup:456"},
+		Status:           []string{"active"},
+		Name:             []string{" Brand ", ""},
+		SortOrder:        "descending",
+		PageSize:         25,
+		PageToken:        &token,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "512345678", searchInput.AccountID)
+	require.Equal(t, []string{"urn:li:sponsoredCampaignGroup:123", "urn:li:sponsoredCampaignGroup:456"}, searchInput.CampaignGroupURNs)
+	require.Equal(t, []string{"ACTIVE"}, searchInput.Status)
+	require.Equal(t, []string{"Brand"}, searchInput.Name)
+	require.Equal(t, "DESCENDING", searchInput.SortOrder)
+	require.Equal(t, "next", searchInput.PageToken)
+}
+
+func TestConvertInput_RejectsInvalidInput(t *testing.T) {
+	cases := map[string]dto.Input{
+		"missing account": {},
+		"bad status":      {AccountID: "1", Status: []string{"COMPLETED"}},
+		"bad id":          {AccountID: "1", CampaignGroupIDs: []string{"urn:li:sponsoredCampaign:1"}},
+		"page size":       {AccountID: "1", PageSize: 1001},
+		"negative page":   {AccountID: "1", PageSize: -1},
+		"bad sort order":  {AccountID: "1", SortOrder: "NEWEST"},
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := convertInput(input)
+			require.Error(t, err)
+		})
+	}
+}
@@ -12,4 +12,5 @@ type Input struct {
 	SortOrder              string   `json:"sortOrder" jsonschema:"Sort by campaign ID: ASCENDING or DESCENDING (default ASCENDING)"`
 	PageSize               int      `json:"pageSize" jsonschema:"Results per page (1-1000). Default 100"`
 	PageToken              *string  `json:"pageToken,omitempty" jsonschema:"Opaque cursor for pagination"`
+	Cache                  string   `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
 }
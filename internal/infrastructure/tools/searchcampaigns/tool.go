@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api/campaigns"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
 	"linkedin-mcp/internal/infrastructure/tools/searchcampaigns/dto"
 	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
 
@@ -30,6 +31,10 @@ func (t *Tool) SearchCampaigns(ctx context.Context, req *mcp.CallToolRequest, in
 	if err := t.validateInput(input); err != nil {
 		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
 	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
 
 	searchInput := t.convertInput(input)
 
@@ -72,18 +77,8 @@ func (t *Tool) validateInput(input dto.Input) error {
 	}
 
 	// Validate status values
-	validStatuses := map[string]bool{
-		"ACTIVE":           true,
-		"PAUSED":           true,
-		"ARCHIVED":         true,
-		"COMPLETED":        true,
-		"CANCELED":         true,
-		"DRAFT":            true,
-		"PENDING_DELETION": true,
-		"REMOVED":          true,
-	}
 	for _, status := range input.Status {
-		if !validStatuses[status] {
+		if !campaigns.IsValidStatus(status) {
 			return fmt.Errorf("invalid status: %s", status)
 		}
 	}
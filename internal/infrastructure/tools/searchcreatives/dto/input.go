@@ -7,4 +7,5 @@ type Input struct {
 	PageSize    *int    `json:"pageSize,omitempty" jsonschema:"Page size for cursor pagination (1-100). Default 100"`
 	PageToken   *string `json:"pageToken,omitempty" jsonschema:"Opaque cursor from prior response paging.nextPageToken"`
 	SortOrder   string  `json:"sortOrder,omitempty" jsonschema:"ASCENDING or DESCENDING (default ASCENDING)"`
+	Cache       string  `json:"cache,omitempty" jsonschema:"Response cache mode: default (reuse a recent identical read) or bypass (always fetch fresh data from LinkedIn, e.g. right after changes made in Campaign Manager)"`
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"linkedin-mcp/internal/infrastructure/api/creatives"
+	"linkedin-mcp/internal/infrastructure/tools/cachecontrol"
 	"linkedin-mcp/internal/infrastructure/tools/searchcreatives/dto"
 	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
 
@@ -31,6 +32,10 @@ func (t *Tool) SearchCreatives(ctx context.Context, req *mcp.CallToolRequest, in
 	if err := validateInput(&input); err != nil {
 		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
 	}
+	if err := cachecontrol.Validate(input.Cache); err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+	ctx = cachecontrol.Apply(ctx, input.Cache)
 
 	campaignURN, err := resolveCampaignURN(input.CampaignURN, input.CampaignID)
 	if err != nil {
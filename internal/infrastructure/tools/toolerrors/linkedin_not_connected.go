@@ -9,12 +9,25 @@ import (
 
 func WrapToolExecutionError(operation string, err error, connectURL string) error {
 	if gateway.IsLinkedInNotConnected(err) {
+		if userConnectURL := gateway.ConnectURLFromError(err); userConnectURL != "" {
+			connectURL = userConnectURL
+		}
 		return fmt.Errorf(
 			"cannot %s because no LinkedIn account is connected for this user. Ask the user to open %s, complete LinkedIn connection, and then retry this tool call",
 			operation,
 			connectURL,
 		)
 	}
+	if unavailableErr, ok := gateway.AsGatewayUnavailable(err); ok {
+		return fmt.Errorf(
+			"cannot %s because the LinkedIn gateway is temporarily unavailable. Retry after %d seconds; further calls fail immediately until then",
+			operation,
+			unavailableErr.RetryAfterSeconds(),
+		)
+	}
+	if rateLimitErr, ok := gateway.AsRateLimit(err); ok {
+		return fmt.Errorf("cannot %s because %s. %s", operation, rateLimitSubject(rateLimitErr), rateLimitAdvice(rateLimitErr))
+	}
 	if validationErr, ok := gateway.AsLinkedInParamValidation(err); ok {
 		details := strings.TrimSpace(validationFields(validationErr))
 		if details != "" {
@@ -31,6 +44,20 @@ func WrapToolExecutionError(operation string, err error, connectURL string) erro
 	return fmt.Errorf("failed to %s: %w", operation, err)
 }
 
+func rateLimitSubject(err *gateway.RateLimitError) string {
+	if err.Source == gateway.RateLimitSourceGateway {
+		return "the Jumon gateway is rate limiting requests"
+	}
+	return "LinkedIn is rate limiting requests for this user"
+}
+
+func rateLimitAdvice(err *gateway.RateLimitError) string {
+	if err.RetryAfter > 0 {
+		return fmt.Sprintf("Rate limited, retry after %d seconds; do not retry this tool call sooner", err.RetryAfterSeconds())
+	}
+	return "Rate limited; wait at least a minute before retrying this tool call"
+}
+
 func validationMessage(err *gateway.LinkedInParamValidationError) string {
 	message := strings.TrimSpace(err.Message)
 	if message == "" {
@@ -2,7 +2,9 @@ package toolerrors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"linkedin-mcp/internal/infrastructure/api/gateway"
 
@@ -17,6 +19,15 @@ func TestWrapToolExecutionError(t *testing.T) {
 	require.Contains(t, err.Error(), "retry this tool call")
 }
 
+func TestWrapToolExecutionError_NotConnectedUsesUserConnectURL(t *testing.T) {
+	notConnected := fmt.Errorf("proxy: %w", &gateway.NotConnectedError{ConnectURL: "https://www.linkedin.com/oauth/v2/authorization?state=abc"})
+	err := WrapToolExecutionError("search ad accounts", notConnected, "https://app.example.com/connections")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no LinkedIn account is connected")
+	require.Contains(t, err.Error(), "https://www.linkedin.com/oauth/v2/authorization?state=abc")
+	require.NotContains(t, err.Error(), "https://app.example.com/connections")
+}
+
 func TestWrapToolExecutionError_GenericError(t *testing.T) {
 	err := WrapToolExecutionError("search campaigns", errors.New("gateway timed out"), "https://app.example.com/connections")
 	require.Error(t, err)
@@ -40,3 +51,27 @@ func TestWrapToolExecutionError_ValidationError(t *testing.T) {
 	require.Contains(t, err.Error(), "Field `search`")
 	require.Contains(t, err.Error(), "retry this tool call")
 }
+
+func TestWrapToolExecutionError_RateLimitError(t *testing.T) {
+	err := WrapToolExecutionError("get analytics", &gateway.RateLimitError{
+		Source:     gateway.RateLimitSourceLinkedIn,
+		StatusCode: 429,
+		RetryAfter: 1500 * time.Millisecond,
+	}, "https://app.example.com/connections")
+	require.EqualError(t, err, "cannot get analytics because LinkedIn is rate limiting requests for this user. Rate limited, retry after 2 seconds; do not retry this tool call sooner")
+
+	err = WrapToolExecutionError("get analytics", fmt.Errorf("page 2: %w", &gateway.RateLimitError{
+		Source:     gateway.RateLimitSourceGateway,
+		StatusCode: 503,
+	}), "https://app.example.com/connections")
+	require.Contains(t, err.Error(), "Jumon gateway is rate limiting")
+	require.Contains(t, err.Error(), "wait at least a minute")
+}
+
+func TestWrapToolExecutionError_GatewayUnavailable(t *testing.T) {
+	err := WrapToolExecutionError("search campaigns", fmt.Errorf("failed to make request: %w", &gateway.GatewayUnavailableError{
+		Endpoint:   gateway.EndpointProxy,
+		RetryAfter: 12300 * time.Millisecond,
+	}), "https://app.example.com/connections")
+	require.EqualError(t, err, "cannot search campaigns because the LinkedIn gateway is temporarily unavailable. Retry after 13 seconds; further calls fail immediately until then")
+}
@@ -0,0 +1,24 @@
+package dto
+
+type Input struct {
+	AccountID         string           `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric value, e.g., 512345678)"`
+	CurrencyCode      string           `json:"currencyCode,omitempty" jsonschema:"Optional ISO currency code of the amounts (e.g. USD). Must match the ad account currency; the account currency is used when omitted"`
+	Updates           []CampaignUpdate `json:"updates" jsonschema:"Budget and bid changes, one entry per campaign (up to 20)"`
+	DryRun            bool             `json:"dryRun,omitempty" jsonschema:"When true, only return the diff and guardrail checks; never apply changes"`
+	Confirm           bool             `json:"confirm,omitempty" jsonschema:"Set to true together with confirmationToken to apply a diff previously returned as confirmation_required"`
+	ConfirmationToken string           `json:"confirmationToken,omitempty" jsonschema:"Token returned by the previous confirmation_required response; must be echoed unchanged"`
+}
+
+type CampaignUpdate struct {
+	CampaignID  string  `json:"campaignID" jsonschema:"Campaign numeric ID or URN (urn:li:sponsoredCampaign:{id})"`
+	DailyBudget *string `json:"dailyBudget,omitempty" jsonschema:"New daily budget amount as a decimal string in the account currency (e.g. \"150.00\")"`
+	TotalBudget *string `json:"totalBudget,omitempty" jsonschema:"New lifetime budget amount as a decimal string in the account currency"`
+	UnitCost    *string `json:"unitCost,omitempty" jsonschema:"New bid amount (unitCost) as a decimal string in the account currency"`
+	EndDate     *Date   `json:"endDate,omitempty" jsonschema:"New run schedule end date (UTC); the campaign stops at the end of this day"`
+}
+
+type Date struct {
+	Year  int `json:"year" jsonschema:"Year (e.g., 2026)"`
+	Month int `json:"month" jsonschema:"Month (1-12)"`
+	Day   int `json:"day" jsonschema:"Day (1-31)"`
+}
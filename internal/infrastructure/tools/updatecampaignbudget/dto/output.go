@@ -0,0 +1,45 @@
+package dto
+
+// Outcomes reported in Output.State.
+const (
+	StateDryRun               = "dry_run"
+	StateConfirmationRequired = "confirmation_required"
+	StateApplied              = "applied"
+	StateDeclined             = "declined"
+	StateNoChanges            = "no_changes"
+)
+
+// Per-campaign results reported in CampaignDiff.Result.
+const (
+	ResultPending   = "pending"
+	ResultUpdated   = "updated"
+	ResultUnchanged = "unchanged"
+	ResultRejected  = "rejected"
+	ResultNotFound  = "not_found"
+	ResultFailed    = "failed"
+)
+
+type Output struct {
+	State             string         `json:"state" jsonschema:"dry_run, confirmation_required, applied, declined or no_changes"`
+	Message           string         `json:"message,omitempty" jsonschema:"Human-readable summary of what happened or what must be confirmed"`
+	ConfirmationToken string         `json:"confirmationToken,omitempty" jsonschema:"Echo this token with confirm=true to apply the diff"`
+	CurrencyCode      string         `json:"currencyCode,omitempty" jsonschema:"Ad account currency used for every amount"`
+	Campaigns         []CampaignDiff `json:"campaigns" jsonschema:"Planned or applied diff per campaign"`
+}
+
+type CampaignDiff struct {
+	CampaignID  string        `json:"campaignID" jsonschema:"Numeric campaign ID"`
+	CampaignURN string        `json:"campaignURN" jsonschema:"Campaign URN"`
+	Name        string        `json:"name,omitempty" jsonschema:"Campaign name"`
+	Changes     []FieldChange `json:"changes,omitempty" jsonschema:"Field-level before/after values"`
+	Violations  []string      `json:"violations,omitempty" jsonschema:"Guardrails the requested change breaks; rejected campaigns are never updated"`
+	Result      string        `json:"result" jsonschema:"pending, updated, unchanged, rejected, not_found or failed"`
+	Reason      string        `json:"reason,omitempty" jsonschema:"Why the campaign was rejected or failed"`
+}
+
+type FieldChange struct {
+	Field         string   `json:"field" jsonschema:"dailyBudget, totalBudget, unitCost or runSchedule.end"`
+	Before        string   `json:"before,omitempty" jsonschema:"Current value (amount, or ISO date for runSchedule.end)"`
+	After         string   `json:"after" jsonschema:"Requested value"`
+	ChangePercent *float64 `json:"changePercent,omitempty" jsonschema:"Relative change of a monetary field in percent (absent when there is no previous value)"`
+}
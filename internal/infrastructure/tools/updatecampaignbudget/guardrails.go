@@ -0,0 +1,83 @@
+package updatecampaignbudget
+
+import (
+	"fmt"
+	"math"
+)
+
+// Guardrails bound how far a single update_campaign_budget call may move spend. They are
+// enforced server-side so an agent cannot bypass them by phrasing the request differently.
+type Guardrails struct {
+	// MaxChangePercent caps the relative change, up or down, of any monetary field in one
+	// call. Zero disables the check.
+	MaxChangePercent float64
+	// Absolute ceilings per ISO currency code. A currency without an entry has no ceiling, so
+	// a field without a current value (or a zero one) cannot be set at all: no percentage can
+	// bound that change.
+	DailyBudgetCeilings map[string]float64
+	TotalBudgetCeilings map[string]float64
+	UnitCostCeilings    map[string]float64
+}
+
+const (
+	fieldDailyBudget = "dailyBudget"
+	fieldTotalBudget = "totalBudget"
+	fieldUnitCost    = "unitCost"
+	fieldRunEnd      = "runSchedule.end"
+)
+
+// checkAmount returns the guardrail violations of moving field from before to after. before
+// is nil when the campaign has no current value. The percent cap cannot bound a change from
+// nothing or zero, so such a change needs a configured ceiling for currency.
+func (g Guardrails) checkAmount(field, currency string, before *float64, after float64) []string {
+	var violations []string
+
+	ceiling, hasCeiling := g.ceilings(field)[currency]
+	if hasCeiling && after > ceiling {
+		violations = append(violations, fmt.Sprintf("%s %.2f %s exceeds the configured ceiling of %.2f %s", field, after, currency, ceiling, currency))
+	}
+
+	if before == nil || *before == 0 {
+		if !hasCeiling && after > 0 {
+			violations = append(violations, fmt.Sprintf("%s has no current value and no ceiling is configured for %s; set it in Campaign Manager or configure a %s ceiling", field, currency, field))
+		}
+		return violations
+	}
+
+	if g.MaxChangePercent > 0 {
+		if ratio := relativeChange(*before, after); math.Abs(ratio)*100 > g.MaxChangePercent {
+			violations = append(violations, fmt.Sprintf("%s change of %.1f%% exceeds the maximum of %.1f%% per call", field, *changePercent(*before, after), g.MaxChangePercent))
+		}
+	}
+
+	return violations
+}
+
+func (g Guardrails) ceilings(field string) map[string]float64 {
+	switch field {
+	case fieldDailyBudget:
+		return g.DailyBudgetCeilings
+	case fieldTotalBudget:
+		return g.TotalBudgetCeilings
+	case fieldUnitCost:
+		return g.UnitCostCeilings
+	default:
+		return nil
+	}
+}
+
+// changePercent returns the relative change from before to after in percent, rounded to one
+// decimal for display, or nil when before is zero and a percentage is undefined. Guardrails
+// compare the unrounded [relativeChange].
+func changePercent(before, after float64) *float64 {
+	if before == 0 {
+		return nil
+	}
+	percent := math.Round(relativeChange(before, after)*1000) / 10
+	return &percent
+}
+
+// relativeChange returns (after-before)/before; before must not be zero.
+func relativeChange(before, after float64) float64 {
+	return (after - before) / before
+}
@@ -0,0 +1,428 @@
+package updatecampaignbudget
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/api/adaccounts"
+	"linkedin-mcp/internal/infrastructure/api/campaigns"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/tools/confirmation"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+	"linkedin-mcp/internal/infrastructure/tools/updatecampaignbudget/dto"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxUpdatesPerCall = 20
+
+type Tool struct {
+	campaignsRepository  *campaigns.Repository
+	adAccountsRepository *adaccounts.Repository
+	guardrails           Guardrails
+	connectURL           string
+	now                  func() time.Time
+}
+
+func NewTool(campaignsRepository *campaigns.Repository, adAccountsRepository *adaccounts.Repository, guardrails Guardrails, connectURL string) *Tool {
+	return &Tool{
+		campaignsRepository:  campaignsRepository,
+		adAccountsRepository: adAccountsRepository,
+		guardrails:           guardrails,
+		connectURL:           connectURL,
+		now:                  time.Now,
+	}
+}
+
+// campaignUpdate is a validated dto.CampaignUpdate with parsed amounts.
+type campaignUpdate struct {
+	campaignID  string
+	dailyBudget *float64
+	totalBudget *float64
+	unitCost    *float64
+	endTime     *time.Time
+}
+
+// UpdateCampaignBudget computes a diff of the requested budget, bid and end date changes,
+// checks it against the configured guardrails and only applies it after confirmation.
+func (t *Tool) UpdateCampaignBudget(ctx context.Context, req *mcp.CallToolRequest, input dto.Input) (*mcp.CallToolResult, dto.Output, error) {
+	result := &mcp.CallToolResult{}
+
+	updates, err := t.validateInput(&input)
+	if err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	// Plans and guardrails must be computed against live state, never a cached read.
+	ctx = gateway.WithCacheBypass(ctx)
+	currency, err := t.accountCurrency(ctx, input.AccountID)
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("look up ad account currency", err, t.connectURL)
+	}
+	if input.CurrencyCode != "" && input.CurrencyCode != currency {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: currencyCode %s does not match the ad account currency %s", input.CurrencyCode, currency)
+	}
+
+	urns := make([]string, len(updates))
+	for i, update := range updates {
+		urns[i] = campaigns.CampaignURN(update.campaignID)
+	}
+	current, err := t.campaignsRepository.SearchCampaigns(ctx, campaigns.SearchInput{
+		AccountID:    input.AccountID,
+		CampaignURNs: urns,
+		PageSize:     maxUpdatesPerCall,
+	})
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("look up campaigns", err, t.connectURL)
+	}
+
+	diffs, patches := t.planDiffs(updates, current.Elements, currency)
+	output := dto.Output{CurrencyCode: currency, Campaigns: diffs}
+
+	if input.DryRun {
+		output.State = dto.StateDryRun
+		output.Message = "Dry run only; nothing was modified."
+		return result, output, nil
+	}
+	if countResult(diffs, dto.ResultPending) == 0 {
+		output.State = dto.StateNoChanges
+		output.Message = "No campaign can be updated; see per-campaign results and guardrail violations."
+		return result, output, nil
+	}
+
+	token := planToken(input.AccountID, currency, diffs)
+	if !input.Confirm || input.ConfirmationToken != token {
+		switch confirmation.Elicit(ctx, req, confirmationMessage(currency, diffs)) {
+		case confirmation.Declined:
+			output.State = dto.StateDeclined
+			output.Message = "The user declined the budget change; nothing was modified."
+			return result, output, nil
+		case confirmation.Unavailable:
+			output.State = dto.StateConfirmationRequired
+			output.ConfirmationToken = token
+			output.Message = "Review the diff with the user, then call this tool again with the same updates, confirm=true and the same confirmationToken."
+			if input.Confirm {
+				output.Message = "The confirmationToken does not match the current diff. " + output.Message
+			}
+			return result, output, nil
+		}
+	}
+
+	if err := t.applyPatches(ctx, input.AccountID, diffs, patches); err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("update campaign budget", err, t.connectURL)
+	}
+
+	output.State = dto.StateApplied
+	output.Message = fmt.Sprintf("%d campaign(s) updated, %d failed.", countResult(diffs, dto.ResultUpdated), countResult(diffs, dto.ResultFailed))
+	return result, output, nil
+}
+
+func (t *Tool) validateInput(input *dto.Input) ([]campaignUpdate, error) {
+	input.AccountID = strings.TrimSpace(input.AccountID)
+	if input.AccountID == "" {
+		return nil, fmt.Errorf("accountID is required")
+	}
+	for _, r := range input.AccountID {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("accountID must contain only digits")
+		}
+	}
+	input.CurrencyCode = strings.ToUpper(strings.TrimSpace(input.CurrencyCode))
+	input.ConfirmationToken = strings.TrimSpace(input.ConfirmationToken)
+
+	if len(input.Updates) == 0 {
+		return nil, fmt.Errorf("updates is required and cannot be empty")
+	}
+	if len(input.Updates) > maxUpdatesPerCall {
+		return nil, fmt.Errorf("updates cannot exceed %d entries", maxUpdatesPerCall)
+	}
+
+	seen := make(map[string]struct{}, len(input.Updates))
+	updates := make([]campaignUpdate, 0, len(input.Updates))
+	for i, raw := range input.Updates {
+		id, err := campaigns.ParseCampaignID(raw.CampaignID)
+		if err != nil {
+			return nil, fmt.Errorf("updates[%d].campaignID: %w", i, err)
+		}
+		if _, exists := seen[id]; exists {
+			return nil, fmt.Errorf("updates[%d]: campaign %s is listed more than once", i, id)
+		}
+		seen[id] = struct{}{}
+
+		update := campaignUpdate{campaignID: id}
+		if update.dailyBudget, err = parseAmount(raw.DailyBudget); err != nil {
+			return nil, fmt.Errorf("updates[%d].dailyBudget: %w", i, err)
+		}
+		if update.totalBudget, err = parseAmount(raw.TotalBudget); err != nil {
+			return nil, fmt.Errorf("updates[%d].totalBudget: %w", i, err)
+		}
+		if update.unitCost, err = parseAmount(raw.UnitCost); err != nil {
+			return nil, fmt.Errorf("updates[%d].unitCost: %w", i, err)
+		}
+		if raw.EndDate != nil {
+			end, err := endOfDay(*raw.EndDate)
+			if err != nil {
+				return nil, fmt.Errorf("updates[%d].endDate: %w", i, err)
+			}
+			if !end.After(t.now()) {
+				return nil, fmt.Errorf("updates[%d].endDate must be in the future", i)
+			}
+			update.endTime = &end
+		}
+		if update.dailyBudget == nil && update.totalBudget == nil && update.unitCost == nil && update.endTime == nil {
+			return nil, fmt.Errorf("updates[%d] must change at least one of dailyBudget, totalBudget, unitCost or endDate", i)
+		}
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+func (t *Tool) accountCurrency(ctx context.Context, accountID string) (string, error) {
+	accounts, err := t.adAccountsRepository.SearchAdAccounts(ctx, adaccounts.SearchInput{AccountIDs: []string{accountID}})
+	if err != nil {
+		return "", err
+	}
+	for _, account := range accounts.Elements {
+		if currency, ok := account["currency"].(string); ok && currency != "" {
+			return strings.ToUpper(currency), nil
+		}
+	}
+	return "", fmt.Errorf("ad account %s was not found or has no currency", accountID)
+}
+
+// planDiffs builds the per-campaign diff and the partial update patch for every campaign that
+// passes the guardrails. Patches are keyed by campaign ID.
+func (t *Tool) planDiffs(updates []campaignUpdate, elements []map[string]any, currency string) ([]dto.CampaignDiff, map[string]map[string]any) {
+	byID := make(map[string]map[string]any, len(elements))
+	for _, element := range elements {
+		if id := campaigns.ElementID(element); id != "" {
+			byID[id] = element
+		}
+	}
+
+	diffs := make([]dto.CampaignDiff, 0, len(updates))
+	patches := make(map[string]map[string]any, len(updates))
+	for _, update := range updates {
+		diff := dto.CampaignDiff{
+			CampaignID:  update.campaignID,
+			CampaignURN: campaigns.CampaignURN(update.campaignID),
+		}
+
+		element, ok := byID[update.campaignID]
+		if !ok {
+			diff.Result = dto.ResultNotFound
+			diff.Reason = "campaign not found in this ad account"
+			diffs = append(diffs, diff)
+			continue
+		}
+		diff.Name, _ = element["name"].(string)
+
+		patch := map[string]any{}
+		t.planAmount(&diff, patch, element, fieldDailyBudget, update.dailyBudget, currency)
+		t.planAmount(&diff, patch, element, fieldTotalBudget, update.totalBudget, currency)
+		t.planAmount(&diff, patch, element, fieldUnitCost, update.unitCost, currency)
+		planEndDate(&diff, patch, element, update.endTime)
+
+		switch {
+		case len(diff.Violations) > 0:
+			diff.Result = dto.ResultRejected
+			diff.Reason = "guardrail violation"
+		case len(patch) == 0:
+			diff.Result = dto.ResultUnchanged
+		default:
+			diff.Result = dto.ResultPending
+			patches[update.campaignID] = patch
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, patches
+}
+
+func (t *Tool) planAmount(diff *dto.CampaignDiff, patch map[string]any, element map[string]any, field string, requested *float64, currency string) {
+	if requested == nil {
+		return
+	}
+
+	before, beforeCurrency := moneyField(element, field)
+	if beforeCurrency != "" && beforeCurrency != currency {
+		diff.Violations = append(diff.Violations, fmt.Sprintf("%s is in %s but the ad account currency is %s", field, beforeCurrency, currency))
+		return
+	}
+	if before != nil && *before == *requested {
+		return
+	}
+
+	change := dto.FieldChange{Field: field, After: formatAmount(*requested)}
+	if before != nil {
+		change.Before = formatAmount(*before)
+		change.ChangePercent = changePercent(*before, *requested)
+	}
+	diff.Changes = append(diff.Changes, change)
+	diff.Violations = append(diff.Violations, t.guardrails.checkAmount(field, currency, before, *requested)...)
+
+	patch[field] = map[string]any{
+		"amount":       formatAmount(*requested),
+		"currencyCode": currency,
+	}
+}
+
+func planEndDate(diff *dto.CampaignDiff, patch map[string]any, element map[string]any, requested *time.Time) {
+	if requested == nil {
+		return
+	}
+
+	schedule, _ := element["runSchedule"].(map[string]any)
+	start, hasStart := schedule["start"].(float64)
+	if !hasStart {
+		diff.Violations = append(diff.Violations, "campaign has no run schedule start, so its end date cannot be set")
+		return
+	}
+	endMillis := requested.UnixMilli()
+	if float64(endMillis) <= start {
+		diff.Violations = append(diff.Violations, "endDate must be after the campaign start date")
+		return
+	}
+
+	change := dto.FieldChange{Field: fieldRunEnd, After: requested.Format(time.DateOnly)}
+	if end, ok := schedule["end"].(float64); ok {
+		if int64(end) == endMillis {
+			return
+		}
+		change.Before = time.UnixMilli(int64(end)).UTC().Format(time.DateOnly)
+	}
+	diff.Changes = append(diff.Changes, change)
+
+	// runSchedule is a record, so the patch replaces it whole; carry the current start over.
+	patch["runSchedule"] = map[string]any{
+		"start": int64(start),
+		"end":   endMillis,
+	}
+}
+
+// applyPatches updates campaigns one by one so a LinkedIn rejection is reported on the
+// affected campaign. Losing the LinkedIn connection aborts the remaining updates.
+func (t *Tool) applyPatches(ctx context.Context, accountID string, diffs []dto.CampaignDiff, patches map[string]map[string]any) error {
+	for i := range diffs {
+		if diffs[i].Result != dto.ResultPending {
+			continue
+		}
+
+		err := t.campaignsRepository.UpdateCampaign(ctx, campaigns.UpdateInput{
+			AccountID:  accountID,
+			CampaignID: diffs[i].CampaignID,
+			Set:        patches[diffs[i].CampaignID],
+		})
+		if gateway.IsLinkedInNotConnected(err) {
+			return err
+		}
+		if err != nil {
+			diffs[i].Result = dto.ResultFailed
+			diffs[i].Reason = err.Error()
+			continue
+		}
+		diffs[i].Result = dto.ResultUpdated
+	}
+	return nil
+}
+
+// planToken binds the confirmation to the full diff, including current values, so the token
+// is rejected if the request or any campaign changed since the diff was shown.
+func planToken(accountID, currency string, diffs []dto.CampaignDiff) string {
+	parts := []string{accountID, currency}
+	for _, diff := range diffs {
+		if diff.Result != dto.ResultPending {
+			continue
+		}
+		for _, change := range diff.Changes {
+			parts = append(parts, diff.CampaignID+":"+change.Field+":"+change.Before+">"+change.After)
+		}
+	}
+	return confirmation.Token(parts...)
+}
+
+func confirmationMessage(currency string, diffs []dto.CampaignDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Apply these budget changes (%s) to %d LinkedIn campaign(s)?", currency, countResult(diffs, dto.ResultPending))
+	for _, diff := range diffs {
+		if diff.Result != dto.ResultPending {
+			continue
+		}
+		name := diff.Name
+		if name == "" {
+			name = diff.CampaignURN
+		}
+		for _, change := range diff.Changes {
+			before := change.Before
+			if before == "" {
+				before = "unset"
+			}
+			fmt.Fprintf(&b, "\n- %s (%s) %s: %s -> %s", name, diff.CampaignID, change.Field, before, change.After)
+		}
+	}
+	return b.String()
+}
+
+// moneyField reads a LinkedIn MoneyAmount ({amount: "12.50", currencyCode: "USD"}) from a
+// campaign entity. The amount is nil when the field is not set.
+func moneyField(element map[string]any, field string) (*float64, string) {
+	raw, ok := element[field].(map[string]any)
+	if !ok {
+		return nil, ""
+	}
+	currency, _ := raw["currencyCode"].(string)
+	amountRaw, _ := raw["amount"].(string)
+	amount, err := strconv.ParseFloat(strings.TrimSpace(amountRaw), 64)
+	if err != nil {
+		return nil, strings.ToUpper(currency)
+	}
+	return &amount, strings.ToUpper(currency)
+}
+
+func parseAmount(raw *string) (*float64, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	amount, err := strconv.ParseFloat(strings.TrimSpace(*raw), 64)
+	// ParseFloat accepts "NaN" and "Inf", which would slip past every guardrail comparison.
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, fmt.Errorf("must be a decimal amount such as \"150.00\"")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("must be greater than zero")
+	}
+	// formatAmount sends two decimals, so finer amounts would be rounded, "0.001" down to zero.
+	if cents := amount * 100; math.Abs(cents-math.Round(cents)) > 1e-6 {
+		return nil, fmt.Errorf("must have at most two decimal places")
+	}
+	return &amount, nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// endOfDay returns the last millisecond of date in UTC, rejecting dates that do not exist
+// on the calendar (time.Date would silently normalize Feb 30 to Mar 2).
+func endOfDay(date dto.Date) (time.Time, error) {
+	day := time.Date(date.Year, time.Month(date.Month), date.Day, 0, 0, 0, 0, time.UTC)
+	if day.Year() != date.Year || int(day.Month()) != date.Month || day.Day() != date.Day {
+		return time.Time{}, fmt.Errorf("%04d-%02d-%02d is not a valid calendar date", date.Year, date.Month, date.Day)
+	}
+	return day.Add(24*time.Hour - time.Millisecond), nil
+}
+
+func countResult(diffs []dto.CampaignDiff, result string) int {
+	count := 0
+	for _, diff := range diffs {
+		if diff.Result == result {
+			count++
+		}
+	}
+	return count
+}
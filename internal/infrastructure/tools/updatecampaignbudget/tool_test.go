@@ -0,0 +1,188 @@
+package updatecampaignbudget
+
+import (
+	"testing"
+	"time"
+
+	"linkedin-mcp/internal/infrastructure/tools/updatecampaignbudget/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func newTestTool(guardrails Guardrails) *Tool {
+	return &Tool{
+		guardrails: guardrails,
+		now: func() time.Time {
+			return time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		},
+	}
+}
+
+func TestValidateInput_RejectsInvalidCalendarEndDate(t *testing.T) {
+	tool := newTestTool(Guardrails{})
+
+	_, err := tool.validateInput(&dto.Input{
+		AccountID: "512247261",
+		Updates: []dto.CampaignUpdate{
+			{CampaignID: "1", EndDate: &dto.Date{Year: 2026, Month: 2, Day: 30}},
+		},
+	})
+	require.ErrorContains(t, err, "not a valid calendar date")
+}
+
+func TestValidateInput_RequiresAtLeastOneChange(t *testing.T) {
+	tool := newTestTool(Guardrails{})
+
+	_, err := tool.validateInput(&dto.Input{
+		AccountID: "512247261",
+		Updates:   []dto.CampaignUpdate{{CampaignID: "1"}},
+	})
+	require.ErrorContains(t, err, "must change at least one")
+}
+
+func TestPlanDiffs_EnforcesPercentAndCeilingGuardrails(t *testing.T) {
+	tool := newTestTool(Guardrails{
+		MaxChangePercent:    50,
+		DailyBudgetCeilings: map[string]float64{"USD": 1000},
+	})
+	elements := []map[string]any{
+		{"id": float64(1), "name": "Small bump", "dailyBudget": map[string]any{"amount": "100.00", "currencyCode": "USD"}},
+		{"id": float64(2), "name": "10x", "dailyBudget": map[string]any{"amount": "100.00", "currencyCode": "USD"}},
+		{"id": float64(3), "name": "Over ceiling", "dailyBudget": map[string]any{"amount": "900.00", "currencyCode": "USD"}},
+	}
+
+	updates, err := tool.validateInput(&dto.Input{
+		AccountID: "512247261",
+		Updates: []dto.CampaignUpdate{
+			{CampaignID: "1", DailyBudget: stringPtr("120")},
+			{CampaignID: "2", DailyBudget: stringPtr("1000")},
+			{CampaignID: "3", DailyBudget: stringPtr("1100")},
+		},
+	})
+	require.NoError(t, err)
+
+	diffs, patches := tool.planDiffs(updates, elements, "USD")
+	require.Len(t, diffs, 3)
+
+	require.Equal(t, dto.ResultPending, diffs[0].Result)
+	require.Equal(t, "100.00", diffs[0].Changes[0].Before)
+	require.Equal(t, "120.00", diffs[0].Changes[0].After)
+	require.Equal(t, 20.0, *diffs[0].Changes[0].ChangePercent)
+	require.Equal(t, map[string]any{"amount": "120.00", "currencyCode": "USD"}, patches["1"]["dailyBudget"])
+
+	require.Equal(t, dto.ResultRejected, diffs[1].Result)
+	require.Len(t, diffs[1].Violations, 1)
+	require.Contains(t, diffs[1].Violations[0], "exceeds the maximum of 50.0%")
+
+	require.Equal(t, dto.ResultRejected, diffs[2].Result)
+	require.Contains(t, diffs[2].Violations[0], "exceeds the configured ceiling")
+	require.NotContains(t, patches, "2")
+	require.NotContains(t, patches, "3")
+}
+
+func TestPlanDiffs_SettingUnsetOrZeroAmountNeedsCeiling(t *testing.T) {
+	tool := newTestTool(Guardrails{
+		MaxChangePercent: 50,
+		UnitCostCeilings: map[string]float64{"USD": 20},
+	})
+	elements := []map[string]any{
+		{"id": float64(1), "name": "No total budget"},
+		{"id": float64(2), "name": "Zero total budget", "totalBudget": map[string]any{"amount": "0", "currencyCode": "USD"}},
+		{"id": float64(3), "name": "No unit cost"},
+	}
+
+	diffs, patches := tool.planDiffs([]campaignUpdate{
+		{campaignID: "1", totalBudget: floatPtr(100000)},
+		{campaignID: "2", totalBudget: floatPtr(100000)},
+		{campaignID: "3", unitCost: floatPtr(15)},
+	}, elements, "USD")
+
+	require.Equal(t, dto.ResultRejected, diffs[0].Result)
+	require.Contains(t, diffs[0].Violations[0], "no ceiling is configured for USD")
+	require.Equal(t, dto.ResultRejected, diffs[1].Result)
+	require.Contains(t, diffs[1].Violations[0], "no ceiling is configured for USD")
+	require.Equal(t, dto.ResultPending, diffs[2].Result)
+	require.NotContains(t, patches, "1")
+	require.NotContains(t, patches, "2")
+	require.Contains(t, patches, "3")
+}
+
+func TestGuardrails_ComparesUnroundedPercent(t *testing.T) {
+	guardrails := Guardrails{MaxChangePercent: 50}
+
+	require.Empty(t, guardrails.checkAmount(fieldDailyBudget, "USD", floatPtr(100), 150))
+	violations := guardrails.checkAmount(fieldDailyBudget, "USD", floatPtr(100), 150.04)
+	require.Len(t, violations, 1)
+	require.Contains(t, violations[0], "exceeds the maximum of 50.0%")
+}
+
+func TestPlanDiffs_RejectsCurrencyMismatch(t *testing.T) {
+	tool := newTestTool(Guardrails{})
+	elements := []map[string]any{
+		{"id": float64(1), "unitCost": map[string]any{"amount": "5.00", "currencyCode": "EUR"}},
+	}
+
+	diffs, patches := tool.planDiffs([]campaignUpdate{{campaignID: "1", unitCost: floatPtr(6)}}, elements, "USD")
+	require.Equal(t, dto.ResultRejected, diffs[0].Result)
+	require.Contains(t, diffs[0].Violations[0], "ad account currency is USD")
+	require.Empty(t, patches)
+}
+
+func TestPlanDiffs_EndDateKeepsRunScheduleStart(t *testing.T) {
+	tool := newTestTool(Guardrails{})
+	start := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	elements := []map[string]any{
+		{"id": float64(1), "runSchedule": map[string]any{"start": float64(start)}},
+	}
+	end, err := endOfDay(dto.Date{Year: 2026, Month: 6, Day: 30})
+	require.NoError(t, err)
+
+	diffs, patches := tool.planDiffs([]campaignUpdate{{campaignID: "1", endTime: &end}}, elements, "USD")
+	require.Equal(t, dto.ResultPending, diffs[0].Result)
+	require.Equal(t, "2026-06-30", diffs[0].Changes[0].After)
+	require.Equal(t, map[string]any{"start": start, "end": end.UnixMilli()}, patches["1"]["runSchedule"])
+}
+
+func TestPlanToken_ChangesWithDiff(t *testing.T) {
+	first := []dto.CampaignDiff{{CampaignID: "1", Result: dto.ResultPending, Changes: []dto.FieldChange{{Field: "dailyBudget", Before: "100.00", After: "120.00"}}}}
+	second := []dto.CampaignDiff{{CampaignID: "1", Result: dto.ResultPending, Changes: []dto.FieldChange{{Field: "dailyBudget", Before: "110.00", After: "120.00"}}}}
+
+	require.NotEqual(t, planToken("9", "USD", first), planToken("9", "USD", second))
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
+
+func TestValidateInput_RejectsNonFiniteAndSubCentAmounts(t *testing.T) {
+	tool := newTestTool(Guardrails{})
+
+	for _, tc := range []struct {
+		amount string
+		err    string
+	}{
+		{amount: "NaN", err: "must be a decimal amount"},
+		{amount: "Inf", err: "must be a decimal amount"},
+		{amount: "-Inf", err: "must be a decimal amount"},
+		{amount: "0.001", err: "at most two decimal places"},
+		{amount: "150.005", err: "at most two decimal places"},
+		{amount: "0", err: "greater than zero"},
+	} {
+		_, err := tool.validateInput(&dto.Input{
+			AccountID: "512247261",
+			Updates:   []dto.CampaignUpdate{{CampaignID: "1", DailyBudget: stringPtr(tc.amount)}},
+		})
+		require.ErrorContains(t, err, tc.err, tc.amount)
+	}
+
+	updates, err := tool.validateInput(&dto.Input{
+		AccountID: "512247261",
+		Updates:   []dto.CampaignUpdate{{CampaignID: "1", DailyBudget: stringPtr("150.10")}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "150.10", formatAmount(*updates[0].dailyBudget))
+}
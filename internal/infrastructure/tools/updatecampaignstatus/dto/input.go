@@ -0,0 +1,9 @@
+package dto
+
+type Input struct {
+	AccountID         string   `json:"accountID" jsonschema:"LinkedIn Ad Account ID (numeric value, e.g., 512345678)"`
+	CampaignIDs       []string `json:"campaignIDs" jsonschema:"Campaigns to update, as numeric IDs or URNs (urn:li:sponsoredCampaign:{id}). Up to 50 per call"`
+	Status            string   `json:"status" jsonschema:"Target status: PAUSED (pause), ACTIVE (resume) or ARCHIVED (archive)"`
+	Confirm           bool     `json:"confirm,omitempty" jsonschema:"Set to true together with confirmationToken to apply a change previously returned as confirmation_required"`
+	ConfirmationToken string   `json:"confirmationToken,omitempty" jsonschema:"Token returned by the previous confirmation_required response; must be echoed unchanged"`
+}
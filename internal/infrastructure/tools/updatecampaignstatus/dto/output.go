@@ -0,0 +1,36 @@
+package dto
+
+// Outcomes reported in Output.State.
+const (
+	StateConfirmationRequired = "confirmation_required"
+	StateApplied              = "applied"
+	StateDeclined             = "declined"
+	StateNoChanges            = "no_changes"
+)
+
+// Per-campaign results reported in CampaignChange.Result.
+const (
+	ResultPending   = "pending"
+	ResultUpdated   = "updated"
+	ResultUnchanged = "unchanged"
+	ResultRejected  = "rejected"
+	ResultNotFound  = "not_found"
+	ResultFailed    = "failed"
+)
+
+type Output struct {
+	State             string           `json:"state" jsonschema:"confirmation_required, applied, declined or no_changes"`
+	Message           string           `json:"message,omitempty" jsonschema:"Human-readable summary of what happened or what must be confirmed"`
+	ConfirmationToken string           `json:"confirmationToken,omitempty" jsonschema:"Echo this token with confirm=true to apply the planned changes"`
+	Campaigns         []CampaignChange `json:"campaigns" jsonschema:"Before/after status per requested campaign"`
+}
+
+type CampaignChange struct {
+	CampaignID   string `json:"campaignID" jsonschema:"Numeric campaign ID"`
+	CampaignURN  string `json:"campaignURN" jsonschema:"Campaign URN"`
+	Name         string `json:"name,omitempty" jsonschema:"Campaign name"`
+	BeforeStatus string `json:"beforeStatus,omitempty" jsonschema:"Status before the change"`
+	AfterStatus  string `json:"afterStatus,omitempty" jsonschema:"Status after the change (planned status while confirmation is pending)"`
+	Result       string `json:"result" jsonschema:"pending, updated, unchanged, rejected, not_found or failed"`
+	Reason       string `json:"reason,omitempty" jsonschema:"Why the campaign was rejected or failed"`
+}
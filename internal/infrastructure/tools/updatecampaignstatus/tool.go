@@ -0,0 +1,261 @@
+package updatecampaignstatus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"linkedin-mcp/internal/infrastructure/api/campaigns"
+	"linkedin-mcp/internal/infrastructure/api/gateway"
+	"linkedin-mcp/internal/infrastructure/tools/confirmation"
+	"linkedin-mcp/internal/infrastructure/tools/toolerrors"
+	"linkedin-mcp/internal/infrastructure/tools/updatecampaignstatus/dto"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxCampaignsPerCall = 50
+
+// targetStatuses are the statuses this tool may set: pause, resume and archive.
+var targetStatuses = map[string]bool{
+	campaigns.StatusActive:   true,
+	campaigns.StatusPaused:   true,
+	campaigns.StatusArchived: true,
+}
+
+type Tool struct {
+	repository *campaigns.Repository
+	connectURL string
+}
+
+func NewTool(repository *campaigns.Repository, connectURL string) *Tool {
+	return &Tool{repository: repository, connectURL: connectURL}
+}
+
+// UpdateCampaignStatus plans a status change for the requested campaigns, asks for
+// confirmation and only then applies it. Confirmation comes from MCP elicitation when the
+// client supports it; otherwise the tool returns confirmation_required with a token that
+// the caller must echo back with confirm=true.
+func (t *Tool) UpdateCampaignStatus(ctx context.Context, req *mcp.CallToolRequest, input dto.Input) (*mcp.CallToolResult, dto.Output, error) {
+	result := &mcp.CallToolResult{}
+
+	campaignIDs, err := validateInput(&input)
+	if err != nil {
+		return result, dto.Output{}, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	// Plans and guardrails must be computed against live state, never a cached read.
+	ctx = gateway.WithCacheBypass(ctx)
+	current, err := t.repository.SearchCampaigns(ctx, campaigns.SearchInput{
+		AccountID:    input.AccountID,
+		CampaignURNs: campaignURNs(campaignIDs),
+		PageSize:     maxCampaignsPerCall,
+	})
+	if err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("look up campaigns", err, t.connectURL)
+	}
+
+	changes := planChanges(campaignIDs, current.Elements, input.Status)
+	if countResult(changes, dto.ResultPending) == 0 {
+		return result, dto.Output{
+			State:     dto.StateNoChanges,
+			Message:   "No campaign needs a status change; see per-campaign results.",
+			Campaigns: changes,
+		}, nil
+	}
+
+	token := planToken(input.AccountID, input.Status, changes)
+	if !input.Confirm || input.ConfirmationToken != token {
+		switch confirmation.Elicit(ctx, req, confirmationMessage(input.Status, changes)) {
+		case confirmation.Declined:
+			return result, dto.Output{
+				State:     dto.StateDeclined,
+				Message:   "The user declined the status change; nothing was modified.",
+				Campaigns: changes,
+			}, nil
+		case confirmation.Unavailable:
+			message := "Review the planned changes with the user, then call this tool again with confirm=true and the same confirmationToken."
+			if input.Confirm {
+				message = "The confirmationToken does not match the current campaign state. " + message
+			}
+			return result, dto.Output{
+				State:             dto.StateConfirmationRequired,
+				Message:           message,
+				ConfirmationToken: token,
+				Campaigns:         changes,
+			}, nil
+		}
+	}
+
+	if err := t.applyChanges(ctx, input.AccountID, input.Status, changes); err != nil {
+		return result, dto.Output{}, toolerrors.WrapToolExecutionError("update campaign status", err, t.connectURL)
+	}
+
+	return result, dto.Output{
+		State:     dto.StateApplied,
+		Message:   fmt.Sprintf("%d campaign(s) updated, %d failed.", countResult(changes, dto.ResultUpdated), countResult(changes, dto.ResultFailed)),
+		Campaigns: changes,
+	}, nil
+}
+
+// applyChanges issues one partial update per pending campaign so a single LinkedIn rejection
+// is reported on that campaign instead of failing the whole batch. Losing the LinkedIn
+// connection mid-way aborts, because every remaining call would fail the same way.
+func (t *Tool) applyChanges(ctx context.Context, accountID, status string, changes []dto.CampaignChange) error {
+	for i := range changes {
+		if changes[i].Result != dto.ResultPending {
+			continue
+		}
+
+		err := t.repository.UpdateCampaign(ctx, campaigns.UpdateInput{
+			AccountID:  accountID,
+			CampaignID: changes[i].CampaignID,
+			Set:        map[string]any{"status": status},
+		})
+		if gateway.IsLinkedInNotConnected(err) {
+			return err
+		}
+		if err != nil {
+			changes[i].Result = dto.ResultFailed
+			changes[i].AfterStatus = changes[i].BeforeStatus
+			changes[i].Reason = err.Error()
+			continue
+		}
+		changes[i].Result = dto.ResultUpdated
+	}
+	return nil
+}
+
+func validateInput(input *dto.Input) ([]string, error) {
+	input.AccountID = strings.TrimSpace(input.AccountID)
+	if input.AccountID == "" {
+		return nil, fmt.Errorf("accountID is required")
+	}
+	for _, r := range input.AccountID {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("accountID must contain only digits")
+		}
+	}
+
+	input.Status = strings.ToUpper(strings.TrimSpace(input.Status))
+	if !targetStatuses[input.Status] {
+		return nil, fmt.Errorf("status must be one of: ACTIVE, PAUSED, ARCHIVED")
+	}
+
+	if len(input.CampaignIDs) == 0 {
+		return nil, fmt.Errorf("campaignIDs is required and cannot be empty")
+	}
+	if len(input.CampaignIDs) > maxCampaignsPerCall {
+		return nil, fmt.Errorf("campaignIDs cannot exceed %d entries", maxCampaignsPerCall)
+	}
+
+	seen := make(map[string]struct{}, len(input.CampaignIDs))
+	ids := make([]string, 0, len(input.CampaignIDs))
+	for i, reference := range input.CampaignIDs {
+		id, err := campaigns.ParseCampaignID(reference)
+		if err != nil {
+			return nil, fmt.Errorf("campaignIDs[%d]: %w", i, err)
+		}
+		if _, exists := seen[id]; exists {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	input.ConfirmationToken = strings.TrimSpace(input.ConfirmationToken)
+	return ids, nil
+}
+
+// planChanges compares the current state of every requested campaign with the target status.
+// Campaigns missing from the account, already in the target status, or in a status that cannot
+// be moved to the target are reported but never updated.
+func planChanges(campaignIDs []string, elements []map[string]any, target string) []dto.CampaignChange {
+	byID := make(map[string]map[string]any, len(elements))
+	for _, element := range elements {
+		if id := campaigns.ElementID(element); id != "" {
+			byID[id] = element
+		}
+	}
+
+	changes := make([]dto.CampaignChange, 0, len(campaignIDs))
+	for _, id := range campaignIDs {
+		change := dto.CampaignChange{
+			CampaignID:  id,
+			CampaignURN: campaigns.CampaignURN(id),
+		}
+
+		element, ok := byID[id]
+		if !ok {
+			change.Result = dto.ResultNotFound
+			change.Reason = "campaign not found in this ad account"
+			changes = append(changes, change)
+			continue
+		}
+
+		change.Name, _ = element["name"].(string)
+		change.BeforeStatus, _ = element["status"].(string)
+		change.AfterStatus = change.BeforeStatus
+
+		switch {
+		case change.BeforeStatus == target:
+			change.Result = dto.ResultUnchanged
+		case !campaigns.CanTransitionStatus(change.BeforeStatus, target):
+			change.Result = dto.ResultRejected
+			change.Reason = fmt.Sprintf("cannot change status from %s to %s", change.BeforeStatus, target)
+		default:
+			change.Result = dto.ResultPending
+			change.AfterStatus = target
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// planToken binds a confirmation to the exact plan, including each campaign's current status,
+// so a token issued before someone else changed a campaign is no longer accepted.
+func planToken(accountID, target string, changes []dto.CampaignChange) string {
+	parts := []string{accountID, target}
+	for _, change := range changes {
+		if change.Result != dto.ResultPending {
+			continue
+		}
+		parts = append(parts, change.CampaignID+":"+change.BeforeStatus)
+	}
+	return confirmation.Token(parts...)
+}
+
+func confirmationMessage(target string, changes []dto.CampaignChange) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Change the status of %d LinkedIn campaign(s) to %s?", countResult(changes, dto.ResultPending), target)
+	for _, change := range changes {
+		if change.Result != dto.ResultPending {
+			continue
+		}
+		name := change.Name
+		if name == "" {
+			name = change.CampaignURN
+		}
+		fmt.Fprintf(&b, "\n- %s (%s): %s -> %s", name, change.CampaignID, change.BeforeStatus, change.AfterStatus)
+	}
+	return b.String()
+}
+
+func campaignURNs(ids []string) []string {
+	urns := make([]string, len(ids))
+	for i, id := range ids {
+		urns[i] = campaigns.CampaignURN(id)
+	}
+	return urns
+}
+
+func countResult(changes []dto.CampaignChange, result string) int {
+	count := 0
+	for _, change := range changes {
+		if change.Result == result {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,59 @@
+package updatecampaignstatus
+
+import (
+	"testing"
+
+	"linkedin-mcp/internal/infrastructure/tools/updatecampaignstatus/dto"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInput_NormalizesCampaignReferences(t *testing.T) {
+	input := dto.Input{
+		AccountID:   " 512247261 ",
+		CampaignIDs: []string{"urn:li:sponsoredCampaign:1", "2", "1"},
+		Status:      "paused",
+	}
+
+	ids, err := validateInput(&input)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, ids)
+	require.Equal(t, "PAUSED", input.Status)
+	require.Equal(t, "512247261", input.AccountID)
+}
+
+func TestValidateInput_RejectsUnsupportedTarget(t *testing.T) {
+	for _, status := range []string{"", "DRAFT", "REMOVED", "COMPLETED"} {
+		_, err := validateInput(&dto.Input{AccountID: "1", CampaignIDs: []string{"2"}, Status: status})
+		require.Error(t, err, status)
+	}
+}
+
+func TestPlanChanges(t *testing.T) {
+	elements := []map[string]any{
+		{"id": float64(1), "name": "Active one", "status": "ACTIVE"},
+		{"id": float64(2), "name": "Already paused", "status": "PAUSED"},
+		{"id": float64(3), "name": "Done", "status": "COMPLETED"},
+	}
+
+	changes := planChanges([]string{"1", "2", "3", "4"}, elements, "PAUSED")
+	require.Len(t, changes, 4)
+
+	require.Equal(t, dto.ResultPending, changes[0].Result)
+	require.Equal(t, "ACTIVE", changes[0].BeforeStatus)
+	require.Equal(t, "PAUSED", changes[0].AfterStatus)
+	require.Equal(t, "Active one", changes[0].Name)
+
+	require.Equal(t, dto.ResultUnchanged, changes[1].Result)
+	require.Equal(t, dto.ResultRejected, changes[2].Result)
+	require.Equal(t, "COMPLETED", changes[2].AfterStatus)
+	require.Equal(t, dto.ResultNotFound, changes[3].Result)
+}
+
+func TestPlanToken_ChangesWithCampaignState(t *testing.T) {
+	before := planChanges([]string{"1"}, []map[string]any{{"id": float64(1), "status": "ACTIVE"}}, "ARCHIVED")
+	after := planChanges([]string{"1"}, []map[string]any{{"id": float64(1), "status": "PAUSED"}}, "ARCHIVED")
+
+	require.Equal(t, planToken("9", "ARCHIVED", before), planToken("9", "ARCHIVED", before))
+	require.NotEqual(t, planToken("9", "ARCHIVED", before), planToken("9", "ARCHIVED", after))
+}
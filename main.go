@@ -1,9 +1,14 @@
 package main
 
 import (
+	"flag"
+
 	"linkedin-mcp/internal/app"
 )
 
 func main() {
-	app.Start()
+	transport := flag.String("transport", app.TransportHTTP, "MCP transport: http (streamable HTTP behind Clerk auth) or stdio (local single-user process)")
+	flag.Parse()
+
+	app.Start(*transport)
 }